@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchRecord is one input row.  A row that could not be read carries its error instead.
+type batchRecord struct {
+	Row       int
+	Operation string
+	Number1   float64
+	Number2   float64
+	Err       error
+}
+
+// batchOutcome is one output row, in the same order as the input.
+type batchOutcome struct {
+	Row       int     `json:"row"`
+	Operation string  `json:"operation"`
+	Number1   number  `json:"number1"`
+	Number2   number  `json:"number2"`
+	Result    *number `json:"result,omitempty"`
+	Code      string  `json:"code"`
+	Error     string  `json:"error,omitempty"`
+}
+
+var batchColumns = []string{"row", "operation", "number1", "number2", "result", "code", "error"}
+
+// runBatch is the batch command.
+func runBatch(o *options, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("batch", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		in       = flags.String("in", "-", "File of operations to run, - for stdin")
+		out      = flags.String("out", "-", "File to write results to, - for stdout")
+		format   = flags.String("format", "", "Input format, ndjson or csv, by default from the -in extension")
+		parallel = flags.Int("parallel", 8, "Calls in flight at once")
+		resume   = flags.Bool("resume", false, "Skip the rows already in -out and append the rest")
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, `Usage: client [flags] batch [-in file] [-out file] [-resume]
+
+Input is newline delimited JSON, {"operation": "add", "number1": 3, "number2": 4} per line,
+or CSV with an operation,number1,number2 header.  Output follows the input format unless -o
+asks for json or csv, one row per input row in input order, with the result or the error.
+JSON output has its numbers as strings, such as "0.5", "NaN" or "+Inf".
+
+`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	inFormat := batchFormat(*in, *format)
+	outFormat := inFormat
+	switch o.format {
+	case "json":
+		outFormat = "ndjson"
+	case "csv":
+		outFormat = "csv"
+	}
+
+	switch {
+	case flags.NArg() > 0:
+		fmt.Fprintf(stderr, "client: batch takes no operands, got %q\n", flags.Arg(0))
+		return exitUsage
+	case inFormat != "ndjson" && inFormat != "csv":
+		fmt.Fprintf(stderr, "client: unknown batch format %q, want ndjson or csv\n", inFormat)
+		return exitUsage
+	case *parallel < 1:
+		fmt.Fprintf(stderr, "client: -parallel must be at least 1\n")
+		return exitUsage
+	case *resume && *out == "-":
+		fmt.Fprintf(stderr, "client: -resume needs an -out file to resume from\n")
+		return exitUsage
+	}
+
+	input, err := openBatchInput(*in)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	defer input.Close()
+
+	var (
+		output io.Writer = stdout
+		done   int
+		empty  = true
+	)
+	if *out != "-" {
+		f, n, kept, err := openBatchOutput(*out, *resume)
+		if err != nil {
+			fmt.Fprintf(stderr, "client: %s\n", err.Error())
+			return exitFailure
+		}
+		defer f.Close()
+		output, done, empty = f, n, !kept
+	}
+
+	records, err := newBatchReader(input, inFormat)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	writer := newBatchWriter(output, outFormat, empty)
+
+	conn, code := o.connect(stderr)
+	if conn == nil {
+		return code
+	}
+	defer conn.Close()
+
+	failed, err := processBatch(pb.NewMathClient(conn), o, records, done, *parallel, writer)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	if failed > 0 {
+		fmt.Fprintf(stderr, "client: %d rows failed\n", failed)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// batchFormat is the format asked for, or else the one the input file's extension suggests.
+func batchFormat(path, format string) string {
+	if format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return "csv"
+	}
+	return "ndjson"
+}
+
+// openBatchInput opens the input file, - is stdin.
+func openBatchInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return ioutil.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+// openBatchOutput opens the output file, for a resume it also reports how many rows it
+// already holds and whether it kept anything, a CSV header included, to write after.  A row
+// cut short by a crash is dropped so it is run again.
+func openBatchOutput(path string, resume bool) (*os.File, int, bool, error) {
+	if !resume {
+		f, err := os.Create(path)
+		return f, 0, false, err
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	contents, err := ioutil.ReadAll(f)
+	if err != nil {
+		f.Close()
+		return nil, 0, false, err
+	}
+
+	complete := bytes.LastIndexByte(contents, '\n') + 1
+	lines := bytes.Count(contents[:complete], []byte("\n"))
+	if complete > 0 && bytes.HasPrefix(contents, []byte(batchColumns[0]+",")) {
+		lines-- // The CSV header is not a row.
+	}
+	if err := f.Truncate(int64(complete)); err != nil {
+		f.Close()
+		return nil, 0, false, err
+	}
+	if _, err := f.Seek(int64(complete), io.SeekStart); err != nil {
+		f.Close()
+		return nil, 0, false, err
+	}
+	return f, lines, complete > 0, nil
+}
+
+// batchReader yields input rows until io.EOF.
+type batchReader interface {
+	Next() (batchRecord, error)
+}
+
+func newBatchReader(r io.Reader, format string) (batchReader, error) {
+	if format == "csv" {
+		return newCSVBatchReader(r)
+	}
+	return &jsonBatchReader{scanner: bufio.NewScanner(r)}, nil
+}
+
+// jsonBatchReader reads one JSON object per line, blank lines are skipped.
+type jsonBatchReader struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func (r *jsonBatchReader) Next() (batchRecord, error) {
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		r.row++
+
+		var fields struct {
+			Operation string   `json:"operation"`
+			Number1   *float64 `json:"number1"`
+			Number2   *float64 `json:"number2"`
+		}
+		record := batchRecord{Row: r.row}
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			record.Err = fmt.Errorf("unreadable row: %s", err.Error())
+			return record, nil
+		}
+		record.Operation = fields.Operation
+		if fields.Number1 == nil || fields.Number2 == nil {
+			record.Err = fmt.Errorf("number1 and number2 are required")
+			return record, nil
+		}
+		record.Number1, record.Number2 = *fields.Number1, *fields.Number2
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return batchRecord{}, err
+	}
+	return batchRecord{}, io.EOF
+}
+
+// csvBatchReader reads CSV rows, finding the columns by the names in the header.
+type csvBatchReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVBatchReader(r io.Reader) (*csvBatchReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CSV header: %s", err.Error())
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"operation", "number1", "number2"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the CSV header has no %s column", name)
+		}
+	}
+	return &csvBatchReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvBatchReader) Next() (batchRecord, error) {
+	fields, err := r.reader.Read()
+	if err == io.EOF {
+		return batchRecord{}, io.EOF
+	}
+	r.row++
+	record := batchRecord{Row: r.row}
+	if err != nil {
+		if _, ok := err.(*csv.ParseError); !ok {
+			return batchRecord{}, err
+		}
+		record.Err = fmt.Errorf("unreadable row: %s", err.Error())
+		return record, nil
+	}
+
+	field := func(name string) string {
+		if i := r.columns[name]; i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	record.Operation = field("operation")
+	if record.Number1, err = strconv.ParseFloat(field("number1"), 64); err != nil {
+		record.Err = fmt.Errorf("number1 %q is not a number", field("number1"))
+		return record, nil
+	}
+	if record.Number2, err = strconv.ParseFloat(field("number2"), 64); err != nil {
+		record.Err = fmt.Errorf("number2 %q is not a number", field("number2"))
+	}
+	return record, nil
+}
+
+// batchWriter writes output rows in the output format.
+type batchWriter interface {
+	Write(batchOutcome) error
+	Flush() error
+}
+
+func newBatchWriter(w io.Writer, format string, header bool) batchWriter {
+	if format == "csv" {
+		return &csvBatchWriter{w: csv.NewWriter(w), header: header}
+	}
+	return &jsonBatchWriter{enc: json.NewEncoder(w)}
+}
+
+type jsonBatchWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonBatchWriter) Write(o batchOutcome) error { return w.enc.Encode(o) }
+func (w *jsonBatchWriter) Flush() error               { return nil }
+
+type csvBatchWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (w *csvBatchWriter) Write(o batchOutcome) error {
+	if w.header {
+		w.header = false
+		if err := w.w.Write(batchColumns); err != nil {
+			return err
+		}
+	}
+	result := ""
+	if o.Result != nil {
+		result = o.Result.String()
+	}
+	return w.w.Write([]string{strconv.Itoa(o.Row), o.Operation, o.Number1.String(), o.Number2.String(), result, o.Code, o.Error})
+}
+
+func (w *csvBatchWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// processBatch runs every row after the first skip with up to parallel calls in flight, and
+// writes the outcomes in input order.  It returns the number of rows that failed.
+func processBatch(client pb.MathClient, o *options, records batchReader, skip, parallel int, out batchWriter) (int, error) {
+	type job struct {
+		record batchRecord
+		done   chan batchOutcome
+	}
+	var (
+		work    = make(chan job)
+		ordered = make(chan job, parallel)
+		readErr error
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range work {
+				j.done <- runBatchRecord(client, o, j.record)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(ordered)
+		defer close(work)
+		for {
+			record, err := records.Next()
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+			if record.Row <= skip {
+				continue
+			}
+			j := job{record: record, done: make(chan batchOutcome, 1)}
+			ordered <- j
+			work <- j
+		}
+	}()
+
+	failed := 0
+	var writeErr error
+	for j := range ordered {
+		outcome := <-j.done
+		if outcome.Code != codes.OK.String() {
+			failed++
+		}
+		if writeErr == nil {
+			writeErr = out.Write(outcome)
+		}
+		if writeErr == nil && len(ordered) == 0 {
+			// Nothing else is ready, so let what is done so far reach the file.
+			writeErr = out.Flush()
+		}
+	}
+	wg.Wait()
+
+	if writeErr == nil {
+		writeErr = out.Flush()
+	}
+	if readErr != nil {
+		return failed, readErr
+	}
+	return failed, writeErr
+}
+
+// runBatchRecord makes the call for one row.
+func runBatchRecord(client pb.MathClient, o *options, record batchRecord) batchOutcome {
+	outcome := batchOutcome{Row: record.Row, Operation: record.Operation, Number1: number(record.Number1), Number2: number(record.Number2)}
+
+	err := record.Err
+	if err == nil {
+		if cmd, ok := findCommand(record.Operation); ok {
+			ctx, cancel := o.context()
+			var response *pb.MathResponse
+			response, err = cmd.Call(client, ctx, &pb.MathRequest{Number1: record.Number1, Number2: record.Number2})
+			cancel()
+			if err == nil {
+				result := number(response.Result)
+				outcome.Result = &result
+			}
+		} else {
+			err = fmt.Errorf("unknown operation %q", record.Operation)
+		}
+	}
+
+	if err != nil {
+		s, ok := status.FromError(err)
+		if !ok {
+			// The row itself was bad, it never reached the service.
+			s = status.New(codes.InvalidArgument, err.Error())
+		}
+		outcome.Code = s.Code().String()
+		outcome.Error = strings.Replace(s.Message(), "\n", " ", -1)
+		return outcome
+	}
+	outcome.Code = codes.OK.String()
+	return outcome
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProcessBatch(t *testing.T) {
+	var cases = []struct {
+		Case   string
+		Format string
+		Output string
+		Input  string
+		Want   string
+		Failed int
+	}{
+		{
+			Case:   "JSON",
+			Format: "ndjson",
+			Output: "ndjson",
+			Input: `{"operation": "add", "number1": 3, "number2": 4}
+
+{"operation": "divide", "number1": 1, "number2": 0}
+{"operation": "subtract", "number1": 1, "number2": 2}
+{"operation": "multiply", "number1": 2}
+not json
+{"operation": "MultiplyNumber", "number1": 2, "number2": 5}
+`,
+			Want: `{"row":1,"operation":"add","number1":"3","number2":"4","result":"7","code":"OK"}
+{"row":2,"operation":"divide","number1":"1","number2":"0","code":"InvalidArgument","error":"Invalid Request: Number2: 0.000000"}
+{"row":3,"operation":"subtract","number1":"1","number2":"2","code":"InvalidArgument","error":"unknown operation \"subtract\""}
+{"row":4,"operation":"multiply","number1":"0","number2":"0","code":"InvalidArgument","error":"number1 and number2 are required"}
+{"row":5,"operation":"","number1":"0","number2":"0","code":"InvalidArgument","error":"unreadable row: invalid character 'o' in literal null (expecting 'u')"}
+{"row":6,"operation":"MultiplyNumber","number1":"2","number2":"5","result":"10","code":"OK"}
+`,
+			Failed: 4,
+		},
+		{
+			Case:   "CSV",
+			Format: "csv",
+			Output: "csv",
+			Input:  "number2,operation,number1\n4,add,3\nfour,add,3\n0.5,divide,1\n",
+			Want: `row,operation,number1,number2,result,code,error
+1,add,3,4,7,OK,
+2,add,3,0,,InvalidArgument,"number2 ""four"" is not a number"
+3,divide,1,0.5,2,OK,
+`,
+			Failed: 1,
+		},
+		{
+			Case:   "CSV To JSON",
+			Format: "csv",
+			Output: "ndjson",
+			Input:  "operation,number1,number2\nadd,NaN,1\nmultiply,1e308,10\ndivide,-Inf,0\n",
+			Want: `{"row":1,"operation":"add","number1":"NaN","number2":"1","result":"NaN","code":"OK"}
+{"row":2,"operation":"multiply","number1":"1e+308","number2":"10","result":"+Inf","code":"OK"}
+{"row":3,"operation":"divide","number1":"-Inf","number2":"0","code":"InvalidArgument","error":"Invalid Request: Number2: 0.000000"}
+`,
+			Failed: 1,
+		},
+	}
+	for n, c := range cases {
+		records, err := newBatchReader(strings.NewReader(c.Input), c.Format)
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			continue
+		}
+		var out bytes.Buffer
+		failed, err := processBatch(localMath{}, &options{}, records, 0, 3, newBatchWriter(&out, c.Output, true))
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if failed != c.Failed {
+			t.Errorf("Case: %d: %s: Got %d failed rows, want %d", n, c.Case, failed, c.Failed)
+		}
+		if out.String() != c.Want {
+			t.Errorf("Case: %d: %s: Got\n%s\nwant\n%s", n, c.Case, out.String(), c.Want)
+		}
+	}
+}
+
+func TestProcessBatch_Order(t *testing.T) {
+	var input bytes.Buffer
+	for i := 1; i <= 500; i++ {
+		input.WriteString(`{"operation": "add", "number1": ` + itoa(i) + `, "number2": 0}` + "\n")
+	}
+	records, _ := newBatchReader(&input, "ndjson")
+
+	var out bytes.Buffer
+	if _, err := processBatch(localMath{}, &options{}, records, 0, 16, newBatchWriter(&out, "csv", false)); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	for i, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if want := itoa(i+1) + ",add," + itoa(i+1) + ",0," + itoa(i+1) + ",OK,"; line != want {
+			t.Fatalf("Row %d: Got %q, want %q", i+1, line, want)
+		}
+	}
+}
+
+func TestOpenBatchOutput_Resume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mathsvc-batch")
+	if err != nil {
+		t.Fatalf("Unable to make temp dir: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	var cases = []struct {
+		Case     string
+		Contents string
+		Done     int
+		Kept     string
+	}{
+		{Case: "Missing", Contents: "", Done: 0, Kept: ""},
+		{Case: "JSON", Contents: "{\"row\":1}\n{\"row\":2}\n", Done: 2, Kept: "{\"row\":1}\n{\"row\":2}\n"},
+		{Case: "Torn Row", Contents: "{\"row\":1}\n{\"ro", Done: 1, Kept: "{\"row\":1}\n"},
+		{Case: "Torn First Row", Contents: "{\"ro", Done: 0, Kept: ""},
+		{Case: "CSV", Contents: "row,operation\n1,add\n2,add\n3,a", Done: 2, Kept: "row,operation\n1,add\n2,add\n"},
+		{Case: "CSV Header Only", Contents: "row,operation\n", Done: 0, Kept: "row,operation\n"},
+	}
+	for n, c := range cases {
+		path := filepath.Join(dir, c.Case)
+		if c.Contents != "" {
+			if err := ioutil.WriteFile(path, []byte(c.Contents), 0644); err != nil {
+				t.Fatalf("Unable to write: %s", err.Error())
+			}
+		}
+		f, done, nonEmpty, err := openBatchOutput(path, true)
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			continue
+		}
+		f.Close()
+		kept, _ := ioutil.ReadFile(path)
+		if done != c.Done || string(kept) != c.Kept {
+			t.Errorf("Case: %d: %s: Got %d rows %q, want %d rows %q", n, c.Case, done, kept, c.Done, c.Kept)
+		}
+		if nonEmpty != (c.Kept != "") {
+			t.Errorf("Case: %d: %s: Got kept %t for %q", n, c.Case, nonEmpty, kept)
+		}
+	}
+}
+
+func itoa(i int) string {
+	return formatNumber(float64(i))
+}
@@ -0,0 +1,411 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// runBenchMode is the bench command.
+func runBenchMode(o *options, args []string, stdout, stderr io.Writer) int {
+	bench, err := parseBenchOptions(args, o, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitUsage
+	}
+	conn, code := o.connect(stderr)
+	if conn == nil {
+		return code
+	}
+	defer conn.Close()
+
+	report := runBench(pb.NewMathClient(conn), bench)
+	if err := writeBenchReport(stdout, o.format, report); err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	if report.Succeeded < report.Requests {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// benchOptions describe one load run.
+type benchOptions struct {
+	Commands    []command
+	QPS         float64
+	Concurrency int
+	Duration    time.Duration
+	Options     *options
+	Keys        int
+	Zipf        float64
+	Max         int64
+	Seed        int64
+}
+
+// parseBenchOptions reads the flags that follow "bench".
+func parseBenchOptions(args []string, shared *options, stderr io.Writer) (benchOptions, error) {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		op          = flags.String("op", "add", "Command to call, a comma separated list to mix them, or all")
+		qps         = flags.Float64("qps", 0, "Target calls per second, 0 calls as fast as -concurrency allows")
+		concurrency = flags.Int("concurrency", 0, "Calls in flight at once, defaults to 1, or 100 with -qps")
+		duration    = flags.Duration("duration", 10*time.Second, "How long to run for")
+		keys        = flags.Int("keys", 1000, "Distinct operand pairs to draw from, fewer pairs means more cache hits, 0 never repeats")
+		zipf        = flags.Float64("zipf", 0, "Draw pairs with a Zipf skew of this exponent (> 1) instead of uniformly")
+		max         = flags.Int64("max", 1000000, "Operands are whole numbers from 1 to max")
+		seed        = flags.Int64("seed", 0, "Random seed, 0 picks one from the clock")
+	)
+	if err := flags.Parse(args); err != nil {
+		return benchOptions{}, err
+	}
+	if flags.NArg() > 0 {
+		return benchOptions{}, fmt.Errorf("bench takes no operands, got %q", flags.Arg(0))
+	}
+
+	o := benchOptions{QPS: *qps, Concurrency: *concurrency, Duration: *duration, Options: shared, Keys: *keys, Zipf: *zipf, Max: *max, Seed: *seed}
+
+	names := strings.Split(*op, ",")
+	if *op == "all" {
+		names = nil
+		for _, c := range commands {
+			names = append(names, c.Name)
+		}
+	}
+	for _, name := range names {
+		c, ok := findCommand(strings.TrimSpace(name))
+		if !ok {
+			return benchOptions{}, fmt.Errorf("unknown command %q in -op", name)
+		}
+		o.Commands = append(o.Commands, c)
+	}
+
+	switch {
+	case math.IsNaN(o.QPS) || o.QPS < 0:
+		return benchOptions{}, fmt.Errorf("-qps must not be negative")
+	case o.QPS > 0 && time.Duration(float64(time.Second)/o.QPS) == 0:
+		// A call every 0ns would never move the schedule on.
+		return benchOptions{}, fmt.Errorf("-qps must be at most 1e9")
+	case o.Concurrency < 0:
+		return benchOptions{}, fmt.Errorf("-concurrency must not be negative")
+	case o.Duration <= 0:
+		return benchOptions{}, fmt.Errorf("-duration must be positive")
+	case o.Keys < 0:
+		return benchOptions{}, fmt.Errorf("-keys must not be negative")
+	case o.Zipf != 0 && o.Zipf <= 1:
+		return benchOptions{}, fmt.Errorf("-zipf must be greater than 1")
+	case o.Max < 1:
+		return benchOptions{}, fmt.Errorf("-max must be at least 1")
+	}
+	if o.Concurrency == 0 {
+		o.Concurrency = 1
+		if o.QPS > 0 {
+			o.Concurrency = 100
+		}
+	}
+	if o.Seed == 0 {
+		o.Seed = time.Now().UnixNano()
+	}
+	return o, nil
+}
+
+// benchJob is one call to make.
+type benchJob struct {
+	cmd     command
+	request *pb.MathRequest
+}
+
+// operandSource draws the requests for a run, the pool size and skew decide the cache hit rate.
+type operandSource struct {
+	rand *rand.Rand
+	zipf *rand.Zipf
+	pool []*pb.MathRequest
+	max  int64
+	cmds []command
+}
+
+func newOperandSource(o benchOptions) *operandSource {
+	r := rand.New(rand.NewSource(o.Seed))
+	s := &operandSource{rand: r, max: o.Max, cmds: o.Commands}
+	for i := 0; i < o.Keys; i++ {
+		s.pool = append(s.pool, s.fresh())
+	}
+	if o.Zipf > 1 && o.Keys > 1 {
+		s.zipf = rand.NewZipf(r, o.Zipf, 1, uint64(o.Keys-1))
+	}
+	return s
+}
+
+func (s *operandSource) fresh() *pb.MathRequest {
+	return &pb.MathRequest{
+		Number1: float64(s.rand.Int63n(s.max) + 1),
+		Number2: float64(s.rand.Int63n(s.max) + 1),
+	}
+}
+
+func (s *operandSource) next() benchJob {
+	job := benchJob{cmd: s.cmds[s.rand.Intn(len(s.cmds))]}
+	switch {
+	case len(s.pool) == 0:
+		job.request = s.fresh()
+	case s.zipf != nil:
+		job.request = s.pool[s.zipf.Uint64()]
+	default:
+		job.request = s.pool[s.rand.Intn(len(s.pool))]
+	}
+	return job
+}
+
+// benchSample is the outcome of one call.
+type benchSample struct {
+	latency time.Duration
+	code    string
+	cache   string
+}
+
+// runBench drives load at client for the configured duration and summarises it.
+func runBench(client pb.MathClient, o benchOptions) benchReport {
+	source := newOperandSource(o)
+	jobs := make(chan benchJob)
+	if o.QPS > 0 {
+		jobs = make(chan benchJob, o.Concurrency)
+	}
+	results := make([][]benchSample, o.Concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.Concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for job := range jobs {
+				results[i] = append(results[i], benchCall(client, job, o.Options))
+			}
+		}(i)
+	}
+
+	start := time.Now()
+	deadline := start.Add(o.Duration)
+	missed := 0
+	if o.QPS > 0 {
+		// Open loop: calls are due on a fixed schedule whether or not the server keeps up.
+		// Once a full -concurrency worth of calls is waiting, a call that falls due is
+		// counted as missed rather than queued.
+		interval := time.Duration(float64(time.Second) / o.QPS)
+		for due := start; due.Before(deadline); due = due.Add(interval) {
+			time.Sleep(time.Until(due))
+			select {
+			case jobs <- source.next():
+			default:
+				missed++
+			}
+		}
+		time.Sleep(time.Until(deadline))
+	} else {
+		// Closed loop: each worker calls again as soon as its last call returns.
+		for time.Now().Before(deadline) {
+			jobs <- source.next()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	var samples []benchSample
+	for _, r := range results {
+		samples = append(samples, r...)
+	}
+	return summarise(samples, time.Since(start), missed)
+}
+
+// benchCall makes one timed call.
+func benchCall(client pb.MathClient, job benchJob, o *options) benchSample {
+	ctx, cancel := o.context()
+	defer cancel()
+
+	var header metadata.MD
+	start := time.Now()
+	_, err := job.cmd.Call(client, ctx, job.request, grpc.Header(&header))
+	sample := benchSample{latency: time.Since(start), code: status.Code(err).String(), cache: "unknown"}
+	if v := header[mathcache.CacheHeader]; len(v) > 0 {
+		sample.cache = v[0]
+	}
+	return sample
+}
+
+// benchReport is the summary of a run, it is also the JSON output.
+type benchReport struct {
+	Seconds    float64        `json:"seconds"`
+	Requests   int            `json:"requests"`
+	Succeeded  int            `json:"succeeded"`
+	Missed     int            `json:"missed"`
+	Throughput float64        `json:"throughput"`
+	Latency    latencySummary `json:"latency_ms"`
+	Histogram  []histogramBin `json:"histogram"`
+	Codes      map[string]int `json:"codes"`
+	Cache      map[string]int `json:"cache"`
+}
+
+// latencySummary holds latencies in milliseconds.
+type latencySummary struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// histogramBin counts the calls that took at most UpperMs, and more than the previous bin's bound.
+type histogramBin struct {
+	UpperMs float64 `json:"upper_ms"`
+	Count   int     `json:"count"`
+}
+
+// histogramBounds grow by doubling from 0.25ms, anything slower lands in the +Inf bin.
+var histogramBounds = []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, math.Inf(1)}
+
+func summarise(samples []benchSample, elapsed time.Duration, missed int) benchReport {
+	report := benchReport{
+		Seconds:  elapsed.Seconds(),
+		Requests: len(samples),
+		Missed:   missed,
+		Codes:    map[string]int{},
+		Cache:    map[string]int{},
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(len(samples)) / elapsed.Seconds()
+	}
+
+	latencies := make([]float64, len(samples))
+	total := 0.0
+	for i, s := range samples {
+		latencies[i] = float64(s.latency) / float64(time.Millisecond)
+		total += latencies[i]
+		report.Codes[s.code]++
+		report.Cache[s.cache]++
+	}
+	report.Succeeded = report.Codes["OK"]
+	sort.Float64s(latencies)
+
+	if n := len(latencies); n > 0 {
+		report.Latency = latencySummary{
+			Min:  latencies[0],
+			Mean: total / float64(n),
+			P50:  percentile(latencies, 0.50),
+			P90:  percentile(latencies, 0.90),
+			P99:  percentile(latencies, 0.99),
+			Max:  latencies[n-1],
+		}
+	}
+
+	next := 0
+	for _, bound := range histogramBounds {
+		bin := histogramBin{UpperMs: bound}
+		for next < len(latencies) && latencies[next] <= bound {
+			bin.Count++
+			next++
+		}
+		report.Histogram = append(report.Histogram, bin)
+	}
+	return report
+}
+
+// percentile picks the nearest rank from sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// MarshalJSON leaves the +Inf bin's bound out, JSON has no infinity.
+func (b histogramBin) MarshalJSON() ([]byte, error) {
+	if math.IsInf(b.UpperMs, 1) {
+		return json.Marshal(struct {
+			UpperMs *float64 `json:"upper_ms"`
+			Count   int      `json:"count"`
+		}{nil, b.Count})
+	}
+	type plain histogramBin
+	return json.Marshal(plain(b))
+}
+
+// writeBenchReport prints the report as JSON, or as text for any other format.
+func writeBenchReport(w io.Writer, format string, r benchReport) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Requests:\t%d in %.2fs, %.1f/s\n", r.Requests, r.Seconds, r.Throughput)
+	fmt.Fprintf(tw, "Succeeded:\t%d\n", r.Succeeded)
+	if r.Missed > 0 {
+		fmt.Fprintf(tw, "Missed:\t%d, the client fell too far behind to make them\n", r.Missed)
+	}
+	l := r.Latency
+	fmt.Fprintf(tw, "Latency:\tmin %.3fms  mean %.3fms  p50 %.3fms  p90 %.3fms  p99 %.3fms  max %.3fms\n", l.Min, l.Mean, l.P50, l.P90, l.P99, l.Max)
+	fmt.Fprintf(tw, "Codes:\t%s\n", formatCounts(r.Codes))
+	fmt.Fprintf(tw, "Cache:\t%s\n", formatCounts(r.Cache))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nLatency histogram:")
+	widest := 0
+	for _, b := range r.Histogram {
+		if b.Count > widest {
+			widest = b.Count
+		}
+	}
+	for _, b := range r.Histogram {
+		if b.Count == 0 {
+			continue
+		}
+		bound := fmt.Sprintf("<= %gms", b.UpperMs)
+		if math.IsInf(b.UpperMs, 1) {
+			bound = fmt.Sprintf("> %gms", histogramBounds[len(histogramBounds)-2])
+		}
+		bar := strings.Repeat("#", int(math.Ceil(40*float64(b.Count)/float64(widest))))
+		fmt.Fprintf(w, "  %10s %8d %s\n", bound, b.Count, bar)
+	}
+	return nil
+}
+
+// formatCounts prints a count map in a stable order, biggest first.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s %d", k, counts[k])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	var cases = []struct {
+		Case string
+		P    float64
+		Want float64
+	}{
+		{Case: "Median", P: 0.5, Want: 5},
+		{Case: "P90", P: 0.9, Want: 9},
+		{Case: "P99", P: 0.99, Want: 10},
+		{Case: "Zero", P: 0, Want: 1},
+	}
+	for n, c := range cases {
+		if got := percentile(sorted, c.P); got != c.Want {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, c.Case, got, c.Want)
+		}
+	}
+}
+
+func TestParseBenchOptions(t *testing.T) {
+	var cases = []struct {
+		Case        string
+		Args        []string
+		Commands    int
+		Concurrency int
+		WantErr     bool
+	}{
+		{Case: "Defaults", Args: nil, Commands: 1, Concurrency: 1},
+		{Case: "Open Loop", Args: []string{"-qps", "50"}, Commands: 1, Concurrency: 100},
+		{Case: "Mix", Args: []string{"-op", "add,divide"}, Commands: 2, Concurrency: 1},
+		{Case: "All", Args: []string{"-op", "all", "-concurrency", "8"}, Commands: len(commands), Concurrency: 8},
+		{Case: "Unknown Op", Args: []string{"-op", "subtract"}, WantErr: true},
+		{Case: "Flat Zipf", Args: []string{"-zipf", "0.5"}, WantErr: true},
+		{Case: "Operands", Args: []string{"1", "2"}, WantErr: true},
+		{Case: "NaN QPS", Args: []string{"-qps", "NaN"}, WantErr: true},
+		{Case: "Infinite QPS", Args: []string{"-qps", "+Inf"}, WantErr: true},
+		{Case: "QPS Past Nanoseconds", Args: []string{"-qps", "2e9"}, WantErr: true},
+		{Case: "Most QPS", Args: []string{"-qps", "1e9"}, Commands: 1, Concurrency: 100},
+	}
+	for n, c := range cases {
+		o, err := parseBenchOptions(c.Args, &options{}, ioutil.Discard)
+		if c.WantErr {
+			if err == nil {
+				t.Errorf("Case: %d: %s: Expected error, none reported.", n, c.Case)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			continue
+		}
+		if len(o.Commands) != c.Commands || o.Concurrency != c.Concurrency {
+			t.Errorf("Case: %d: %s: Got %d commands at concurrency %d, want %d at %d", n, c.Case, len(o.Commands), o.Concurrency, c.Commands, c.Concurrency)
+		}
+	}
+}
+
+func TestOperandSource_Keys(t *testing.T) {
+	o, err := parseBenchOptions([]string{"-keys", "3", "-seed", "7"}, &options{}, ioutil.Discard)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	source := newOperandSource(o)
+
+	seen := map[[2]float64]bool{}
+	for i := 0; i < 1000; i++ {
+		r := source.next().request
+		if r.Number1 < 1 || r.Number2 < 1 || r.Number1 > float64(o.Max) {
+			t.Fatalf("Operands out of range: %v", r)
+		}
+		seen[[2]float64{r.Number1, r.Number2}] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 distinct pairs from a 3 key pool, got %d", len(seen))
+	}
+}
+
+func TestRunBench(t *testing.T) {
+	for _, args := range [][]string{
+		{"-duration", "100ms", "-concurrency", "4", "-op", "all"},
+		{"-duration", "200ms", "-qps", "100"},
+	} {
+		o, err := parseBenchOptions(args, &options{}, ioutil.Discard)
+		if err != nil {
+			t.Fatalf("Unexpected Error: %s", err.Error())
+		}
+		r := runBench(localMath{}, o)
+
+		if r.Requests == 0 || r.Succeeded != r.Requests || r.Codes["OK"] != r.Requests {
+			t.Errorf("%v: Unexpected counts: %+v", args, r)
+		}
+		if r.Seconds < o.Duration.Seconds() || r.Throughput <= 0 {
+			t.Errorf("%v: Unexpected timing: %+v", args, r)
+		}
+		if l := r.Latency; !(l.Min <= l.P50 && l.P50 <= l.P90 && l.P90 <= l.P99 && l.P99 <= l.Max) {
+			t.Errorf("%v: Percentiles out of order: %+v", args, l)
+		}
+		binned := 0
+		for _, b := range r.Histogram {
+			binned += b.Count
+		}
+		if binned != r.Requests {
+			t.Errorf("%v: Histogram holds %d calls, want %d", args, binned, r.Requests)
+		}
+		if o.QPS > 0 && (r.Requests+r.Missed < 15 || r.Requests+r.Missed > 25) {
+			t.Errorf("%v: Expected about 20 calls due at 100/s over 200ms, got %d", args, r.Requests+r.Missed)
+		}
+	}
+}
+
+func TestWriteBenchReport(t *testing.T) {
+	r := summarise([]benchSample{
+		{latency: time.Millisecond, code: "OK", cache: "hit"},
+		{latency: 10 * time.Second, code: "DeadlineExceeded", cache: "unknown"},
+	}, time.Second, 0)
+
+	var text bytes.Buffer
+	if err := writeBenchReport(&text, "value", r); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	for _, want := range []string{"2 in 1.00s", "DeadlineExceeded 1", "> 4096ms"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Text report does not contain %q:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeBenchReport(&out, "json", r); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	var decoded benchReport
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON report does not decode: %s\n%s", err.Error(), out.String())
+	}
+	if decoded.Requests != 2 || decoded.Codes["OK"] != 1 || decoded.Cache["hit"] != 1 {
+		t.Errorf("Unexpected JSON report: %+v", decoded)
+	}
+}
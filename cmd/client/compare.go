@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/shadow"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	logxi "github.com/mgutz/logxi/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// side is one server's answer to a compared row.
+type side struct {
+	Result  *number `json:"result,omitempty"`
+	Code    string  `json:"code"`
+	Error   string  `json:"error,omitempty"`
+	Latency float64 `json:"latency_ms"`
+}
+
+// comparison is one row run against both servers.
+type comparison struct {
+	Row       int    `json:"row"`
+	Operation string `json:"operation"`
+	Number1   number `json:"number1"`
+	Number2   number `json:"number2"`
+	Verdict   string `json:"verdict"`
+	Primary   side   `json:"primary"`
+	Candidate side   `json:"candidate"`
+}
+
+// compareSummary totals a compare run, it is also the last line of the JSON output.
+type compareSummary struct {
+	Rows      int            `json:"rows"`
+	Verdicts  map[string]int `json:"verdicts"`
+	Primary   latencySummary `json:"primary_latency_ms"`
+	Candidate latencySummary `json:"candidate_latency_ms"`
+	MeanDelta float64        `json:"mean_delta_ms"`
+}
+
+// runCompare is the compare command.
+func runCompare(o *options, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		target    = flags.String("candidate", "", "host:port of the candidate server")
+		plaintext = flags.Bool("candidate-plaintext", o.plaintext, "Talk to the candidate without TLS or JWT")
+		tolerance = flags.Float64("tolerance", 1e-9, "Relative difference allowed between results")
+		in        = flags.String("in", "-", "File of operations to run, - for stdin, in the batch format")
+		format    = flags.String("format", "", "Input format, ndjson or csv, by default from the -in extension")
+		parallel  = flags.Int("parallel", 8, "Rows in flight at once")
+		all       = flags.Bool("all", false, "Report every row, not only those that differ")
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, `Usage: client [flags] compare -candidate host:port [-in file]
+
+Runs every row of a batch file against the usual server and the candidate, then reports the
+rows where they differ, in results beyond -tolerance or in error codes, and how their latency
+compares.  Exits 1 if anything differed.
+
+`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	host, port, err := net.SplitHostPort(*target)
+	switch {
+	case err != nil:
+		fmt.Fprintf(stderr, "client: -candidate %q: %v\n", *target, err)
+		return exitUsage
+	case flags.NArg() > 0:
+		fmt.Fprintf(stderr, "client: compare takes no operands, got %q\n", flags.Arg(0))
+		return exitUsage
+	case *parallel < 1:
+		fmt.Fprintf(stderr, "client: -parallel must be at least 1\n")
+		return exitUsage
+	}
+
+	input, err := openBatchInput(*in)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	defer input.Close()
+	records, err := newBatchReader(input, batchFormat(*in, *format))
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+
+	primaryConn, code := o.connect(stderr)
+	if primaryConn == nil {
+		return code
+	}
+	defer primaryConn.Close()
+	candidateConn, err := dialAddress(logxi.New("mathsvc.client"), host, port, *plaintext, o.insecure)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: unable to connect to the candidate: %s\n", err.Error())
+		return exitFailure
+	}
+	defer candidateConn.Close()
+
+	rows, err := compareAll(pb.NewMathClient(primaryConn), pb.NewMathClient(candidateConn), o, records, *parallel, *tolerance)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+
+	summary := summariseComparisons(rows)
+	if err := writeComparisons(stdout, o.format, rows, summary, *all, [2]string{"primary", "candidate"}); err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	if summary.Verdicts[shadow.Match] < summary.Rows {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// compareAll runs every record against both servers, up to parallel rows at a time, and
+// returns the comparisons in input order.
+func compareAll(primary, candidate pb.MathClient, o *options, records batchReader, parallel int, tolerance float64) ([]comparison, error) {
+	var (
+		work = make(chan batchRecord)
+		rows []comparison
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range work {
+				row := compareRecord(primary, candidate, o, record, tolerance)
+				mu.Lock()
+				rows = append(rows, row)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var readErr error
+	for {
+		record, err := records.Next()
+		if err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			break
+		}
+		work <- record
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Row < rows[j].Row })
+	return rows, readErr
+}
+
+// compareRecord sends one row to both servers at once.
+func compareRecord(primary, candidate pb.MathClient, o *options, record batchRecord, tolerance float64) comparison {
+	row := comparison{Row: record.Row, Operation: record.Operation, Number1: number(record.Number1), Number2: number(record.Number2)}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		row.Primary = compareSide(primary, o, record)
+	}()
+	go func() {
+		defer wg.Done()
+		row.Candidate = compareSide(candidate, o, record)
+	}()
+	wg.Wait()
+
+	row.Verdict = shadow.Compare(row.Primary.outcome(), row.Candidate.outcome(), tolerance)
+	return row
+}
+
+func compareSide(client pb.MathClient, o *options, record batchRecord) side {
+	start := time.Now()
+	outcome := runBatchRecord(client, o, record)
+	return side{
+		Result:  outcome.Result,
+		Code:    outcome.Code,
+		Error:   outcome.Error,
+		Latency: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+}
+
+func (s side) outcome() shadow.Outcome {
+	o := shadow.Outcome{Code: parseCode(s.Code)}
+	if s.Result != nil {
+		o.Result = float64(*s.Result)
+	}
+	return o
+}
+
+func summariseComparisons(rows []comparison) compareSummary {
+	summary := compareSummary{Rows: len(rows), Verdicts: map[string]int{}}
+	primary := make([]benchSample, len(rows))
+	candidate := make([]benchSample, len(rows))
+	delta := 0.0
+	for i, row := range rows {
+		summary.Verdicts[row.Verdict]++
+		primary[i].latency = time.Duration(row.Primary.Latency * float64(time.Millisecond))
+		candidate[i].latency = time.Duration(row.Candidate.Latency * float64(time.Millisecond))
+		delta += row.Candidate.Latency - row.Primary.Latency
+	}
+	summary.Primary = summarise(primary, 0, 0).Latency
+	summary.Candidate = summarise(candidate, 0, 0).Latency
+	if len(rows) > 0 {
+		summary.MeanDelta = delta / float64(len(rows))
+	}
+	return summary
+}
+
+// writeComparisons prints the rows that differ (or all of them) and the summary, as JSON
+// lines, whose numbers are strings so that NaN and the infinities survive, or as text.
+// names label the primary and candidate sides in the text.
+func writeComparisons(w io.Writer, format string, rows []comparison, summary compareSummary, all bool, names [2]string) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		for _, row := range rows {
+			if all || row.Verdict != shadow.Match {
+				if err := enc.Encode(row); err != nil {
+					return err
+				}
+			}
+		}
+		return enc.Encode(struct {
+			Summary compareSummary `json:"summary"`
+		}{summary})
+	}
+
+	answer := func(s side) string {
+		if s.Code != codes.OK.String() {
+			return s.Code
+		}
+		return s.Result.String()
+	}
+	capitalised := func(name string) string { return strings.ToUpper(name[:1]) + name[1:] }
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	header := false
+	for _, row := range rows {
+		if !all && row.Verdict == shadow.Match {
+			continue
+		}
+		if !header {
+			header = true
+			fmt.Fprintf(tw, "ROW\tOPERATION\tNUMBER1\tNUMBER2\t%s\t%s\tVERDICT\n", strings.ToUpper(names[0]), strings.ToUpper(names[1]))
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", row.Row, row.Operation, row.Number1, row.Number2, answer(row.Primary), answer(row.Candidate), row.Verdict)
+	}
+	if header {
+		fmt.Fprintln(tw)
+	}
+	fmt.Fprintf(tw, "Rows:\t%d\n", summary.Rows)
+	fmt.Fprintf(tw, "Verdicts:\t%s\n", formatCounts(summary.Verdicts))
+	p, c := summary.Primary, summary.Candidate
+	fmt.Fprintf(tw, "%s:\tp50 %.3fms  p99 %.3fms  max %.3fms\n", capitalised(names[0]), p.P50, p.P99, p.Max)
+	fmt.Fprintf(tw, "%s:\tp50 %.3fms  p99 %.3fms  max %.3fms\n", capitalised(names[1]), c.P50, c.P99, c.Max)
+	fmt.Fprintf(tw, "Mean delta:\t%+.3fms, %s minus %s\n", summary.MeanDelta, names[1], names[0])
+	return tw.Flush()
+}
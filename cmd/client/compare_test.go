@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// sloppyMath multiplies slightly wrong and divides by zero happily.
+type sloppyMath struct {
+	localMath
+}
+
+func (sloppyMath) MultiplyNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: in.Number1*in.Number2 + 1e-6}, nil
+}
+
+func (sloppyMath) DevideNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	if in.Number2 == 0 {
+		return &pb.MathResponse{}, nil
+	}
+	return localMath{}.DevideNumber(ctx, in, opts...)
+}
+
+func TestCompareAll(t *testing.T) {
+	input := `{"operation": "add", "number1": 3, "number2": 4}
+{"operation": "multiply", "number1": 3, "number2": 4}
+{"operation": "divide", "number1": 1, "number2": 0}
+{"operation": "divide", "number1": 1, "number2": 4}
+{"operation": "nope", "number1": 1, "number2": 4}
+`
+	var cases = []struct {
+		Case      string
+		Tolerance float64
+		Want      []string
+	}{
+		{Case: "Strict", Tolerance: 1e-9, Want: []string{"match", "result_mismatch", "code_mismatch", "match", "match"}},
+		{Case: "Loose", Tolerance: 1e-3, Want: []string{"match", "match", "code_mismatch", "match", "match"}},
+	}
+	for n, c := range cases {
+		records, _ := newBatchReader(strings.NewReader(input), "ndjson")
+		rows, err := compareAll(localMath{}, sloppyMath{}, &options{}, records, 3, c.Tolerance)
+		if err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if len(rows) != len(c.Want) {
+			t.Fatalf("Case: %d: %s: Got %d rows, want %d", n, c.Case, len(rows), len(c.Want))
+		}
+		for i, row := range rows {
+			if row.Row != i+1 || row.Verdict != c.Want[i] {
+				t.Errorf("Case: %d: %s: Row %d: Got row %d %s, want %s", n, c.Case, i+1, row.Row, row.Verdict, c.Want[i])
+			}
+		}
+	}
+}
+
+func TestWriteComparisons(t *testing.T) {
+	seven, eight := number(7), number(8)
+	rows := []comparison{
+		{Row: 1, Operation: "add", Number1: 3, Number2: 4, Verdict: "match", Primary: side{Result: &seven, Code: "OK", Latency: 1}, Candidate: side{Result: &seven, Code: "OK", Latency: 3}},
+		{Row: 2, Operation: "add", Number1: 3, Number2: 5, Verdict: "result_mismatch", Primary: side{Result: &eight, Code: "OK", Latency: 1}, Candidate: side{Result: &seven, Code: "OK", Latency: 1}},
+		{Row: 3, Operation: "divide", Number1: 1, Number2: 0, Verdict: "code_mismatch", Primary: side{Code: "InvalidArgument", Latency: 1}, Candidate: side{Result: &seven, Code: "OK", Latency: 1}},
+	}
+	summary := summariseComparisons(rows)
+	if summary.Verdicts["match"] != 1 || summary.MeanDelta <= 0.6 || summary.MeanDelta >= 0.7 {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+
+	var out bytes.Buffer
+	if err := writeComparisons(&out, "value", rows, summary, false, [2]string{"primary", "candidate"}); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	text := out.String()
+	if strings.Contains(text, "\n1 ") || !strings.Contains(text, "InvalidArgument") || !strings.Contains(text, "result_mismatch 1") {
+		t.Errorf("Unexpected report:\n%s", text)
+	}
+
+	infinity, nan := number(math.Inf(1)), number(math.NaN())
+	rows = []comparison{
+		{Row: 1, Operation: "multiply", Number1: 1e308, Number2: 10, Verdict: "result_mismatch", Primary: side{Result: &infinity, Code: "OK"}, Candidate: side{Result: &nan, Code: "OK"}},
+	}
+	out.Reset()
+	if err := writeComparisons(&out, "json", rows, summariseComparisons(rows), false, [2]string{"primary", "candidate"}); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	want := `{"row":1,"operation":"multiply","number1":"1e+308","number2":"10","verdict":"result_mismatch",` +
+		`"primary":{"result":"+Inf","code":"OK","latency_ms":0},"candidate":{"result":"NaN","code":"OK","latency_ms":0}}` + "\n"
+	if !strings.HasPrefix(out.String(), want) || !strings.Contains(out.String(), `{"summary":`) {
+		t.Errorf("Got\n%s\nwant it to start\n%s", out.String(), want)
+	}
+}
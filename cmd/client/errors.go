@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes.  A failed call exits with exitRPC plus its gRPC status code, so scripts can
+// tell an InvalidArgument (13) from an Unavailable (24) without parsing stderr.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+	exitRPC     = 10
+)
+
+// exitCode maps a call error onto the process exit code.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	code := status.Code(err)
+	if code == codes.OK {
+		return exitFailure
+	}
+	return exitRPC + int(code)
+}
+
+// printError writes a failed call's status, and any details the server attached to it.
+func printError(w io.Writer, method string, err error) {
+	s := status.Convert(err)
+	fmt.Fprintf(w, "%s: %s: %s\n", method, s.Code(), s.Message())
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fmt.Fprintf(w, "  %s: %s\n", v.Field, v.Description)
+			}
+		case proto.Message:
+			fmt.Fprintf(w, "  %s: %s\n", proto.MessageName(d), proto.CompactTextString(d))
+		case error:
+			fmt.Fprintf(w, "  undecodable detail: %s\n", d.Error())
+		}
+	}
+}
+
+// parseCode turns a code's name back into the code, names it does not know are Unknown.
+func parseCode(name string) codes.Code {
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == name {
+			return c
+		}
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errInterrupted is returned by ReadLine when the user hits Ctrl-C.
+var errInterrupted = errors.New("interrupted")
+
+// lineReader reads one line of input at a time, after showing a prompt.
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// newLineReader edits lines in place when in is a terminal, and reads plain lines otherwise.
+func newLineReader(in *os.File, out io.Writer) lineReader {
+	if isTerminal(int(in.Fd())) {
+		return &lineEditor{in: bufio.NewReader(in), fd: int(in.Fd()), out: out}
+	}
+	return newPlainReader(in, out)
+}
+
+// plainReader reads lines from a pipe or file, the prompt is only shown to keep transcripts readable.
+type plainReader struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+func newPlainReader(in io.Reader, out io.Writer) *plainReader {
+	return &plainReader{scanner: bufio.NewScanner(in), out: out}
+}
+
+func (r *plainReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(r.out, prompt)
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// lineEditor is a small emacs style line editor: arrows, Home/End, Ctrl-A/E/B/F/K/U/W,
+// Backspace/Delete, and Up/Down through the lines entered so far.
+type lineEditor struct {
+	in      *bufio.Reader
+	fd      int
+	out     io.Writer
+	history []string
+}
+
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+	restore, err := makeRaw(e.fd)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	var (
+		line   []rune
+		pos    int
+		recall = len(e.history)
+		draft  []rune
+	)
+
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(line))
+		if back := len(line) - pos; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	show := func(r []rune) {
+		line = append([]rune(nil), r...)
+		pos = len(line)
+		redraw()
+	}
+	redraw()
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			s := string(line)
+			if s != "" && (len(e.history) == 0 || e.history[len(e.history)-1] != s) {
+				e.history = append(e.history, s)
+			}
+			return s, nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", errInterrupted
+		case 4: // Ctrl-D, end of input on an empty line, delete otherwise.
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if pos < len(line) {
+				line = append(line[:pos], line[pos+1:]...)
+			}
+		case 1: // Ctrl-A
+			pos = 0
+		case 5: // Ctrl-E
+			pos = len(line)
+		case 2: // Ctrl-B
+			if pos > 0 {
+				pos--
+			}
+		case 6: // Ctrl-F
+			if pos < len(line) {
+				pos++
+			}
+		case 11: // Ctrl-K
+			line = line[:pos]
+		case 21: // Ctrl-U
+			line = append([]rune(nil), line[pos:]...)
+			pos = 0
+		case 23: // Ctrl-W
+			start := pos
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[pos:]...)
+			pos = start
+		case 8, 127: // Backspace
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+			}
+		case 27: // Escape sequences, only the CSI ones matter.
+			if b, _ := e.in.ReadByte(); b != '[' {
+				continue
+			}
+			b, _ := e.in.ReadByte()
+			switch b {
+			case 'A': // Up
+				if recall > 0 {
+					if recall == len(e.history) {
+						draft = append([]rune(nil), line...)
+					}
+					recall--
+					show([]rune(e.history[recall]))
+				}
+			case 'B': // Down
+				if recall < len(e.history) {
+					recall++
+					if recall == len(e.history) {
+						show(draft)
+					} else {
+						show([]rune(e.history[recall]))
+					}
+				}
+			case 'C': // Right
+				if pos < len(line) {
+					pos++
+				}
+			case 'D': // Left
+				if pos > 0 {
+					pos--
+				}
+			case 'H':
+				pos = 0
+			case 'F':
+				pos = len(line)
+			case '3': // Delete is ESC [ 3 ~
+				if t, _ := e.in.ReadByte(); t == '~' && pos < len(line) {
+					line = append(line[:pos], line[pos+1:]...)
+				}
+			}
+		default:
+			if r < ' ' {
+				continue
+			}
+			line = append(line, 0)
+			copy(line[pos+1:], line[pos:])
+			line[pos] = r
+			pos++
+		}
+		redraw()
+	}
+}
@@ -1,120 +1,233 @@
 package main
 
 import (
-	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
+	"io"
+	"net"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/mangeshhendre/grpcutils"
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
-func main() {
-	var (
-		number1    = flag.Float64("number1", 1, "First Number")
-		number2    = flag.Float64("number2", 1, "Second Number")
-		insecure   = flag.Bool("insecure", false, "Should I ignore certificate warnings?")
-		print      = flag.Bool("print", false, "Should I print the results out?")
-		iterations = flag.Int("n", 1, "The number of times to call the service")
-	)
-	flag.Parse()
+// command is one subcommand, backed by one Math RPC.
+type command struct {
+	Name   string
+	Method string
+	Usage  string
+	Call   func(pb.MathClient, context.Context, *pb.MathRequest, ...grpc.CallOption) (*pb.MathResponse, error)
+}
 
-	logger := logxi.New("mathsvc.client")
+var commands = []command{
+	{Name: "add", Method: "AddNumber", Usage: "Add number2 to number1", Call: pb.MathClient.AddNumber},
+	{Name: "multiply", Method: "MultiplyNumber", Usage: "Multiply number1 by number2", Call: pb.MathClient.MultiplyNumber},
+	{Name: "divide", Method: "DevideNumber", Usage: "Divide number1 by number2", Call: pb.MathClient.DevideNumber},
+}
 
-	// Make a suitable grpc client.
-	conn, err := grpcutils.MakeGRPCClientConn(logger,
-		grpcutils.EnvOrDefault("GRPC_AUTH_URL", "https://authentication."+grpcutils.EnvOrDefault("DOMAIN", "sgtec.io")),
-		grpcutils.EnvOrDefault("GRPC_AUTH_USERNAME", "AUTH_URL_UNSET"),
-		grpcutils.EnvOrDefault("GRPC_AUTH_PASSWORD", "AUTH_URL_UNSET"),
-		grpcutils.EnvOrDefault("GRPC_HOST", "mathsvc.grpc."+grpcutils.EnvOrDefault("DOMAIN", "safeguardproperties.com")),
-		grpcutils.EnvOrDefault("GRPC_PORT", "32363"),
-		*insecure,
-	)
+// findCommand looks a subcommand up by name, or by the RPC it calls.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.Name == name || c.Method == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
 
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// options are the flags shared by every command.
+type options struct {
+	insecure  bool
+	plaintext bool
+	format    string
+	timeout   time.Duration
+}
+
+// connect dials the service, a failure is reported to stderr and returned as an exit code.
+func (o *options) connect(stderr io.Writer) (*grpc.ClientConn, int) {
+	conn, err := dial(logxi.New("mathsvc.client"), o.plaintext, o.insecure)
 	if err != nil {
-		panic(logger.Error("I was unable to create a grpc client."))
+		fmt.Fprintf(stderr, "client: unable to create a grpc client: %s\n", err.Error())
+		return nil, exitFailure
 	}
+	return conn, exitOK
+}
 
-	// Create the math client.
-	c := pb.NewMathClient(conn)
-
-	// defer un(trace("GRPC Calls"))
-	for i := 0; i < *iterations; i++ {
-		//getAndMap(*print, logger, c, *propertyLoanID, *orderNumber)
-		doItAgain(*print, logger, c, *number1, *number2)
+// context returns a context bounded by -timeout.
+func (o *options) context() (context.Context, context.CancelFunc) {
+	if o.timeout > 0 {
+		return context.WithTimeout(context.Background(), o.timeout)
 	}
+	return context.WithCancel(context.Background())
+}
 
+// mode is a command that is not a single call, it gets the arguments after its name.
+type mode struct {
+	Name  string
+	Usage string
+	Run   func(o *options, args []string, stdout, stderr io.Writer) int
 }
 
-func doItAgain(print bool, logger logxi.Logger, client pb.MathClient, number1, number2 float64) {
-	request := &pb.MathRequest{
-		Number1: number1,
-		Number2: number2,
-	}
+var modes = []mode{
+	{Name: "repl", Usage: "Evaluate expressions interactively on one connection", Run: runREPL},
+	{Name: "bench", Usage: "Generate load and report latency, see client bench -h", Run: runBenchMode},
+	{Name: "batch", Usage: "Run the operations in a JSON or CSV file, see client batch -h", Run: runBatch},
+	{Name: "compare", Usage: "Run a JSON or CSV file against a candidate server too and diff, see client compare -h", Run: runCompare},
+	{Name: "replay", Usage: "Send the calls in server capture files again and diff, see client replay -h", Run: runReplay},
+}
 
-	if number1 == 0 || number2 == 0 {
-		return
+// run is main without the os.Exit, it returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	o := &options{}
+	flags := flag.NewFlagSet("client", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.BoolVar(&o.insecure, "insecure", false, "Should I ignore certificate warnings?")
+	flags.BoolVar(&o.plaintext, "plaintext", false, "Talk plaintext gRPC without authentication, for a server started with -dev")
+	flags.StringVar(&o.format, "o", "value", "Output format: json, value, table or csv")
+	flags.DurationVar(&o.timeout, "timeout", 10*time.Second, "Deadline for each call, 0 for none")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: client [flags] <command> [number1 number2]\n\nCommands:\n")
+		for _, c := range commands {
+			fmt.Fprintf(stderr, "  %-10s %s\n", c.Name, c.Usage)
+		}
+		for _, m := range modes {
+			fmt.Fprintf(stderr, "  %-10s %s\n", m.Name, m.Usage)
+		}
+		fmt.Fprintf(stderr, "\nOperands may also be given as -number1 and -number2 after the command.\n\nFlags:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(stderr, "\nExit status is 0 on success, 1 on local failure, 2 on bad usage and 10 plus the gRPC status code when the call fails.\n")
 	}
-
-	logger.Info("Calling AddNumber")
-	result, err := client.AddNumber(context.Background(), request)
-	if err != nil {
-		logger.Info("AddNumber: Call Failed", "Request", request, "Error", err.Error())
-		return
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
 	}
-	if print {
-		dumpJSON("AddNumberResult", result)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
 	}
-}
 
-func getAndMap(print bool, logger logxi.Logger, client pb.MathClient, number1, number2 float64) {
-	defer un(trace(fmt.Sprintf("GetAndMap Prop: %f, Order: %f", number1, number2)))
+	for _, m := range modes {
+		if m.Name == flags.Arg(0) {
+			return m.Run(o, flags.Args()[1:], stdout, stderr)
+		}
+	}
 
-	request := &pb.MathRequest{
-		Number1: number1,
-		Number2: number2,
+	cmd, ok := findCommand(flags.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "client: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return exitUsage
 	}
+	return runCall(o, cmd, flags.Args()[1:], stdout, stderr)
+}
 
-	// We should Set these up to be persistent.
-	result := &pb.MathResponse{}
-	var err error
+// runCall makes a single call and prints its result.
+func runCall(o *options, cmd command, args []string, stdout, stderr io.Writer) int {
+	request, err := parseOperands(cmd, args, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitUsage
+	}
 
-	logger.Info("Calling DevideNumber")
-	result, err = client.DevideNumber(context.Background(), request)
+	out, err := newPrinter(o.format, stdout)
 	if err != nil {
-		logger.Info("DevideNumber: Call Failed", "Request", request, "Error", err.Error())
-		return
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitUsage
+	}
+
+	conn, code := o.connect(stderr)
+	if conn == nil {
+		return code
 	}
+	defer conn.Close()
 
-	dumpJSON("DevideNumber", result.Result)
+	ctx, cancel := o.context()
+	defer cancel()
 
-	logger.Info("Calling MultiplyNumber")
-	result, err = client.MultiplyNumber(context.Background(), request)
+	response, err := cmd.Call(pb.NewMathClient(conn), ctx, request)
 	if err != nil {
-		logger.Info("MultiplyNumber: Call Failed", "Request", request, "Error", err.Error())
-		return
+		printError(stderr, cmd.Method, err)
+		return exitCode(err)
+	}
+
+	if err := out.Print(result{Operation: cmd.Name, Number1: number(request.Number1), Number2: number(request.Number2), Result: number(response.Result)}); err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
 	}
-	dumpJSON("MultiplyNumber", result.Result)
+	if err := out.Flush(); err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	return exitOK
 }
 
-func dumpJSON(name string, result interface{}) {
-	foo, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		log.Fatalf("Unable to marshal to json")
+// parseOperands reads the two operands of a command, positionally or from -number1 and -number2.
+func parseOperands(cmd command, args []string, stderr io.Writer) (*pb.MathRequest, error) {
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	number1 := flags.Float64("number1", 0, "First Number")
+	number2 := flags.Float64("number2", 0, "Second Number")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	// Positional operands fill whichever of number1 and number2 the flags left unset.
+	operands := map[string]*float64{"number1": number1, "number2": number2}
+	positional := flags.Args()
+	for _, name := range []string{"number1", "number2"} {
+		if set[name] || len(positional) == 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(positional[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("operand %q is not a number", positional[0])
+		}
+		*operands[name] = v
+		set[name] = true
+		positional = positional[1:]
+	}
+	if len(positional) > 0 {
+		return nil, fmt.Errorf("%s takes two operands, %q is one too many", cmd.Name, positional[0])
+	}
+	if !set["number1"] || !set["number2"] {
+		return nil, fmt.Errorf("%s needs two operands", cmd.Name)
 	}
-	fmt.Printf("%s\n%s\n", name, foo)
+
+	return &pb.MathRequest{Number1: *number1, Number2: *number2}, nil
 }
 
-func trace(s string) (string, time.Time) {
-	// log.Println("START:", s)
-	return s, time.Now()
+// dial makes a connection to the service named by GRPC_HOST and GRPC_PORT.
+func dial(logger logxi.Logger, plaintext, insecure bool) (*grpc.ClientConn, error) {
+	if plaintext {
+		return dialAddress(logger, grpcutils.EnvOrDefault("GRPC_HOST", "127.0.0.1"), grpcutils.EnvOrDefault("GRPC_PORT", "8443"), plaintext, insecure)
+	}
+	return dialAddress(logger,
+		grpcutils.EnvOrDefault("GRPC_HOST", "mathsvc.grpc."+grpcutils.EnvOrDefault("DOMAIN", "safeguardproperties.com")),
+		grpcutils.EnvOrDefault("GRPC_PORT", "32363"),
+		plaintext, insecure)
 }
 
-func un(s string, startTime time.Time) {
-	endTime := time.Now()
-	log.Println("", s, "ElapsedTime in seconds:", endTime.Sub(startTime))
+// dialAddress makes a connection to the service at host and port, authenticating as
+// GRPC_AUTH_USERNAME unless it is plaintext.
+func dialAddress(logger logxi.Logger, host, port string, plaintext, insecure bool) (*grpc.ClientConn, error) {
+	if plaintext {
+		return grpc.Dial(net.JoinHostPort(host, port), grpc.WithInsecure())
+	}
+	return grpcutils.MakeGRPCClientConn(logger,
+		grpcutils.EnvOrDefault("GRPC_AUTH_URL", "https://authentication."+grpcutils.EnvOrDefault("DOMAIN", "sgtec.io")),
+		grpcutils.EnvOrDefault("GRPC_AUTH_USERNAME", "AUTH_URL_UNSET"),
+		grpcutils.EnvOrDefault("GRPC_AUTH_PASSWORD", "AUTH_URL_UNSET"),
+		host, port, insecure,
+	)
 }
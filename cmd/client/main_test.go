@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseOperands(t *testing.T) {
+	add, _ := findCommand("add")
+
+	var cases = []struct {
+		Case    string
+		Args    []string
+		Number1 float64
+		Number2 float64
+		WantErr bool
+	}{
+		{Case: "Positional", Args: []string{"3", "4"}, Number1: 3, Number2: 4},
+		{Case: "Flags", Args: []string{"-number1", "3", "-number2", "-4.5"}, Number1: 3, Number2: -4.5},
+		{Case: "Mixed", Args: []string{"-number1", "3", "4"}, Number1: 3, Number2: 4},
+		{Case: "Zero", Args: []string{"0", "0"}, Number1: 0, Number2: 0},
+		{Case: "Missing", Args: []string{"3"}, WantErr: true},
+		{Case: "Too Many", Args: []string{"1", "2", "3"}, WantErr: true},
+		{Case: "Not A Number", Args: []string{"1", "two"}, WantErr: true},
+		{Case: "Given Twice", Args: []string{"-number1", "3", "4", "5"}, WantErr: true},
+	}
+	for n, c := range cases {
+		req, err := parseOperands(add, c.Args, ioutil.Discard)
+		if c.WantErr {
+			if err == nil {
+				t.Errorf("Case: %d: %s: Expected error, none reported.", n, c.Case)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			continue
+		}
+		if req.Number1 != c.Number1 || req.Number2 != c.Number2 {
+			t.Errorf("Case: %d: %s: Got %v, %v want %v, %v", n, c.Case, req.Number1, req.Number2, c.Number1, c.Number2)
+		}
+	}
+}
+
+func TestPrinters(t *testing.T) {
+	r := result{Operation: "divide", Number1: 1, Number2: 3, Result: 1.0 / 3}
+
+	overflow := result{Operation: "add", Number1: 1e308, Number2: 1e308, Result: number(math.Inf(1))}
+	undefined := result{Operation: "add", Number1: number(math.NaN()), Number2: number(math.Inf(-1)), Result: number(math.NaN())}
+
+	var cases = []struct {
+		Case   string
+		Format string
+		In     *result
+		Want   string
+	}{
+		{Case: "Value", Format: "value", Want: "0.3333333333333333\n"},
+		{Case: "JSON", Format: "json", Want: `{"operation":"divide","number1":"1","number2":"3","result":"0.3333333333333333"}` + "\n"},
+		{Case: "JSON Infinity", Format: "json", In: &overflow, Want: `{"operation":"add","number1":"1e+308","number2":"1e+308","result":"+Inf"}` + "\n"},
+		{Case: "JSON NaN", Format: "json", In: &undefined, Want: `{"operation":"add","number1":"NaN","number2":"-Inf","result":"NaN"}` + "\n"},
+		{Case: "Table Infinity", Format: "table", In: &overflow, Want: "OPERATION  NUMBER1  NUMBER2  RESULT\nadd        1e+308   1e+308   +Inf\n"},
+		{Case: "CSV", Format: "csv", Want: "operation,number1,number2,result\ndivide,1,3,0.3333333333333333\n"},
+		{Case: "Table", Format: "table", Want: "OPERATION  NUMBER1  NUMBER2  RESULT\ndivide     1        3        0.3333333333333333\n"},
+	}
+	for n, c := range cases {
+		var out bytes.Buffer
+		p, err := newPrinter(c.Format, &out)
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			continue
+		}
+		in := r
+		if c.In != nil {
+			in = *c.In
+		}
+		if err := p.Print(in); err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if err := p.Flush(); err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if out.String() != c.Want {
+			t.Errorf("Case: %d: %s: Got %q, want %q", n, c.Case, out.String(), c.Want)
+		}
+	}
+
+	if _, err := newPrinter("yaml", ioutil.Discard); err == nil {
+		t.Errorf("Expected an unknown format to be refused")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	var cases = []struct {
+		Case string
+		Err  error
+		Want int
+	}{
+		{Case: "OK", Err: nil, Want: exitOK},
+		{Case: "Invalid Argument", Err: status.Error(codes.InvalidArgument, "nope"), Want: 13},
+		{Case: "Unavailable", Err: status.Error(codes.Unavailable, "down"), Want: 24},
+		{Case: "Not A Status", Err: errors.New("boom"), Want: exitRPC + int(codes.Unknown)},
+	}
+	for n, c := range cases {
+		if got := exitCode(c.Err); got != c.Want {
+			t.Errorf("Case: %d: %s: Got %d, want %d", n, c.Case, got, c.Want)
+		}
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	var cases = []struct {
+		Case string
+		Args []string
+	}{
+		{Case: "No Command", Args: nil},
+		{Case: "Unknown Command", Args: []string{"subtract", "1", "2"}},
+		{Case: "Bad Format", Args: []string{"-o", "yaml", "add", "1", "2"}},
+		{Case: "Missing Operand", Args: []string{"add", "1"}},
+	}
+	for n, c := range cases {
+		if got := run(c.Args, ioutil.Discard, ioutil.Discard); got != exitUsage {
+			t.Errorf("Case: %d: %s: Got exit %d, want %d", n, c.Case, got, exitUsage)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// result is one answered call, as the printers see it.
+type result struct {
+	Operation string `json:"operation"`
+	Number1   number `json:"number1"`
+	Number2   number `json:"number2"`
+	Result    number `json:"result"`
+}
+
+// printer writes results in one output format.
+type printer interface {
+	Print(r result) error
+	Flush() error
+}
+
+// newPrinter returns the printer for a format name.
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case "json":
+		return &jsonPrinter{enc: json.NewEncoder(w)}, nil
+	case "value":
+		return &valuePrinter{w: w}, nil
+	case "table":
+		return &tablePrinter{w: tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)}, nil
+	case "csv":
+		return &csvPrinter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, want json, value, table or csv", format)
+}
+
+// formatNumber prints a float in the shortest form that reads back exactly.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// number is an operand or answer that JSON carries as a string in formatNumber's form, since
+// JSON has no NaN or infinities and a server may answer with them.
+type number float64
+
+func (n number) String() string {
+	return formatNumber(float64(n))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n number) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+func (p *jsonPrinter) Print(r result) error { return p.enc.Encode(r) }
+func (p *jsonPrinter) Flush() error         { return nil }
+
+type valuePrinter struct {
+	w io.Writer
+}
+
+func (p *valuePrinter) Print(r result) error {
+	_, err := fmt.Fprintln(p.w, r.Result)
+	return err
+}
+func (p *valuePrinter) Flush() error { return nil }
+
+type tablePrinter struct {
+	w      *tabwriter.Writer
+	header bool
+}
+
+func (p *tablePrinter) Print(r result) error {
+	if !p.header {
+		p.header = true
+		if _, err := fmt.Fprintln(p.w, "OPERATION\tNUMBER1\tNUMBER2\tRESULT"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(p.w, "%s\t%s\t%s\t%s\n", r.Operation, r.Number1.String(), r.Number2.String(), r.Result.String())
+	return err
+}
+func (p *tablePrinter) Flush() error { return p.w.Flush() }
+
+type csvPrinter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (p *csvPrinter) Print(r result) error {
+	if !p.header {
+		p.header = true
+		if err := p.w.Write([]string{"operation", "number1", "number2", "result"}); err != nil {
+			return err
+		}
+	}
+	return p.w.Write([]string{r.Operation, r.Number1.String(), r.Number2.String(), r.Result.String()})
+}
+func (p *csvPrinter) Flush() error {
+	p.w.Flush()
+	return p.w.Error()
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// operators maps infix operators onto the command that implements them.
+var operators = map[string]string{"+": "add", "*": "multiply", "/": "divide"}
+
+const replHelp = `Expressions:
+  add 3 4          call a command by name (add, multiply, divide)
+  3 * 4            or use an operator: + * /
+  $2 / $           $N is the Nth answer, $ the last one
+Commands:
+  history          list the answers so far
+  help             show this text
+  quit             leave, as does Ctrl-D
+`
+
+// runREPL is the repl command.
+func runREPL(o *options, args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		fmt.Fprintf(stderr, "client: repl takes no operands\n")
+		return exitUsage
+	}
+	conn, code := o.connect(stderr)
+	if conn == nil {
+		return code
+	}
+	defer conn.Close()
+
+	session := &repl{client: pb.NewMathClient(conn), options: o, out: stdout, errOut: stderr}
+	return session.run(newLineReader(os.Stdin, stdout))
+}
+
+// repl is one interactive session on a single connection.
+type repl struct {
+	client  pb.MathClient
+	options *options
+	out     io.Writer
+	errOut  io.Writer
+	answers []float64
+}
+
+// run reads and evaluates lines until the input ends or the user quits.
+func (r *repl) run(lines lineReader) int {
+	fmt.Fprintln(r.out, `Type "help" for help, Ctrl-D to quit.`)
+	for {
+		line, err := lines.ReadLine("mathsvc> ")
+		switch {
+		case err == errInterrupted:
+			continue
+		case err == io.EOF:
+			return exitOK
+		case err != nil:
+			fmt.Fprintf(r.errOut, "client: %s\n", err.Error())
+			return exitFailure
+		}
+		if r.eval(line) {
+			return exitOK
+		}
+	}
+}
+
+// eval runs one line of input, it returns true when the user asked to leave.
+func (r *repl) eval(line string) bool {
+	tokens := tokenize(line)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	switch tokens[0] {
+	case "quit", "exit":
+		return true
+	case "help":
+		fmt.Fprint(r.out, replHelp)
+		return false
+	case "history":
+		for n, answer := range r.answers {
+			fmt.Fprintf(r.out, "$%d = %s\n", n+1, formatNumber(answer))
+		}
+		return false
+	}
+
+	cmd, operands, err := r.parse(tokens)
+	if err != nil {
+		fmt.Fprintf(r.errOut, "%s\n", err.Error())
+		return false
+	}
+	r.call(cmd, &pb.MathRequest{Number1: operands[0], Number2: operands[1]})
+	return false
+}
+
+// parse turns "add 3 4" or "3 + 4" into a command and its operands.
+func (r *repl) parse(tokens []string) (command, []float64, error) {
+	var (
+		name  string
+		terms []string
+	)
+	if len(tokens) == 3 && !isCommand(tokens[0]) {
+		op := tokens[1]
+		if operators[op] == "" {
+			return command{}, nil, fmt.Errorf("unknown operator %q, the service can only do + * /", op)
+		}
+		name, terms = operators[op], []string{tokens[0], tokens[2]}
+	} else {
+		name, terms = tokens[0], tokens[1:]
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		return command{}, nil, fmt.Errorf("expected \"<command> a b\" or \"a <operator> b\", try help")
+	}
+	if len(terms) != 2 {
+		return command{}, nil, fmt.Errorf("%s takes two operands", cmd.Name)
+	}
+
+	operands := make([]float64, len(terms))
+	for i, term := range terms {
+		v, err := r.operand(term)
+		if err != nil {
+			return command{}, nil, err
+		}
+		operands[i] = v
+	}
+	return cmd, operands, nil
+}
+
+// operand reads a number, or looks up an earlier answer.
+func (r *repl) operand(term string) (float64, error) {
+	if !strings.HasPrefix(term, "$") {
+		v, err := strconv.ParseFloat(term, 64)
+		if err != nil {
+			return 0, fmt.Errorf("operand %q is not a number", term)
+		}
+		return v, nil
+	}
+
+	n := len(r.answers)
+	if term != "$" {
+		var err error
+		if n, err = strconv.Atoi(term[1:]); err != nil {
+			return 0, fmt.Errorf("operand %q is not an answer, use $1, $2, ...", term)
+		}
+	}
+	if n < 1 || n > len(r.answers) {
+		return 0, fmt.Errorf("there is no answer %s yet", term)
+	}
+	return r.answers[n-1], nil
+}
+
+// call makes one RPC and reports the answer, how long it took and whether the cache answered it.
+func (r *repl) call(cmd command, request *pb.MathRequest) {
+	ctx, cancel := r.options.context()
+	defer cancel()
+
+	var header metadata.MD
+	start := time.Now()
+	response, err := cmd.Call(r.client, ctx, request, grpc.Header(&header))
+	elapsed := time.Since(start).Round(time.Microsecond)
+	if err != nil {
+		printError(r.errOut, cmd.Method, err)
+		fmt.Fprintf(r.errOut, "  (%s)\n", elapsed)
+		return
+	}
+
+	cache := "unknown"
+	if v := header[mathcache.CacheHeader]; len(v) > 0 {
+		cache = v[0]
+	}
+	r.answers = append(r.answers, response.Result)
+	fmt.Fprintf(r.out, "$%d = %s\t(%s, cache %s)\n", len(r.answers), formatNumber(response.Result), elapsed, cache)
+}
+
+// isCommand reports whether a token names a command.
+func isCommand(token string) bool {
+	_, ok := findCommand(token)
+	return ok
+}
+
+// tokenize splits a line on spaces and around operators, so "3*4" reads like "3 * 4".
+// The sign of an exponent, as in 1e+5, stays part of its number.
+func tokenize(line string) []string {
+	var (
+		tokens  []string
+		current []rune
+	)
+	flush := func() {
+		if len(current) > 0 {
+			tokens = append(tokens, string(current))
+			current = current[:0]
+		}
+	}
+
+	for _, c := range line {
+		switch {
+		case unicode.IsSpace(c):
+			flush()
+		case c == '*' || c == '/' || (c == '+' && !endsInExponent(current)):
+			flush()
+			tokens = append(tokens, string(c))
+		default:
+			current = append(current, c)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func endsInExponent(token []rune) bool {
+	n := len(token)
+	return n > 1 && (token[n-1] == 'e' || token[n-1] == 'E') && unicode.IsDigit(token[n-2])
+}
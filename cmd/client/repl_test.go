@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// localMath answers locally, so the REPL can be tested without a server.
+type localMath struct{}
+
+func (localMath) AddNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: in.Number1 + in.Number2}, nil
+}
+
+func (localMath) MultiplyNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: in.Number1 * in.Number2}, nil
+}
+
+func (localMath) DevideNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	if in.Number2 == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Request: Number2: %f", in.Number2)
+	}
+	return &pb.MathResponse{Result: in.Number1 / in.Number2}, nil
+}
+
+func TestTokenize(t *testing.T) {
+	var cases = []struct {
+		Case string
+		Line string
+		Want []string
+	}{
+		{Case: "Spaced", Line: "3 * 4", Want: []string{"3", "*", "4"}},
+		{Case: "Packed", Line: "3*4", Want: []string{"3", "*", "4"}},
+		{Case: "Negative", Line: "-3+-4", Want: []string{"-3", "+", "-4"}},
+		{Case: "Exponent", Line: "1e+5/2", Want: []string{"1e+5", "/", "2"}},
+		{Case: "Command", Line: "  add $1 $ ", Want: []string{"add", "$1", "$"}},
+		{Case: "Empty", Line: "   ", Want: nil},
+	}
+	for n, c := range cases {
+		if got := tokenize(c.Line); !reflect.DeepEqual(got, c.Want) {
+			t.Errorf("Case: %d: %s: Got %q, want %q", n, c.Case, got, c.Want)
+		}
+	}
+}
+
+func TestREPL(t *testing.T) {
+	input := strings.Join([]string{
+		"add 3 4",
+		"$1 * 2",
+		"$ / $1",
+		"5 - 1",
+		"divide 1 0",
+		"add $9 1",
+		"history",
+		"quit",
+		"add 1 1",
+	}, "\n")
+
+	var out, errOut bytes.Buffer
+	session := &repl{client: localMath{}, options: &options{}, out: &out, errOut: &errOut}
+	if code := session.run(newPlainReader(strings.NewReader(input), &out)); code != exitOK {
+		t.Errorf("Got exit %d, want %d", code, exitOK)
+	}
+
+	if want := []float64{7, 14, 2}; !reflect.DeepEqual(session.answers, want) {
+		t.Errorf("Got answers %v, want %v", session.answers, want)
+	}
+	for _, want := range []string{"$3 = 2\t(", "cache unknown)", "$2 = 14\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Output does not contain %q:\n%s", want, out.String())
+		}
+	}
+	for _, want := range []string{`unknown operator "-"`, "DevideNumber: InvalidArgument", "no answer $9"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Errorf("Errors do not contain %q:\n%s", want, errOut.String())
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/capture"
+	"github.com/mangeshhendre/mathsvc/pkg/shadow"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+)
+
+// mathService prefixes the captured methods the client can replay.
+const mathService = "/services.luggage.v1.Math/"
+
+// replayOptions describe one replay.
+type replayOptions struct {
+	Speed     float64
+	Tolerance float64
+	Parallel  int
+}
+
+// runReplay is the replay command.
+func runReplay(o *options, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		speed     = flags.Float64("speed", 1, "Replay at this multiple of the captured pace, 0 sends as fast as -parallel allows")
+		tolerance = flags.Float64("tolerance", 1e-9, "Relative difference allowed between the recorded and replayed results")
+		parallel  = flags.Int("parallel", 64, "Calls in flight at once")
+		all       = flags.Bool("all", false, "Report every call, not only those that differ")
+	)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, `Usage: client [flags] replay [-speed n] capture-file...
+
+Sends the calls in capture files, written by a server with capture.file set, back to the
+server, and reports any whose answer differs from the recorded one.  Give rotated files
+oldest first, file.2 file.1 file.  With no files the capture is read from stdin.
+
+In JSON output the recorded answer is the primary and the replayed one the candidate.
+
+`)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *speed < 0 || *parallel < 1 {
+		fmt.Fprintf(stderr, "client: -speed cannot be negative and -parallel must be at least 1\n")
+		return exitUsage
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var inputs []io.Reader
+	for _, path := range files {
+		f, err := openCapture(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "client: %s\n", err.Error())
+			return exitFailure
+		}
+		defer f.Close()
+		inputs = append(inputs, f)
+	}
+
+	conn, code := o.connect(stderr)
+	if conn == nil {
+		return code
+	}
+	defer conn.Close()
+
+	rows, skipped, err := replay(pb.NewMathClient(conn), o, io.MultiReader(inputs...), replayOptions{Speed: *speed, Tolerance: *tolerance, Parallel: *parallel})
+	if err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	if skipped > 0 {
+		fmt.Fprintf(stderr, "client: skipped %d calls to methods the client cannot replay\n", skipped)
+	}
+
+	summary := summariseComparisons(rows)
+	if err := writeComparisons(stdout, o.format, rows, summary, *all, [2]string{"recorded", "replayed"}); err != nil {
+		fmt.Fprintf(stderr, "client: %s\n", err.Error())
+		return exitFailure
+	}
+	if summary.Verdicts[shadow.Match] < summary.Rows {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// openCapture opens a capture file for reading up to its current end, so replaying to the
+// server that is writing the file does not go on to replay the replay.
+func openCapture(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return openBatchInput(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(f, info.Size()), f}, nil
+}
+
+// replay sends each captured call at its captured offset from the first, divided by the
+// speed, and compares the answers with the recorded ones.  It returns the comparisons in
+// capture order and how many calls it could not replay.
+func replay(client pb.MathClient, o *options, r io.Reader, ro replayOptions) ([]comparison, int, error) {
+	var (
+		rows    []comparison
+		skipped int
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, ro.Parallel)
+		first   time.Time
+		start   time.Time
+		n       int
+	)
+
+	err := capture.Read(r, func(record capture.Record) error {
+		n++
+		cmd, ok := findCommand(strings.TrimPrefix(record.Method, mathService))
+		if !ok || !strings.HasPrefix(record.Method, mathService) || record.Request == nil {
+			skipped++
+			return nil
+		}
+
+		if first.IsZero() {
+			first, start = record.Time, time.Now()
+		}
+		if ro.Speed > 0 {
+			offset := time.Duration(float64(record.Time.Sub(first)) / ro.Speed)
+			time.Sleep(time.Until(start.Add(offset)))
+		}
+
+		row := comparison{Row: n, Operation: cmd.Name, Number1: number(record.Request.Number1), Number2: number(record.Request.Number2)}
+		row.Primary = side{Code: record.Code, Error: record.Error, Latency: record.DurationMs}
+		if record.Response != nil {
+			result := number(record.Response.Result)
+			row.Primary.Result = &result
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			row.Candidate = compareSide(client, o, batchRecord{Row: row.Row, Operation: cmd.Name, Number1: float64(row.Number1), Number2: float64(row.Number2)})
+			row.Verdict = shadow.Compare(row.Primary.outcome(), row.Candidate.outcome(), ro.Tolerance)
+			mu.Lock()
+			rows = append(rows, row)
+			mu.Unlock()
+		}()
+		return nil
+	})
+	wg.Wait()
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Row < rows[j].Row })
+	return rows, skipped, err
+}
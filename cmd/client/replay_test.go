@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	input := `{"v":1,"time":"2026-10-19T09:30:00Z","method":"/services.luggage.v1.Math/AddNumber","request":{"number1":"3","number2":"4"},"response":{"result":"7"},"code":"OK","duration_ms":0.4}
+{"v":1,"time":"2026-10-19T09:30:00.01Z","method":"/services.luggage.v1.Math/MultiplyNumber","request":{"number1":"3","number2":"4"},"response":{"result":"12"},"code":"OK","duration_ms":0.4}
+{"v":2,"time":"2026-10-19T09:30:00.02Z","method":"/services.luggage.v1.Math/AddNumber","request":{"numbers":[1,1]},"response":{"result":3},"code":"OK"}
+{"v":1,"time":"2026-10-19T09:30:00.03Z","method":"/services.luggage.v1.Other/AddNumber","request":{"number1":"1","number2":"1"},"code":"OK"}
+{"v":1,"time":"2026-10-19T09:30:00.04Z","method":"/services.luggage.v1.Math/DevideNumber","request":{"number1":"1","number2":"0"},"code":"InvalidArgument","error":"Invalid Request"}
+`
+	var cases = []struct {
+		Case   string
+		Client localMath
+		Speed  float64
+		Want   []string
+	}{
+		{Case: "Paced", Speed: 10, Want: []string{"match", "match", "match"}},
+		{Case: "Flat out", Speed: 0, Want: []string{"match", "match", "match"}},
+	}
+	for n, c := range cases {
+		rows, skipped, err := replay(c.Client, &options{}, strings.NewReader(input), replayOptions{Speed: c.Speed, Tolerance: 1e-9, Parallel: 2})
+		if err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if skipped != 1 {
+			t.Errorf("Case: %d: %s: Got %d skipped, want 1", n, c.Case, skipped)
+		}
+		if len(rows) != len(c.Want) {
+			t.Fatalf("Case: %d: %s: Got %d rows, want %d", n, c.Case, len(rows), len(c.Want))
+		}
+		for i, row := range rows {
+			if row.Verdict != c.Want[i] {
+				t.Errorf("Case: %d: %s: Row %d: Got %s, want %s", n, c.Case, row.Row, row.Verdict, c.Want[i])
+			}
+		}
+	}
+
+	rows, _, _ := replay(sloppyMath{}, &options{}, strings.NewReader(input), replayOptions{Tolerance: 1e-9, Parallel: 1})
+	if got := []string{rows[0].Verdict, rows[1].Verdict, rows[2].Verdict}; got[1] != "result_mismatch" || got[2] != "code_mismatch" {
+		t.Errorf("Got verdicts %v against a sloppy server", got)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !faults
+// +build !faults
+
+package main
+
+import (
+	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/mathsvc/pkg/fault"
+)
+
+// faultInjection is off in production builds, build with -tags faults to turn it on.
+func faultInjection() (*fault.Injector, grpcutils.GRPCService) {
+	return nil, nil
+}
@@ -0,0 +1,16 @@
+//go:build faults
+// +build faults
+
+package main
+
+import (
+	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/mathsvc/pkg/fault"
+)
+
+// faultInjection wraps the database and cache with an injector that starts without rules,
+// and serves FaultAdmin to change them.
+func faultInjection() (*fault.Injector, grpcutils.GRPCService) {
+	faults := fault.New()
+	return faults, fault.NewAdmin(faults)
+}
@@ -1,52 +1,161 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"bytes"
-
-	"github.com/kelseyhightower/envconfig"
 	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/mathsvc/pkg/capture"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/grpcserver"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
 	handler "github.com/mangeshhendre/mathsvc/pkg/mathhandler"
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	"github.com/mangeshhendre/mathsvc/pkg/shadow"
+	"github.com/mangeshhendre/mathsvc/pkg/tracing"
 	logxi "github.com/mgutz/logxi/v1"
+	"google.golang.org/grpc"
 )
 
-// This is my config yo.
-type Config struct {
-	DSN string `required:"true" desc:"Oracle connection string"`
-}
-
-const config_prefix string = ""
-
 func main() {
+	var (
+		configFile  = flag.String("config", grpcutils.EnvOrDefault("CONFIG_FILE", ""), "YAML config file, the environment overrides anything in it")
+		printConfig = flag.Bool("print-config", false, "Print the effective configuration, secrets redacted, and exit")
+		dev         = flag.Bool("dev", false, "Local development mode: plaintext gRPC without JWT on loopback, in-memory database and cache")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "\nEnvironment:")
+		config.Usage(os.Stderr)
+	}
+	flag.Parse()
 
-	logger := logxi.New("mathsvc")
+	logger := logging.New("mathsvc")
 
-	c := &Config{}
+	var overrides []func(*config.Config)
+	if *dev {
+		overrides = append(overrides, config.Dev)
+	}
 
-	err := envconfig.Process(config_prefix, c)
+	c, err := config.Load(*configFile, overrides...)
 	if err != nil {
-		buf := bytes.NewBuffer(nil)
-		envconfig.Usagef(config_prefix, c, buf, envconfig.DefaultTableFormat)
-		logger.Fatal("Unable to load config:", "Config", buf.String())
+		logger.Fatal("Unable to load config", "Error", err)
+	}
+
+	if *printConfig {
+		fmt.Print(c)
+		return
+	}
+	logger.Debug("Configuration Data", "Config", c.Redacted())
+
+	live := config.NewLive(c.Runtime)
+
+	// Decide where trace spans go.
+	switch c.Trace.Exporter {
+	case "file":
+		exporter, err := tracing.NewFileExporter(c.Trace.File)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to open trace file: %v", err))
+		}
+		tracing.DefaultTracer.SetExporter(exporter)
+	case "otlp":
+		tracing.DefaultTracer.SetExporter(tracing.NewOTLPExporter(c.Trace.Endpoint, "mathsvc", 5*time.Second))
 	}
 
 	//Create the server instance.
-	server, err := handler.New(c.DSN)
+	newHandler, newGRPCServer := handler.New, grpcserver.New
+	if c.Dev {
+		newHandler, newGRPCServer = handler.NewInMemory, grpcserver.NewDev
+	}
+
+	faults, admin := faultInjection()
+	server, err := newHandler(c, live, faults)
 	if err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to create server instance: %v", err))
 	}
 
-	defer server.Close()
+	var interceptors []grpc.UnaryServerInterceptor
+	if c.Capture.File != "" {
+		capturer, err := capture.New(c.Capture)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to capture to %s: %v", c.Capture.File, err))
+		}
+		defer capturer.Close()
+		interceptors = append(interceptors, capturer.UnaryServerInterceptor())
+	}
+	if c.Shadow.Target != "" {
+		shadower, err := shadow.New(c.Shadow)
+		if err != nil {
+			logger.Fatal(fmt.Sprintf("Unable to shadow to %s: %v", c.Shadow.Target, err))
+		}
+		defer shadower.Close()
+		interceptors = append(interceptors, shadower.UnaryServerInterceptor())
+	}
 
-	grpcManager, err := grpcutils.New("mathsvc.grpc")
+	grpcServer, err := newGRPCServer("mathsvc.grpc", c.GRPC, interceptors...)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 
+	if c.Dev {
+		printDevBanner(grpcServer.Addr().String())
+	}
+
 	// Register your service.
-	grpcManager.RegisterHandlers(server)
+	grpcServer.RegisterHandlers(server)
+	if admin != nil {
+		logger.Warn("Fault injection is built in, FaultAdmin can make the database and cache fail")
+		grpcServer.RegisterHandlers(admin)
+	}
+
+	// The debug http server serves the default mux, so metrics live next to /debug/requests.
+	http.Handle("/metrics", metrics.Handler())
+
+	go reloadOnHangup(logger, *configFile, c, live)
+
+	// Start the show, this blocks until we are told to stop and have drained.
+	grpcServer.Run()
+
+	if err := server.Close(); err != nil {
+		logger.Warn("Unable to close server instance", "Error", err)
+	}
+}
 
-	// Start the show.
-	grpcManager.Run()
+// printDevBanner tells whoever started dev mode how to talk to it.
+func printDevBanner(addr string) {
+	host, port, _ := net.SplitHostPort(addr)
+	fmt.Printf(`mathsvc is running in dev mode on %s: plaintext, no authentication, in-memory database and cache.
+
+Try it with:
+  GRPC_HOST=%s GRPC_PORT=%s go run ./cmd/client -plaintext add 3 4
+
+or, with grpcurl:
+  grpcurl -plaintext -d '{"number1": 3, "number2": 4}' %s services.luggage.v1.Math/AddNumber
+
+`, addr, host, port, addr)
+}
+
+// reloadOnHangup re-reads the configuration on every SIGHUP and applies the parts that are safe to change.
+func reloadOnHangup(logger logxi.Logger, configFile string, current *config.Config, live *config.Live) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+
+	for range hangups {
+		restart, err := live.Reload(configFile, current)
+		if err != nil {
+			logger.Warn("Unable to reload config, keeping the old one", "Error", err)
+			continue
+		}
+		logger.Info("Reloaded config", "Runtime", live.Load())
+		if len(restart) > 0 {
+			logger.Warn("Config changes that need a restart were ignored", "Sections", restart)
+		}
+	}
 }
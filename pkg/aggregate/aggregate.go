@@ -0,0 +1,138 @@
+// Package aggregate folds a series of numbers into one, without the precision a chain of
+// single additions or multiplications loses.  NaN and the infinities go through with
+// IEEE 754 semantics, it is up to the caller to refuse them.
+package aggregate
+
+import "math"
+
+// Fold is one aggregate being computed, numbers are added one at a time.
+type Fold interface {
+	Add(x float64)
+	Result() float64
+}
+
+// Sum adds with Neumaier's compensated summation: the rounding error of every addition is
+// kept aside and added back at the end, so 1 + 1e100 + 1 - 1e100 is 2 rather than 0.
+type Sum struct {
+	sum          float64
+	compensation float64
+}
+
+func (s *Sum) Add(x float64) {
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+}
+
+// Result is 0 for no numbers.  Once the sum is infinite or NaN the compensation means
+// nothing and is left out.
+func (s *Sum) Result() float64 {
+	if math.IsInf(s.sum, 0) || math.IsNaN(s.sum) {
+		return s.sum
+	}
+	return s.sum + s.compensation
+}
+
+// Product multiplies mantissas and adds exponents, so only a result that cannot be
+// represented overflows or underflows, not a partial product on the way to it.
+type Product struct {
+	mantissa float64
+	exponent int
+	started  bool
+}
+
+func (p *Product) Add(x float64) {
+	if !p.started {
+		p.mantissa, p.started = 1, true
+	}
+	frac, exp := math.Frexp(x)
+	p.mantissa, p.exponent = p.mantissa*frac, p.exponent+exp
+	frac, exp = math.Frexp(p.mantissa)
+	p.mantissa, p.exponent = frac, p.exponent+exp
+}
+
+// Result is 1 for no numbers.
+func (p *Product) Result() float64 {
+	if !p.started {
+		return 1
+	}
+	return math.Ldexp(p.mantissa, p.exponent)
+}
+
+// Min is the smallest number, NaN if any was NaN.
+type Min struct {
+	min     float64
+	started bool
+}
+
+func (m *Min) Add(x float64) {
+	if !m.started {
+		m.min, m.started = x, true
+		return
+	}
+	m.min = math.Min(m.min, x)
+}
+
+// Result is NaN for no numbers.
+func (m *Min) Result() float64 {
+	if !m.started {
+		return math.NaN()
+	}
+	return m.min
+}
+
+// Max is the largest number, NaN if any was NaN.
+type Max struct {
+	max     float64
+	started bool
+}
+
+func (m *Max) Add(x float64) {
+	if !m.started {
+		m.max, m.started = x, true
+		return
+	}
+	m.max = math.Max(m.max, x)
+}
+
+// Result is NaN for no numbers.
+func (m *Max) Result() float64 {
+	if !m.started {
+		return math.NaN()
+	}
+	return m.max
+}
+
+// Mean divides the compensated sum by the count.  When that sum overflows although every
+// number was finite, it falls back to a running mean, which cannot.
+type Mean struct {
+	sum       Sum
+	running   float64
+	count     float64
+	nonFinite bool
+}
+
+func (m *Mean) Add(x float64) {
+	m.sum.Add(x)
+	m.count++
+	m.running += (x - m.running) / m.count
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		m.nonFinite = true
+	}
+}
+
+// Result is NaN for no numbers.
+func (m *Mean) Result() float64 {
+	if m.count == 0 {
+		return math.NaN()
+	}
+	mean := m.sum.Result() / m.count
+	if math.IsInf(mean, 0) && !m.nonFinite {
+		return m.running
+	}
+	return mean
+}
@@ -0,0 +1,50 @@
+package aggregate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFolds(t *testing.T) {
+	max, inf, nan := math.MaxFloat64, math.Inf(1), math.NaN()
+	big := math.Ldexp(1, 1000) // a power of two, so products of it are exact
+	var cases = []struct {
+		Case    string
+		Fold    func() Fold
+		Numbers []float64
+		Want    float64
+	}{
+		{Case: "Sum none", Fold: func() Fold { return &Sum{} }, Want: 0},
+		{Case: "Sum", Fold: func() Fold { return &Sum{} }, Numbers: []float64{1, 2, 3.5}, Want: 6.5},
+		{Case: "Sum compensated", Fold: func() Fold { return &Sum{} }, Numbers: []float64{1, 1e100, 1, -1e100}, Want: 2},
+		{Case: "Sum tenths", Fold: func() Fold { return &Sum{} }, Numbers: []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, Want: 1},
+		{Case: "Sum overflow", Fold: func() Fold { return &Sum{} }, Numbers: []float64{max, max}, Want: inf},
+		{Case: "Sum infinities", Fold: func() Fold { return &Sum{} }, Numbers: []float64{inf, 1, -inf}, Want: nan},
+		{Case: "Product none", Fold: func() Fold { return &Product{} }, Want: 1},
+		{Case: "Product", Fold: func() Fold { return &Product{} }, Numbers: []float64{2, -3, 0.5}, Want: -3},
+		{Case: "Product through overflow", Fold: func() Fold { return &Product{} }, Numbers: []float64{big, big, 1 / big}, Want: big},
+		{Case: "Product through underflow", Fold: func() Fold { return &Product{} }, Numbers: []float64{1 / big, 1 / big, big}, Want: 1 / big},
+		{Case: "Product overflow", Fold: func() Fold { return &Product{} }, Numbers: []float64{1e300, 1e300}, Want: inf},
+		{Case: "Product zero", Fold: func() Fold { return &Product{} }, Numbers: []float64{5, 0, 7}, Want: 0},
+		{Case: "Product zero and infinity", Fold: func() Fold { return &Product{} }, Numbers: []float64{0, inf}, Want: nan},
+		{Case: "Min", Fold: func() Fold { return &Min{} }, Numbers: []float64{3, -1, 2}, Want: -1},
+		{Case: "Min none", Fold: func() Fold { return &Min{} }, Want: nan},
+		{Case: "Min NaN", Fold: func() Fold { return &Min{} }, Numbers: []float64{3, nan, 2}, Want: nan},
+		{Case: "Max", Fold: func() Fold { return &Max{} }, Numbers: []float64{3, -1, 2}, Want: 3},
+		{Case: "Max infinity", Fold: func() Fold { return &Max{} }, Numbers: []float64{3, inf}, Want: inf},
+		{Case: "Mean", Fold: func() Fold { return &Mean{} }, Numbers: []float64{1, 2, 3, 4}, Want: 2.5},
+		{Case: "Mean none", Fold: func() Fold { return &Mean{} }, Want: nan},
+		{Case: "Mean through overflow", Fold: func() Fold { return &Mean{} }, Numbers: []float64{max, max}, Want: max},
+		{Case: "Mean infinity", Fold: func() Fold { return &Mean{} }, Numbers: []float64{1, inf}, Want: inf},
+	}
+	for n, c := range cases {
+		fold := c.Fold()
+		for _, x := range c.Numbers {
+			fold.Add(x)
+		}
+		got := fold.Result()
+		if got != c.Want && !(math.IsNaN(got) && math.IsNaN(c.Want)) {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, c.Case, got, c.Want)
+		}
+	}
+}
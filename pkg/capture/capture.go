@@ -0,0 +1,323 @@
+// Package capture samples Math requests and their responses to a rotating file, so the
+// traffic behind a production bug can be replayed with cmd/client replay.
+//
+// A capture is newline delimited JSON, one Record per line:
+//
+//	{"v":1,"time":"2026-10-19T09:30:00.123456Z","method":"/services.luggage.v1.Math/AddNumber",
+//	 "metadata":{"authorization":["REDACTED"],"user-agent":["grpc-go/1.10.0"]},
+//	 "request":{"number1":"3","number2":"4"},"response":{"result":"7"},"code":"OK","duration_ms":0.41}
+//
+// The fields are:
+//   - v is the format version, 1.  Readers should skip records with a version they do not know.
+//   - time is when the request arrived, UTC, RFC 3339 with nanoseconds.
+//   - method is the full gRPC method name.
+//   - metadata is the incoming metadata, with the values of the configured keys replaced by REDACTED.
+//   - request and response are the messages as JSON, every field present.  Their numbers are
+//     strings in Go's strconv form, such as "0.5", "1e+308", "NaN", "+Inf" or "-Inf", as JSON
+//     has no NaN or infinities and those are the requests most worth replaying.  response is
+//     omitted when the call failed.
+//   - code is the gRPC status code name, error its message when the call failed.
+//   - duration_ms is how long the server took, in milliseconds.
+//
+// When the file reaches its size limit it is renamed to file.1, file.1 to file.2 and so on,
+// and the oldest beyond the file limit is removed.  A replay of several files should start
+// from the highest number.
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Version is the format version written in every Record.
+const Version = 1
+
+// Redacted replaces the values of redacted metadata.
+const Redacted = "REDACTED"
+
+// mathService prefixes the methods that are captured.
+const mathService = "/services.luggage.v1.Math/"
+
+// queueSize bounds the records waiting to be written, past it records are dropped so a
+// slow disk never slows requests down.
+const queueSize = 1024
+
+var captured = metrics.DefaultRegistry.NewCounterVec(
+	"mathsvc_capture_records_total",
+	"Captured requests, by result (written, dropped, error).",
+	"result")
+
+// Record is one captured call, see the package documentation.
+type Record struct {
+	Version    int                 `json:"v"`
+	Time       time.Time           `json:"time"`
+	Method     string              `json:"method"`
+	Metadata   map[string][]string `json:"metadata,omitempty"`
+	Request    *Request            `json:"request"`
+	Response   *Response           `json:"response,omitempty"`
+	Code       string              `json:"code"`
+	Error      string              `json:"error,omitempty"`
+	DurationMs float64             `json:"duration_ms"`
+}
+
+// Request is a captured pb.MathRequest.  The generated type omits zero fields from JSON,
+// a capture keeps them so it reads unambiguously.
+type Request struct {
+	Number1 float64
+	Number2 float64
+}
+
+// requestJSON is how a Request is written.
+type requestJSON struct {
+	Number1 number `json:"number1"`
+	Number2 number `json:"number2"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Request) MarshalJSON() ([]byte, error) {
+	return json.Marshal(requestJSON{number(r.Number1), number(r.Number2)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Request) UnmarshalJSON(b []byte) error {
+	var fields requestJSON
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	r.Number1, r.Number2 = float64(fields.Number1), float64(fields.Number2)
+	return nil
+}
+
+// Response is a captured pb.MathResponse.
+type Response struct {
+	Result float64
+}
+
+// responseJSON is how a Response is written.
+type responseJSON struct {
+	Result number `json:"result"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Response) MarshalJSON() ([]byte, error) {
+	return json.Marshal(responseJSON{number(r.Result)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Response) UnmarshalJSON(b []byte) error {
+	var fields responseJSON
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	r.Result = float64(fields.Result)
+	return nil
+}
+
+// number is a float64 written as a string formatted by strconv.
+type number float64
+
+// MarshalJSON implements json.Marshaler.
+func (n number) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(float64(n), 'g', -1, 64))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *number) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = number(f)
+	return nil
+}
+
+// Capture writes sampled calls to a rotating file.
+type Capture struct {
+	sample  float64
+	redact  map[string]bool
+	out     io.WriteCloser
+	logger  logxi.Logger
+	records chan Record
+	done    chan struct{}
+
+	// closed is set by Close, under closing, so a handler still running when the server
+	// stops without waiting for it drops its record rather than sending on records.
+	closing sync.RWMutex
+	closed  bool
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New opens the capture file named by c.File.
+func New(c config.Capture) (*Capture, error) {
+	logger := logging.New("mathsvc.Capture")
+	out, err := NewRotatingFile(c.File, int64(c.MaxSizeMB)<<20, c.MaxFiles)
+	if err != nil {
+		return nil, logger.Error("Unable to open the capture file", "File", c.File, "Error", err)
+	}
+	return newCapture(c, out, logger), nil
+}
+
+func newCapture(c config.Capture, out io.WriteCloser, logger logxi.Logger) *Capture {
+	redact := map[string]bool{}
+	for _, key := range c.Redact {
+		redact[strings.ToLower(strings.TrimSpace(key))] = true
+	}
+	capture := &Capture{
+		sample:  c.Sample,
+		redact:  redact,
+		out:     out,
+		logger:  logger,
+		records: make(chan Record, queueSize),
+		done:    make(chan struct{}),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	go capture.write()
+	return capture
+}
+
+// UnaryServerInterceptor captures the Math calls it samples.
+func (c *Capture) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		if strings.HasPrefix(info.FullMethod, mathService) && c.sampled() {
+			c.record(ctx, info.FullMethod, start, req, resp, err)
+		}
+		return resp, err
+	}
+}
+
+func (c *Capture) sampled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rand.Float64() < c.sample
+}
+
+func (c *Capture) record(ctx context.Context, method string, start time.Time, req, resp interface{}, err error) {
+	r := Record{
+		Version:    Version,
+		Time:       start.UTC(),
+		Method:     method,
+		Code:       status.Code(err).String(),
+		DurationMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		r.Metadata = c.redacted(md)
+	}
+	if in, ok := req.(*pb.MathRequest); ok {
+		r.Request = &Request{Number1: in.Number1, Number2: in.Number2}
+	}
+	if err != nil {
+		r.Error = status.Convert(err).Message()
+	} else if out, ok := resp.(*pb.MathResponse); ok && out != nil {
+		r.Response = &Response{Result: out.Result}
+	}
+
+	c.closing.RLock()
+	defer c.closing.RUnlock()
+	if c.closed {
+		captured.WithLabelValues("dropped").Inc()
+		return
+	}
+	select {
+	case c.records <- r:
+	default:
+		captured.WithLabelValues("dropped").Inc()
+	}
+}
+
+// redacted copies md with the values of redacted keys replaced.
+func (c *Capture) redacted(md metadata.MD) map[string][]string {
+	out := make(map[string][]string, len(md))
+	for key, values := range md {
+		if c.redact[key] {
+			values = []string{Redacted}
+		}
+		out[key] = values
+	}
+	return out
+}
+
+// write drains the queue to the file, flushing whenever it runs dry.
+func (c *Capture) write() {
+	defer close(c.done)
+	buffered := bufio.NewWriter(c.out)
+	enc := json.NewEncoder(buffered)
+	for r := range c.records {
+		if err := enc.Encode(r); err != nil {
+			captured.WithLabelValues("error").Inc()
+			c.logger.Warn("Unable to write a capture record", "Error", err)
+			continue
+		}
+		captured.WithLabelValues("written").Inc()
+		if len(c.records) == 0 {
+			if err := buffered.Flush(); err != nil {
+				c.logger.Warn("Unable to flush the capture file", "Error", err)
+			}
+		}
+	}
+	buffered.Flush()
+}
+
+// Close writes what is queued and closes the file.  Calls captured after it are dropped.
+func (c *Capture) Close() error {
+	c.closing.Lock()
+	c.closed = true
+	close(c.records)
+	c.closing.Unlock()
+	<-c.done
+	return c.out.Close()
+}
+
+// Read reads a capture, calling fn for each record in turn.  Records of another version are skipped.
+func Read(r io.Reader, fn func(Record) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// The version comes first, a record of another version may not decode as a Record.
+		var header struct {
+			Version int `json:"v"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return err
+		}
+		if header.Version != Version {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
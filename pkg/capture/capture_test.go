@@ -0,0 +1,167 @@
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type closingBuffer struct {
+	bytes.Buffer
+}
+
+func (closingBuffer) Close() error { return nil }
+
+func TestCapture(t *testing.T) {
+	var cases = []struct {
+		Case     string
+		Method   string
+		Response interface{}
+		Err      error
+		Want     *Record
+	}{
+		{Case: "Answered", Method: "/services.luggage.v1.Math/AddNumber", Response: &pb.MathResponse{Result: 7},
+			Want: &Record{Code: "OK", Response: &Response{Result: 7}}},
+		{Case: "Failed", Method: "/services.luggage.v1.Math/DevideNumber", Err: status.Errorf(codes.InvalidArgument, "Invalid Request"),
+			Want: &Record{Code: "InvalidArgument", Error: "Invalid Request"}},
+		{Case: "Other service", Method: "/grpc.health.v1.Health/Check", Response: &pb.MathResponse{}},
+	}
+	for n, c := range cases {
+		out := &closingBuffer{}
+		capture := newCapture(config.Capture{Sample: 1, Redact: []string{"Authorization"}}, out, logxi.NullLog)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret", "user-agent", "test"))
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) { return c.Response, c.Err }
+		capture.UnaryServerInterceptor()(ctx, &pb.MathRequest{Number1: 3, Number2: 4}, &grpc.UnaryServerInfo{FullMethod: c.Method}, handler)
+		capture.Close()
+
+		var records []Record
+		if err := Read(out, func(r Record) error { records = append(records, r); return nil }); err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if c.Want == nil {
+			if len(records) != 0 {
+				t.Errorf("Case: %d: %s: Got %d records, want none", n, c.Case, len(records))
+			}
+			continue
+		}
+		if len(records) != 1 {
+			t.Fatalf("Case: %d: %s: Got %d records, want 1", n, c.Case, len(records))
+		}
+		r := records[0]
+		if r.Version != Version || r.Method != c.Method || r.Code != c.Want.Code || r.Error != c.Want.Error {
+			t.Errorf("Case: %d: %s: Unexpected record: %+v", n, c.Case, r)
+		}
+		if r.Request == nil || *r.Request != (Request{Number1: 3, Number2: 4}) {
+			t.Errorf("Case: %d: %s: Got request %+v", n, c.Case, r.Request)
+		}
+		if (r.Response == nil) != (c.Want.Response == nil) || (r.Response != nil && *r.Response != *c.Want.Response) {
+			t.Errorf("Case: %d: %s: Got response %+v, want %+v", n, c.Case, r.Response, c.Want.Response)
+		}
+		if got := r.Metadata["authorization"]; len(got) != 1 || got[0] != Redacted {
+			t.Errorf("Case: %d: %s: Got authorization %q, want it redacted", n, c.Case, got)
+		}
+		if got := r.Metadata["user-agent"]; len(got) != 1 || got[0] != "test" {
+			t.Errorf("Case: %d: %s: Got user-agent %q", n, c.Case, got)
+		}
+	}
+}
+
+func TestCapture_NonFinite(t *testing.T) {
+	out := &closingBuffer{}
+	capture := newCapture(config.Capture{Sample: 1}, out, logxi.NullLog)
+	refused := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Request")
+	}
+	overflowed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.MathResponse{Result: math.Inf(1)}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/services.luggage.v1.Math/AddNumber"}
+	capture.UnaryServerInterceptor()(context.Background(), &pb.MathRequest{Number1: math.NaN(), Number2: math.Inf(-1)}, info, refused)
+	capture.UnaryServerInterceptor()(context.Background(), &pb.MathRequest{Number1: 1e308, Number2: 1e308}, info, overflowed)
+	capture.Close()
+
+	raw := out.String()
+	for _, want := range []string{`"request":{"number1":"NaN","number2":"-Inf"}`, `"request":{"number1":"1e+308","number2":"1e+308"},"response":{"result":"+Inf"}`} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("Expected %s in the capture:\n%s", want, raw)
+		}
+	}
+
+	var records []Record
+	if err := Read(out, func(r Record) error { records = append(records, r); return nil }); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if len(records) != 2 {
+		t.Fatalf("Got %d records, want 2", len(records))
+	}
+	if r := records[0]; !math.IsNaN(r.Request.Number1) || !math.IsInf(r.Request.Number2, -1) || r.Response != nil {
+		t.Errorf("Got refused record %+v %+v", r.Request, r.Response)
+	}
+	if r := records[1]; r.Response == nil || !math.IsInf(r.Response.Result, 1) {
+		t.Errorf("Got overflowed record %+v %+v", r.Request, r.Response)
+	}
+}
+
+func TestCapture_AfterClose(t *testing.T) {
+	out := &closingBuffer{}
+	capture := newCapture(config.Capture{Sample: 1}, out, logxi.NullLog)
+	if err := capture.Close(); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+
+	// A handler the server stopped waiting for finishes after Close.
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.MathResponse{Result: 7}, nil
+	}
+	resp, err := capture.UnaryServerInterceptor()(context.Background(), &pb.MathRequest{Number1: 3, Number2: 4},
+		&grpc.UnaryServerInfo{FullMethod: "/services.luggage.v1.Math/AddNumber"}, handler)
+	if err != nil || resp.(*pb.MathResponse).Result != 7 {
+		t.Errorf("Got %v, %v, want the handler's answer", resp, err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Got %q written after Close", out.String())
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "capture.ndjson")
+	f, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(f, "line %d\n", i)
+	}
+	f.Close()
+
+	for name, want := range map[string]string{"": "line 4\n", ".1": "line 3\n", ".2": "line 2\n"} {
+		got, err := ioutil.ReadFile(path + name)
+		if err != nil || string(got) != want {
+			t.Errorf("%s%s: Got %q (%v), want %q", filepath.Base(path), name, got, err, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Got a third rotated file, want at most two")
+	}
+}
@@ -0,0 +1,486 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the whole of the mathsvc configuration.  Values come from Default, then the
+// config file if there is one, then the environment, each overriding the last.
+type Config struct {
+	Dev      bool     `yaml:"dev" envconfig:"DEV" desc:"Local development mode: plaintext, unauthenticated, in-memory backends, loopback only"`
+	DSN      string   `yaml:"dsn" envconfig:"DSN" secret:"true" desc:"Oracle connection string, not needed in dev mode"`
+	GRPC     GRPC     `yaml:"grpc" envconfig:"GRPC"`
+	Memcache Memcache `yaml:"memcache" envconfig:"MEMCACHE"`
+	Statsd   Statsd   `yaml:"statsd" envconfig:"STATSD"`
+	Trace    Trace    `yaml:"trace" envconfig:"TRACE"`
+	Shadow   Shadow   `yaml:"shadow" envconfig:"SHADOW"`
+	Capture  Capture  `yaml:"capture" envconfig:"CAPTURE"`
+	Session  Session  `yaml:"session" envconfig:"SESSION"`
+	Runtime  `yaml:",inline"`
+}
+
+// GRPC configures the grpc and debug http servers, the names match grpcutils.ManagerConfig.
+type GRPC struct {
+	MinLife         int64         `yaml:"min_life" split_words:"true" desc:"Minimum lifetime of the worker in seconds, 0 to live until signalled"`
+	LifeRange       int64         `yaml:"life_range" split_words:"true" desc:"Random range in seconds which will be added to the minimum life"`
+	BindAddress     string        `yaml:"bind_address" split_words:"true" desc:"Address to bind to to serve up GRPC requests"`
+	BindPort        string        `yaml:"bind_port" split_words:"true" desc:"Port to bind to to serve up GRPC requests"`
+	JWTCertPath     string        `yaml:"jwt_cert_path" envconfig:"JWT_CERT_PATH" desc:"Path to the directory containing authorized JWT certificates, named as ISSUER.pem"`
+	SSLCertPath     string        `yaml:"ssl_cert_path" envconfig:"SSL_CERT_PATH" desc:"Where to find the SSL Certificate"`
+	SSLKeyPath      string        `yaml:"ssl_key_path" envconfig:"SSL_KEY_PATH" desc:"Where to find the SSL Key"`
+	DebugAddress    string        `yaml:"debug_address" split_words:"true" desc:"Address to bind the http debug server to"`
+	DebugPort       string        `yaml:"debug_port" split_words:"true" desc:"Port to bind the http debug server to"`
+	DrainPeriod     time.Duration `yaml:"drain_period" split_words:"true" desc:"How long health reports NOT_SERVING before new RPCs are refused"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" split_words:"true" desc:"How long in flight RPCs get to finish before they are cut off"`
+}
+
+// Memcache configures the result cache.
+type Memcache struct {
+	Servers string `yaml:"servers" envconfig:"SERVERS" desc:"Semicolon separated list of memcache host:port"`
+	Scope   string `yaml:"scope" envconfig:"SCOPE" desc:"protocache scope, changing it orphans every cached result"`
+}
+
+// ServerList splits Servers into its individual addresses.
+func (m Memcache) ServerList() []string {
+	var servers []string
+	for _, server := range strings.Split(m.Servers, ";") {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// Statsd configures the statsd timings sent by the tracer package.
+type Statsd struct {
+	Address string  `yaml:"address" envconfig:"ADDRESS" desc:"host:port of the statsd server"`
+	Prefix  string  `yaml:"prefix" envconfig:"PREFIX" desc:"Prefix for every statsd bucket"`
+	Sample  float32 `yaml:"sample" envconfig:"SAMPLE" desc:"Statsd sample rate"`
+}
+
+// Trace configures where trace spans go.
+type Trace struct {
+	Exporter string `yaml:"exporter" envconfig:"EXPORTER" desc:"Where to send trace spans: none, file or otlp"`
+	File     string `yaml:"file" envconfig:"FILE" desc:"File the file trace exporter appends spans to"`
+	Endpoint string `yaml:"endpoint" envconfig:"ENDPOINT" desc:"OTLP/HTTP traces endpoint for the otlp trace exporter"`
+}
+
+// Shadow configures sending a sample of requests to a candidate build as well, to compare its answers.
+type Shadow struct {
+	Target    string        `yaml:"target" envconfig:"TARGET" desc:"host:port of the candidate server, empty turns shadowing off"`
+	Plaintext bool          `yaml:"plaintext" envconfig:"PLAINTEXT" desc:"Talk to the candidate without TLS, for a candidate started with -dev, and without passing on the caller's authorization"`
+	Insecure  bool          `yaml:"insecure" envconfig:"INSECURE" desc:"Skip checking the candidate's certificate"`
+	Sample    float64       `yaml:"sample" envconfig:"SAMPLE" desc:"Fraction of requests to shadow"`
+	Tolerance float64       `yaml:"tolerance" envconfig:"TOLERANCE" desc:"Relative difference allowed between primary and candidate results"`
+	Timeout   time.Duration `yaml:"timeout" envconfig:"TIMEOUT" desc:"Deadline for each shadowed call"`
+}
+
+// Capture configures sampling requests and responses to a file for replay.
+type Capture struct {
+	File      string   `yaml:"file" envconfig:"FILE" desc:"File to capture to, empty turns capture off"`
+	Sample    float64  `yaml:"sample" envconfig:"SAMPLE" desc:"Fraction of requests to capture"`
+	MaxSizeMB int      `yaml:"max_size_mb" envconfig:"MAX_SIZE_MB" desc:"Size in MB at which the capture file is rotated, 0 never rotates"`
+	MaxFiles  int      `yaml:"max_files" envconfig:"MAX_FILES" desc:"Rotated capture files to keep"`
+	Redact    []string `yaml:"redact" envconfig:"REDACT" desc:"Comma separated metadata keys whose values are not captured"`
+}
+
+// Session configures MathSession calculator sessions.
+type Session struct {
+	IdleTimeout time.Duration `yaml:"idle_timeout" envconfig:"IDLE_TIMEOUT" desc:"How long a session may go without a command before the server ends it, 0 for no limit"`
+	History     string        `yaml:"history" envconfig:"HISTORY" desc:"File session transcripts are appended to, empty refuses the transcript command"`
+	MaxSizeMB   int           `yaml:"max_size_mb" envconfig:"MAX_SIZE_MB" desc:"Size in MB at which the history file is rotated, 0 never rotates"`
+	MaxFiles    int           `yaml:"max_files" envconfig:"MAX_FILES" desc:"Rotated history files to keep"`
+}
+
+// Runtime is the part of the configuration that is safe to change on SIGHUP.
+type Runtime struct {
+	LogLevel   string        `yaml:"log_level" split_words:"true" desc:"Log level (debug, info, warn, error), empty leaves LOGXI in charge"`
+	CacheTTL   time.Duration `yaml:"cache_ttl" envconfig:"CACHE_TTL" desc:"How long results stay in memcache"`
+	RateLimit  float64       `yaml:"rate_limit" split_words:"true" desc:"RPCs per second accepted across the server, 0 for no limit"`
+	RateBurst  int           `yaml:"rate_burst" split_words:"true" desc:"RPCs allowed in a burst above the rate limit"`
+	Features   Features      `yaml:"features" envconfig:"FEATURES"`
+	Validation Validation    `yaml:"validation" envconfig:"VALIDATION"`
+	Batch      Batch         `yaml:"batch" envconfig:"BATCH"`
+	Expression Expression    `yaml:"expression" envconfig:"EXPRESSION"`
+	Precise    Precise       `yaml:"precise" envconfig:"PRECISE"`
+	Money      Money         `yaml:"money" envconfig:"MONEY"`
+	Rational   Rational      `yaml:"rational" envconfig:"RATIONAL"`
+}
+
+// Features are switches for optional behaviour.
+type Features struct {
+	Cache bool `yaml:"cache" envconfig:"CACHE" desc:"Consult memcache before the database"`
+}
+
+// Validation says which operands and results the math methods accept.  Zero is always a
+// valid operand except as a divisor, NaN and the infinities are refused unless allowed here.
+type Validation struct {
+	AllowNaN      bool `yaml:"allow_nan" envconfig:"ALLOW_NAN" desc:"Accept NaN operands and results"`
+	AllowInfinity bool `yaml:"allow_infinity" envconfig:"ALLOW_INFINITY" desc:"Accept infinite operands and results"`
+}
+
+// Batch limits the Batch RPC, which takes one rate limit token however many items it has.
+type Batch struct {
+	MaxItems    int `yaml:"max_items" envconfig:"MAX_ITEMS" desc:"Most items a single Batch call may have"`
+	Concurrency int `yaml:"concurrency" envconfig:"CONCURRENCY" desc:"Items of a Batch call evaluated at once"`
+}
+
+// Expression limits the expressions the Evaluate RPC accepts.
+type Expression struct {
+	MaxLength int `yaml:"max_length" envconfig:"MAX_LENGTH" desc:"Longest expression Evaluate accepts, in bytes"`
+	MaxDepth  int `yaml:"max_depth" envconfig:"MAX_DEPTH" desc:"Deepest nesting of parentheses, prefix operators, ^ and function calls Evaluate accepts"`
+}
+
+// Precise limits the arbitrary precision MathPrecise methods.
+type Precise struct {
+	Precision    int `yaml:"precision" envconfig:"PRECISION" desc:"Significant digits of a result when the request asks for none"`
+	MaxPrecision int `yaml:"max_precision" envconfig:"MAX_PRECISION" desc:"Most significant digits a request may ask for"`
+	MaxLength    int `yaml:"max_length" envconfig:"MAX_LENGTH" desc:"Longest operand, in bytes"`
+	MaxExponent  int `yaml:"max_exponent" envconfig:"MAX_EXPONENT" desc:"Largest power of ten an operand may have, of either sign"`
+}
+
+// Money limits the MathMoney methods, whose amounts are also held to the Precise limits on
+// operands.
+type Money struct {
+	MaxScale int `yaml:"max_scale" envconfig:"MAX_SCALE" desc:"Most places after the point a result may have"`
+}
+
+// Rational limits the MathRational methods, whose numerators and denominators are also held
+// to the Precise limit on operand length.
+type Rational struct {
+	Digits    int `yaml:"digits" envconfig:"DIGITS" desc:"Places after the point of a result's decimal form when the request asks for none"`
+	MaxDigits int `yaml:"max_digits" envconfig:"MAX_DIGITS" desc:"Most places after the point a request may ask for"`
+}
+
+// Default returns the configuration used when nothing else is said.
+func Default() *Config {
+	return &Config{
+		GRPC: GRPC{
+			MinLife:         3600,
+			LifeRange:       1800,
+			BindAddress:     "0.0.0.0",
+			BindPort:        "8443",
+			JWTCertPath:     "/jwt_certs",
+			SSLCertPath:     "/certs/server.crt",
+			SSLKeyPath:      "/certs/server.key",
+			DebugAddress:    "0.0.0.0",
+			DebugPort:       "7777",
+			DrainPeriod:     5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+		},
+		Memcache: Memcache{
+			Servers: "mem01:11211;mem01:11212;mem01:11213;mem02:11211;mem02:11212;mem03:11213;mem03:11211;mem03:11212;mem02:11213",
+			Scope:   "MathSample",
+		},
+		Statsd: Statsd{
+			Address: "graphite:8125",
+			Prefix:  "grpc.mathsvc",
+			Sample:  1,
+		},
+		Trace: Trace{
+			Exporter: "none",
+			File:     "mathsvc-traces.jsonl",
+			Endpoint: "http://localhost:4318/v1/traces",
+		},
+		Shadow: Shadow{
+			Sample:    1,
+			Tolerance: 1e-9,
+			Timeout:   2 * time.Second,
+		},
+		Capture: Capture{
+			Sample:    0.01,
+			MaxSizeMB: 100,
+			MaxFiles:  5,
+			Redact:    []string{"authorization", "cookie", "x-api-key"},
+		},
+		Session: Session{
+			IdleTimeout: 5 * time.Minute,
+			MaxSizeMB:   100,
+			MaxFiles:    5,
+		},
+		Runtime: Runtime{
+			CacheTTL:   10 * time.Second,
+			RateBurst:  1,
+			Features:   Features{Cache: true},
+			Batch:      Batch{MaxItems: 1000, Concurrency: 16},
+			Expression: Expression{MaxLength: 4096, MaxDepth: 64},
+			Precise:    Precise{Precision: 34, MaxPrecision: 1000, MaxLength: 1000, MaxExponent: 10000},
+			Money:      Money{MaxScale: 18},
+			Rational:   Rational{Digits: 10, MaxDigits: 1000},
+		},
+	}
+}
+
+const config_prefix string = ""
+
+// Load builds the configuration from the defaults, the optional YAML file at path and the
+// environment, applies any overrides (such as Dev) and then validates it.
+func Load(path string, overrides ...func(*Config)) (*Config, error) {
+	c := Default()
+
+	if path != "" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading config file: %v", err)
+		}
+		if err := yaml.UnmarshalStrict(raw, c); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %v", path, err)
+		}
+	}
+
+	if err := envconfig.Process(config_prefix, c); err != nil {
+		return nil, fmt.Errorf("reading environment: %v", err)
+	}
+
+	for _, override := range overrides {
+		override(c)
+	}
+	if c.Dev {
+		applyDev(c)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Dev is a Load override that switches on development mode.
+func Dev(c *Config) {
+	c.Dev = true
+}
+
+// applyDev moves anything still listening on every interface to loopback, and lets the
+// process live until it is interrupted.
+func applyDev(c *Config) {
+	defaults := Default()
+	if c.GRPC.BindAddress == defaults.GRPC.BindAddress {
+		c.GRPC.BindAddress = "127.0.0.1"
+	}
+	if c.GRPC.DebugAddress == defaults.GRPC.DebugAddress {
+		c.GRPC.DebugAddress = "127.0.0.1"
+	}
+	c.GRPC.MinLife = 0
+	c.GRPC.LifeRange = 0
+	c.GRPC.DrainPeriod = 0
+}
+
+// isLoopback reports whether address (a host name or IP) only ever means this machine.
+func isLoopback(address string) bool {
+	if address == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(address)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Usage writes a table of the environment variables the configuration reads.
+func Usage(w io.Writer) error {
+	return envconfig.Usagef(config_prefix, Default(), w, envconfig.DefaultTableFormat)
+}
+
+// Validate checks the configuration for values that cannot work.
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.Dev {
+		for name, address := range map[string]string{"grpc.bind_address": c.GRPC.BindAddress, "grpc.debug_address": c.GRPC.DebugAddress} {
+			if !isLoopback(address) {
+				add("dev mode refuses to listen on %s %q, it is not a loopback address", name, address)
+			}
+		}
+	} else if c.DSN == "" {
+		add("dsn is required")
+	}
+	for name, port := range map[string]string{"grpc.bind_port": c.GRPC.BindPort, "grpc.debug_port": c.GRPC.DebugPort} {
+		if p, err := strconv.Atoi(port); err != nil || p < 0 || p > 65535 {
+			add("%s %q is not a port", name, port)
+		}
+	}
+	if c.GRPC.MinLife < 0 || c.GRPC.LifeRange < 0 {
+		add("grpc.min_life and grpc.life_range cannot be negative")
+	}
+	if c.GRPC.DrainPeriod < 0 || c.GRPC.ShutdownTimeout < 0 {
+		add("grpc.drain_period and grpc.shutdown_timeout cannot be negative")
+	}
+	if len(c.Memcache.ServerList()) == 0 && !c.Dev {
+		add("memcache.servers needs at least one server")
+	}
+	for _, server := range c.Memcache.ServerList() {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			add("memcache server %q: %v", server, err)
+		}
+	}
+	if c.Statsd.Sample < 0 || c.Statsd.Sample > 1 {
+		add("statsd.sample must be between 0 and 1")
+	}
+	switch c.Trace.Exporter {
+	case "none", "file", "otlp":
+	default:
+		add("trace.exporter %q must be none, file or otlp", c.Trace.Exporter)
+	}
+	if c.Shadow.Target != "" {
+		if _, _, err := net.SplitHostPort(c.Shadow.Target); err != nil {
+			add("shadow.target %q: %v", c.Shadow.Target, err)
+		}
+	}
+	if c.Shadow.Sample < 0 || c.Shadow.Sample > 1 {
+		add("shadow.sample must be between 0 and 1")
+	}
+	if c.Shadow.Tolerance < 0 {
+		add("shadow.tolerance cannot be negative")
+	}
+	if c.Shadow.Timeout <= 0 {
+		add("shadow.timeout must be positive")
+	}
+	if c.Capture.Sample < 0 || c.Capture.Sample > 1 {
+		add("capture.sample must be between 0 and 1")
+	}
+	if c.Capture.MaxSizeMB < 0 || c.Capture.MaxFiles < 0 {
+		add("capture.max_size_mb and capture.max_files cannot be negative")
+	}
+	if c.Session.IdleTimeout < 0 || c.Session.MaxSizeMB < 0 || c.Session.MaxFiles < 0 {
+		add("session.idle_timeout, session.max_size_mb and session.max_files cannot be negative")
+	}
+	if err := c.Runtime.Validate(); err != nil {
+		add("%v", err)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Validate checks the reloadable part of the configuration.
+func (r Runtime) Validate() error {
+	if r.LogLevel != "" {
+		if _, err := logging.ParseLevel(r.LogLevel); err != nil {
+			return err
+		}
+	}
+	if r.CacheTTL < time.Second {
+		return fmt.Errorf("cache_ttl %s must be at least a second", r.CacheTTL)
+	}
+	if r.RateLimit < 0 {
+		return fmt.Errorf("rate_limit cannot be negative")
+	}
+	if r.RateLimit > 0 && r.RateBurst < 1 {
+		return fmt.Errorf("rate_burst must be at least 1 when rate_limit is set")
+	}
+	if r.Batch.MaxItems < 1 || r.Batch.Concurrency < 1 {
+		return fmt.Errorf("batch.max_items and batch.concurrency must be at least 1")
+	}
+	if r.Expression.MaxLength < 1 || r.Expression.MaxDepth < 1 {
+		return fmt.Errorf("expression.max_length and expression.max_depth must be at least 1")
+	}
+	if r.Precise.Precision < 1 || r.Precise.MaxPrecision < r.Precise.Precision || r.Precise.MaxLength < 1 || r.Precise.MaxExponent < 1 {
+		return fmt.Errorf("precise.precision must be between 1 and precise.max_precision, precise.max_length and precise.max_exponent at least 1")
+	}
+	if r.Money.MaxScale < 0 {
+		return fmt.Errorf("money.max_scale cannot be negative")
+	}
+	if r.Rational.Digits < 1 || r.Rational.MaxDigits < r.Rational.Digits {
+		return fmt.Errorf("rational.digits must be between 1 and rational.max_digits")
+	}
+	return nil
+}
+
+// Redacted returns a copy with every field tagged secret blanked out.
+func (c *Config) Redacted() *Config {
+	out := *c
+	redact(reflect.ValueOf(&out).Elem())
+	return &out
+}
+
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		case v.Type().Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "":
+			field.SetString("REDACTED")
+		}
+	}
+}
+
+// String renders the effective configuration as YAML, with secrets redacted.
+func (c *Config) String() string {
+	out, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("unprintable configuration: %v", err)
+	}
+	return string(out)
+}
+
+// Live holds the current Runtime settings for components that pick up reloads.
+type Live struct {
+	value atomic.Value
+}
+
+// NewLive creates a Live holding r, which is expected to have been validated.
+func NewLive(r Runtime) *Live {
+	l := &Live{}
+	l.Store(r)
+	return l
+}
+
+// Load returns the current runtime settings.
+func (l *Live) Load() Runtime {
+	return l.value.Load().(Runtime)
+}
+
+// Store replaces the runtime settings and applies the log level.
+func (l *Live) Store(r Runtime) error {
+	l.value.Store(r)
+	return logging.SetLevel(r.LogLevel)
+}
+
+// Reload re-reads the configuration and applies its Runtime part.  Anything outside Runtime
+// that changed is returned by name, since those need a restart.
+func (l *Live) Reload(path string, current *Config) ([]string, error) {
+	next, err := Load(path, func(c *Config) { c.Dev = c.Dev || current.Dev })
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Store(next.Runtime); err != nil {
+		return nil, err
+	}
+
+	var restart []string
+	for _, section := range []struct {
+		name    string
+		was, is interface{}
+	}{
+		{"dev", current.Dev, next.Dev},
+		{"dsn", current.DSN, next.DSN},
+		{"grpc", current.GRPC, next.GRPC},
+		{"memcache", current.Memcache, next.Memcache},
+		{"statsd", current.Statsd, next.Statsd},
+		{"trace", current.Trace, next.Trace},
+		{"shadow", current.Shadow, next.Shadow},
+		{"capture", current.Capture, next.Capture},
+		{"session", current.Session, next.Session},
+	} {
+		if !reflect.DeepEqual(section.was, section.is) {
+			restart = append(restart, section.name)
+		}
+	}
+	return restart, nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "mathsvc-config")
+	if err != nil {
+		t.Fatalf("Unable to make temp dir: %s", err.Error())
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "mathsvc.yaml")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("Unable to write config: %s", err.Error())
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfig(t, `
+dsn: user/secret@db:1521/svc
+grpc:
+  bind_port: "9443"
+  drain_period: 2s
+memcache:
+  servers: "a:11211;b:11211"
+cache_ttl: 30s
+features:
+  cache: false
+validation:
+  allow_nan: true
+`)
+	t.Setenv("GRPC_BIND_PORT", "10443")
+	t.Setenv("RATE_LIMIT", "50")
+	t.Setenv("RATE_BURST", "10")
+	t.Setenv("VALIDATION_ALLOW_INFINITY", "true")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+
+	var cases = []struct {
+		Case string
+		Got  interface{}
+		Want interface{}
+	}{
+		{Case: "File", Got: c.DSN, Want: "user/secret@db:1521/svc"},
+		{Case: "Env Over File", Got: c.GRPC.BindPort, Want: "10443"},
+		{Case: "File Duration", Got: c.GRPC.DrainPeriod, Want: 2 * time.Second},
+		{Case: "Default", Got: c.GRPC.DebugPort, Want: "7777"},
+		{Case: "Memcache Servers", Got: len(c.Memcache.ServerList()), Want: 2},
+		{Case: "Runtime From File", Got: c.CacheTTL, Want: 30 * time.Second},
+		{Case: "Feature From File", Got: c.Features.Cache, Want: false},
+		{Case: "Runtime From Env", Got: c.RateLimit, Want: 50.0},
+		{Case: "Validation From File", Got: c.Validation.AllowNaN, Want: true},
+		{Case: "Validation From Env", Got: c.Validation.AllowInfinity, Want: true},
+	}
+	for n, cs := range cases {
+		if cs.Got != cs.Want {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, cs.Case, cs.Got, cs.Want)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	var cases = []struct {
+		Case     string
+		Contents string
+		Want     string
+	}{
+		{Case: "Missing DSN", Contents: "grpc: {bind_port: '1'}", Want: "dsn is required"},
+		{Case: "Bad Port", Contents: "dsn: x\ngrpc: {bind_port: nope}", Want: "grpc.bind_port"},
+		{Case: "Bad Exporter", Contents: "dsn: x\ntrace: {exporter: zipkin}", Want: "trace.exporter"},
+		{Case: "Bad Log Level", Contents: "dsn: x\nlog_level: loud", Want: "unknown log level"},
+		{Case: "Short TTL", Contents: "dsn: x\ncache_ttl: 1ms", Want: "cache_ttl"},
+		{Case: "Unknown Key", Contents: "dsn: x\nmemcached: {}", Want: "memcached"},
+		{Case: "Bad Shadow Target", Contents: "dsn: x\nshadow: {target: candidate}", Want: "shadow.target"},
+		{Case: "Empty Batch", Contents: "dsn: x\nbatch: {max_items: 0}", Want: "batch.max_items"},
+		{Case: "Negative Idle Timeout", Contents: "dsn: x\nsession: {idle_timeout: -1s}", Want: "session.idle_timeout"},
+		{Case: "No Expression Depth", Contents: "dsn: x\nexpression: {max_depth: 0}", Want: "expression.max_depth"},
+		{Case: "Precision Past Its Maximum", Contents: "dsn: x\nprecise: {precision: 50, max_precision: 40}", Want: "precise.precision"},
+		{Case: "Negative Money Scale", Contents: "dsn: x\nmoney: {max_scale: -1}", Want: "money.max_scale"},
+		{Case: "Too Many Rational Digits", Contents: "dsn: x\nrational: {digits: 20, max_digits: 10}", Want: "rational.digits"},
+	}
+	for n, c := range cases {
+		_, err := Load(writeConfig(t, c.Contents))
+		if err == nil {
+			t.Errorf("Case: %d: %s: Expected error, none reported.", n, c.Case)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.Want) {
+			t.Errorf("Case: %d: %s: Error %q does not mention %q", n, c.Case, err.Error(), c.Want)
+		}
+	}
+}
+
+func TestConfig_String(t *testing.T) {
+	c := Default()
+	c.DSN = "user/secret@db:1521/svc"
+
+	out := c.String()
+	if strings.Contains(out, "secret") {
+		t.Errorf("DSN was not redacted:\n%s", out)
+	}
+	if !strings.Contains(out, "dsn: REDACTED") || !strings.Contains(out, "drain_period: 5s") {
+		t.Errorf("Unexpected rendering:\n%s", out)
+	}
+	if c.DSN != "user/secret@db:1521/svc" {
+		t.Errorf("Redaction modified the original config")
+	}
+}
+
+func TestLive_Reload(t *testing.T) {
+	path := writeConfig(t, "dsn: x\ncache_ttl: 10s\n")
+	current, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	live := NewLive(current.Runtime)
+
+	if err := ioutil.WriteFile(path, []byte("dsn: y\ncache_ttl: 1m\nfeatures: {cache: false}\n"), 0600); err != nil {
+		t.Fatalf("Unable to rewrite config: %s", err.Error())
+	}
+	restart, err := live.Reload(path, current)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if got := live.Load(); got.CacheTTL != time.Minute || got.Features.Cache {
+		t.Errorf("Runtime was not reloaded: %+v", got)
+	}
+	if len(restart) != 1 || restart[0] != "dsn" {
+		t.Errorf("Expected only dsn to need a restart, got %v", restart)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("dsn: y\ncache_ttl: nope\n"), 0600); err != nil {
+		t.Fatalf("Unable to rewrite config: %s", err.Error())
+	}
+	if _, err := live.Reload(path, current); err == nil {
+		t.Errorf("Expected a broken config to be rejected")
+	}
+	if got := live.Load(); got.CacheTTL != time.Minute {
+		t.Errorf("A rejected reload changed the runtime: %+v", got)
+	}
+}
+
+func TestLoad_Dev(t *testing.T) {
+	c, err := Load(writeConfig(t, "grpc: {bind_port: '8443'}\n"), Dev)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if c.GRPC.BindAddress != "127.0.0.1" || c.GRPC.DebugAddress != "127.0.0.1" {
+		t.Errorf("Dev mode did not move to loopback: %+v", c.GRPC)
+	}
+
+	_, err = Load(writeConfig(t, "grpc: {bind_address: 10.0.0.1}\n"), Dev)
+	if err == nil || !strings.Contains(err.Error(), "loopback") {
+		t.Errorf("Expected a non-loopback dev bind to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,299 @@
+// Package decimal is arbitrary precision decimal arithmetic on math/big: a Decimal is a
+// big.Int coefficient times a power of ten, so decimal strings such as "0.1" are held
+// exactly, sums and products are exact, and only a quotient, or a result asked to fit in
+// fewer digits or places, is ever rounded.  Rounding to significant digits is to the
+// nearest, ties to even, as IEEE 754 does, rounding to a scale takes a Rounding.  big.Float
+// is not used: it rounds in binary, and rounding a binary result again to decimal digits
+// can be wrong in the last digit.
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is coef × 10^exp.  The zero value is not usable, make one with Parse.
+type Decimal struct {
+	coef *big.Int
+	exp  int
+}
+
+// Rounding says which way a result between two representable values goes.
+type Rounding int
+
+const (
+	// HalfEven goes to the nearer, ties to the one with an even last digit.
+	HalfEven Rounding = iota
+	// HalfUp goes to the nearer, ties away from zero.
+	HalfUp
+	// Down goes toward zero, truncating.
+	Down
+	// Ceiling goes toward positive infinity.
+	Ceiling
+	// Floor goes toward negative infinity.
+	Floor
+)
+
+var (
+	one = big.NewInt(1)
+	ten = big.NewInt(10)
+)
+
+// syntax is a decimal number, with an optional sign, fraction and exponent.
+var syntax = regexp.MustCompile(`^([+-]?)([0-9]*)(?:\.([0-9]*))?(?:[eE]([+-]?[0-9]+))?$`)
+
+// Parse reads a decimal such as "12", "-0.50" or "6.02e23".  An exponent past maxExponent
+// in size is refused, it would take that many digits to compute with.
+func Parse(s string, maxExponent int) (Decimal, error) {
+	m := syntax.FindStringSubmatch(s)
+	if m == nil || m[2] == "" && m[3] == "" {
+		return Decimal{}, fmt.Errorf("%q is not a decimal number", s)
+	}
+	exp := 0
+	if m[4] != "" {
+		var err error
+		if exp, err = strconv.Atoi(m[4]); err != nil || exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, fmt.Errorf("the exponent of %q is beyond ±%d", s, maxExponent)
+		}
+	}
+	coef, _ := new(big.Int).SetString(m[1]+m[2]+m[3], 10)
+	return Decimal{coef: coef, exp: exp - len(m[3])}, nil
+}
+
+// String formats d in plain notation, as in "-0.0025", unless that would need zeros that
+// are not significant or more than six leading ones, when it is "2.5e-7" or "1.50e+40".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	adjusted := d.exp + len(digits) - 1
+	if d.coef.Sign() == 0 && d.exp > 0 {
+		return "0"
+	}
+	if d.exp <= 0 && adjusted >= -6 {
+		point := len(digits) + d.exp
+		switch {
+		case d.exp == 0:
+			return sign + digits
+		case point > 0:
+			return sign + digits[:point] + "." + digits[point:]
+		default:
+			return sign + "0." + strings.Repeat("0", -point) + digits
+		}
+	}
+	mantissa := digits[:1]
+	if len(digits) > 1 {
+		mantissa += "." + digits[1:]
+	}
+	return fmt.Sprintf("%s%se%+d", sign, mantissa, adjusted)
+}
+
+// Plain formats d in plain notation whatever its exponent, as amounts of money are written.
+func (d Decimal) Plain() string {
+	if d.exp > 0 {
+		return new(big.Int).Mul(d.coef, pow10(d.exp)).String()
+	}
+	digits := new(big.Int).Abs(d.coef).String()
+	if len(digits) <= -d.exp {
+		digits = strings.Repeat("0", 1-d.exp-len(digits)) + digits
+	}
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.exp == 0 {
+		return sign + digits
+	}
+	point := len(digits) + d.exp
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Sign is -1, 0 or +1.
+func (d Decimal) Sign() int {
+	return d.coef.Sign()
+}
+
+// Add is a + b, exactly.
+func Add(a, b Decimal) Decimal {
+	x, y, exp := align(a, b)
+	return Decimal{coef: x.Add(x, y), exp: exp}
+}
+
+// Sub is a - b, exactly.
+func Sub(a, b Decimal) Decimal {
+	x, y, exp := align(a, b)
+	return Decimal{coef: x.Sub(x, y), exp: exp}
+}
+
+// Mul is a × b, exactly.
+func Mul(a, b Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(a.coef, b.coef), exp: a.exp + b.exp}
+}
+
+// Quo is a / b to digits significant digits, and whether it had to be rounded to fit.  b
+// must not be zero.  An exact quotient has no trailing zeros past the ones a and b imply.
+func Quo(a, b Decimal, digits int) (Decimal, bool) {
+	num, den := new(big.Int).Set(a.coef), new(big.Int).Set(b.coef)
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	q, rounded := round(num, den, a.exp-b.exp, digits)
+	if !rounded {
+		q = q.reduce(a.exp - b.exp)
+	}
+	return q, rounded
+}
+
+// QuoScale is a / b with scale places after the point, rounded by mode, and whether it had
+// to be rounded.  b must not be zero.
+func QuoScale(a, b Decimal, scale int, mode Rounding) (Decimal, bool) {
+	num, den := new(big.Int).Set(a.coef), new(big.Int).Set(b.coef)
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	if shift := a.exp - b.exp + scale; shift > 0 {
+		num.Mul(num, pow10(shift))
+	} else if shift < 0 {
+		den.Mul(den, pow10(-shift))
+	}
+	q, rounded := roundQuo(num, den, mode)
+	return Decimal{coef: q, exp: -scale}, rounded
+}
+
+// Rescale is d with scale places after the point, rounded by mode, and whether that
+// changed it.
+func (d Decimal) Rescale(scale int, mode Rounding) (Decimal, bool) {
+	switch shift := d.exp + scale; {
+	case shift > 0:
+		return Decimal{coef: new(big.Int).Mul(d.coef, pow10(shift)), exp: -scale}, false
+	case shift < 0:
+		q, rounded := roundQuo(d.coef, pow10(-shift), mode)
+		return Decimal{coef: q, exp: -scale}, rounded
+	}
+	return d, false
+}
+
+// Round is d to digits significant digits, and whether that changed it.
+func (d Decimal) Round(digits int) (Decimal, bool) {
+	if precision(d.coef) <= digits {
+		return d, false
+	}
+	return round(d.coef, one, d.exp, digits)
+}
+
+// align gives a and b the smaller of their exponents, returning new coefficients.
+func align(a, b Decimal) (*big.Int, *big.Int, int) {
+	x, y := new(big.Int).Set(a.coef), new(big.Int).Set(b.coef)
+	switch {
+	case a.exp > b.exp:
+		x.Mul(x, pow10(a.exp-b.exp))
+		return x, y, b.exp
+	case b.exp > a.exp:
+		y.Mul(y, pow10(b.exp-a.exp))
+	}
+	return x, y, a.exp
+}
+
+// round is num/den × 10^exp, den positive, to digits significant digits.
+func round(num, den *big.Int, exp, digits int) (Decimal, bool) {
+	if num.Sign() == 0 {
+		return Decimal{coef: new(big.Int), exp: exp}, false
+	}
+	// The leading digit of num/den is 10^magnitude or 10^(magnitude-1).
+	magnitude := precision(num) - precision(den)
+	if compareShifted(num, den, magnitude) < 0 {
+		magnitude--
+	}
+	shift := digits - 1 - magnitude
+	n, d := new(big.Int).Set(num), new(big.Int).Set(den)
+	if shift > 0 {
+		n.Mul(n, pow10(shift))
+	} else if shift < 0 {
+		d.Mul(d, pow10(-shift))
+	}
+	q, rounded := roundQuo(n, d, HalfEven)
+	exp -= shift
+	if precision(q) > digits {
+		// Rounded up to a power of ten, 9.99 to 10.0, the last digit is a zero.
+		q.Quo(q, ten)
+		exp++
+	}
+	return Decimal{coef: q, exp: exp}, rounded
+}
+
+// compareShifted compares |num| with den × 10^shift.
+func compareShifted(num, den *big.Int, shift int) int {
+	n, d := new(big.Int).Abs(num), new(big.Int).Set(den)
+	if shift > 0 {
+		d.Mul(d, pow10(shift))
+	} else if shift < 0 {
+		n.Mul(n, pow10(-shift))
+	}
+	return n.Cmp(d)
+}
+
+// roundQuo is num/den rounded to an integer by mode, and whether it was inexact.  den must
+// be positive.
+func roundQuo(num, den *big.Int, mode Rounding) (*big.Int, bool) {
+	// q is truncated toward zero, so away from zero is the only way it can need to go.
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q, false
+	}
+	negative := num.Sign() < 0
+	var away bool
+	switch mode {
+	case Ceiling:
+		away = !negative
+	case Floor:
+		away = negative
+	case HalfEven, HalfUp:
+		twice := r.Abs(r)
+		twice.Lsh(twice, 1)
+		c := twice.Cmp(den)
+		away = c > 0 || c == 0 && (mode == HalfUp || q.Bit(0) == 1)
+	}
+	if away && negative {
+		q.Sub(q, one)
+	} else if away {
+		q.Add(q, one)
+	}
+	return q, true
+}
+
+// reduce drops trailing zeros of d's coefficient while its exponent stays at most ideal.
+func (d Decimal) reduce(ideal int) Decimal {
+	coef, exp := new(big.Int).Set(d.coef), d.exp
+	q, r := new(big.Int), new(big.Int)
+	for exp < ideal && coef.Sign() != 0 {
+		q.QuoRem(coef, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef.Set(q)
+		exp++
+	}
+	if coef.Sign() == 0 && exp < ideal {
+		exp = ideal
+	}
+	return Decimal{coef: coef, exp: exp}
+}
+
+// precision is the number of decimal digits in x, 1 for zero.
+func precision(x *big.Int) int {
+	if x.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(x).String())
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
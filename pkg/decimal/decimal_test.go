@@ -0,0 +1,148 @@
+package decimal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var cases = []struct {
+		Case    string
+		In      string
+		Want    string
+		WantErr bool
+	}{
+		{Case: "Integer", In: "12", Want: "12"},
+		{Case: "Signs", In: "-0.50", Want: "-0.50"},
+		{Case: "Plus", In: "+.5", Want: "0.5"},
+		{Case: "Trailing point", In: "5.", Want: "5"},
+		{Case: "Exponent", In: "6.02e23", Want: "6.02e+23"},
+		{Case: "Small", In: "25e-8", Want: "2.5e-7"},
+		{Case: "Not too small", In: "0.0000025", Want: "0.0000025"},
+		{Case: "Zero", In: "-0", Want: "0"},
+		{Case: "Long", In: "123456789012345678901234567890.123456789", Want: "123456789012345678901234567890.123456789"},
+		{Case: "Empty", In: "", WantErr: true},
+		{Case: "Sign only", In: "-", WantErr: true},
+		{Case: "Point only", In: ".", WantErr: true},
+		{Case: "Exponent only", In: "e5", WantErr: true},
+		{Case: "Fraction", In: "1/3", WantErr: true},
+		{Case: "Infinity", In: "Inf", WantErr: true},
+		{Case: "Huge exponent", In: "1e1001", WantErr: true},
+		{Case: "Overflowing exponent", In: "1e99999999999999999999", WantErr: true},
+	}
+	for n, c := range cases {
+		d, err := Parse(c.In, 1000)
+		if (err != nil) != c.WantErr {
+			t.Errorf("Case: %d: %s: Got error %v, want error %t", n, c.Case, err, c.WantErr)
+			continue
+		}
+		if err == nil && d.String() != c.Want {
+			t.Errorf("Case: %d: %s: Got %s, want %s", n, c.Case, d, c.Want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	var cases = []struct {
+		Case        string
+		Op          string
+		A, B        string
+		Digits      int
+		Want        string
+		WantRounded bool
+	}{
+		{Case: "Tenths", Op: "add", A: "0.1", B: "0.2", Digits: 34, Want: "0.3"},
+		{Case: "Keeps its scale", Op: "add", A: "1.50", B: "1.50", Digits: 34, Want: "3.00"},
+		{Case: "Large integers", Op: "add", A: "9007199254740993", B: "1", Digits: 34, Want: "9007199254740994"},
+		{Case: "Cancellation", Op: "add", A: "1e30", B: "-1e30", Digits: 34, Want: "0"},
+		{Case: "Add rounded", Op: "add", A: "1e40", B: "1", Digits: 34, Want: "1.000000000000000000000000000000000e+40", WantRounded: true},
+		{Case: "Multiply", Op: "multiply", A: "1.1", B: "1.1", Digits: 34, Want: "1.21"},
+		{Case: "Multiply large", Op: "multiply", A: "123456789012345678901234567890", B: "987654321098765432109876543210", Digits: 100, Want: "121932631137021795226185032733622923332237463801111263526900"},
+		{Case: "Multiply rounded", Op: "multiply", A: "99999", B: "99999", Digits: 4, Want: "1.000e+10", WantRounded: true},
+		{Case: "Divide exactly", Op: "divide", A: "1", B: "4", Digits: 34, Want: "0.25"},
+		{Case: "Divide keeps its scale", Op: "divide", A: "10.00", B: "4", Digits: 34, Want: "2.50"},
+		{Case: "Divide integers", Op: "divide", A: "100", B: "4", Digits: 34, Want: "25"},
+		{Case: "A third", Op: "divide", A: "1", B: "3", Digits: 10, Want: "0.3333333333", WantRounded: true},
+		{Case: "Two thirds", Op: "divide", A: "-2", B: "3", Digits: 10, Want: "-0.6666666667", WantRounded: true},
+		{Case: "Negative divisor", Op: "divide", A: "2", B: "-3", Digits: 3, Want: "-0.667", WantRounded: true},
+		{Case: "Ties to even", Op: "divide", A: "0.25", B: "1", Digits: 1, Want: "0.2", WantRounded: true},
+		{Case: "Ties to even up", Op: "divide", A: "0.35", B: "1", Digits: 1, Want: "0.4", WantRounded: true},
+		{Case: "Rounds to a power of ten", Op: "divide", A: "9.99", B: "1", Digits: 2, Want: "10", WantRounded: true},
+		{Case: "Tiny quotient", Op: "divide", A: "1", B: "7e20", Digits: 5, Want: "1.4286e-21", WantRounded: true},
+		{Case: "Zero quotient", Op: "divide", A: "0.00", B: "3", Digits: 5, Want: "0.00"},
+	}
+	for n, c := range cases {
+		a, _ := Parse(c.A, 1000)
+		b, _ := Parse(c.B, 1000)
+		var got Decimal
+		var rounded bool
+		switch c.Op {
+		case "add":
+			got, rounded = Add(a, b).Round(c.Digits)
+		case "multiply":
+			got, rounded = Mul(a, b).Round(c.Digits)
+		case "divide":
+			got, rounded = Quo(a, b, c.Digits)
+		}
+		if got.String() != c.Want || rounded != c.WantRounded {
+			t.Errorf("Case: %d: %s: Got %s rounded %t, want %s rounded %t", n, c.Case, got, rounded, c.Want, c.WantRounded)
+		}
+	}
+}
+
+func TestLongDivision(t *testing.T) {
+	a, _ := Parse("1", 1000)
+	b, _ := Parse("7", 1000)
+	got, rounded := Quo(a, b, 600)
+	want := "0." + strings.Repeat("142857", 100)
+	if got.String() != want || !rounded {
+		t.Errorf("Got %s rounded %t, want %s rounded", got, rounded, want)
+	}
+}
+
+func TestRescale(t *testing.T) {
+	var cases = []struct {
+		Case        string
+		In          string
+		Scale       int
+		Mode        Rounding
+		Want        string
+		WantRounded bool
+	}{
+		{Case: "Half even down", In: "2.345", Scale: 2, Mode: HalfEven, Want: "2.34", WantRounded: true},
+		{Case: "Half even up", In: "2.355", Scale: 2, Mode: HalfEven, Want: "2.36", WantRounded: true},
+		{Case: "Half even negative", In: "-2.345", Scale: 2, Mode: HalfEven, Want: "-2.34", WantRounded: true},
+		{Case: "Half up", In: "2.345", Scale: 2, Mode: HalfUp, Want: "2.35", WantRounded: true},
+		{Case: "Half up negative", In: "-2.345", Scale: 2, Mode: HalfUp, Want: "-2.35", WantRounded: true},
+		{Case: "Half up under half", In: "2.3449", Scale: 2, Mode: HalfUp, Want: "2.34", WantRounded: true},
+		{Case: "Down", In: "2.349", Scale: 2, Mode: Down, Want: "2.34", WantRounded: true},
+		{Case: "Down negative", In: "-2.349", Scale: 2, Mode: Down, Want: "-2.34", WantRounded: true},
+		{Case: "Ceiling", In: "2.341", Scale: 2, Mode: Ceiling, Want: "2.35", WantRounded: true},
+		{Case: "Ceiling negative", In: "-2.349", Scale: 2, Mode: Ceiling, Want: "-2.34", WantRounded: true},
+		{Case: "Floor", In: "2.349", Scale: 2, Mode: Floor, Want: "2.34", WantRounded: true},
+		{Case: "Floor negative", In: "-2.341", Scale: 2, Mode: Floor, Want: "-2.35", WantRounded: true},
+		{Case: "Exact", In: "2.3400", Scale: 2, Mode: Ceiling, Want: "2.34"},
+		{Case: "Padded", In: "7", Scale: 2, Mode: HalfEven, Want: "7.00"},
+		{Case: "Whole units", In: "1e3", Scale: 0, Mode: HalfEven, Want: "1000"},
+		{Case: "Under a cent", In: "0.004", Scale: 2, Mode: HalfEven, Want: "0.00", WantRounded: true},
+		{Case: "Tiny", In: "-0.0000001", Scale: 8, Mode: HalfEven, Want: "-0.00000010"},
+	}
+	for n, c := range cases {
+		d, _ := Parse(c.In, 1000)
+		got, rounded := d.Rescale(c.Scale, c.Mode)
+		if got.Plain() != c.Want || rounded != c.WantRounded {
+			t.Errorf("Case: %d: %s: Got %s rounded %t, want %s rounded %t", n, c.Case, got.Plain(), rounded, c.Want, c.WantRounded)
+		}
+	}
+
+	a, _ := Parse("100", 1000)
+	b, _ := Parse("3", 1000)
+	for mode, want := range map[Rounding]string{HalfEven: "33.33", Ceiling: "33.34", Floor: "33.33"} {
+		if got, rounded := QuoScale(a, b, 2, mode); got.Plain() != want || !rounded {
+			t.Errorf("QuoScale(100, 3, 2, %d): Got %s rounded %t, want %s rounded", mode, got.Plain(), rounded, want)
+		}
+	}
+	if got, _ := QuoScale(Sub(b, a), b, 2, Floor); got.Plain() != "-32.34" {
+		t.Errorf("QuoScale(-97, 3, 2, Floor): Got %s, want -32.34", got.Plain())
+	}
+}
@@ -0,0 +1,147 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+)
+
+// constants are the names that need no binding.
+var constants = map[string]float64{"pi": math.Pi, "e": math.E}
+
+// function is one of the library, arity -1 takes one or more arguments.
+type function struct {
+	arity int
+	fn    func(args []float64) float64
+}
+
+func (f function) arguments() string {
+	switch f.arity {
+	case -1:
+		return "one or more arguments"
+	case 1:
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", f.arity)
+}
+
+func unary(fn func(float64) float64) function {
+	return function{arity: 1, fn: func(args []float64) float64 { return fn(args[0]) }}
+}
+
+func binaryFunction(fn func(float64, float64) float64) function {
+	return function{arity: 2, fn: func(args []float64) float64 { return fn(args[0], args[1]) }}
+}
+
+func fold(fn func(float64, float64) float64) function {
+	return function{arity: -1, fn: func(args []float64) float64 {
+		result := args[0]
+		for _, x := range args[1:] {
+			result = fn(result, x)
+		}
+		return result
+	}}
+}
+
+// functions is the library.
+var functions = map[string]function{
+	"abs":   unary(math.Abs),
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"exp":   unary(math.Exp),
+	"ln":    unary(math.Log),
+	"log2":  unary(math.Log2),
+	"log10": unary(math.Log10),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"round": unary(math.Round),
+	"trunc": unary(math.Trunc),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"pow":   binaryFunction(math.Pow),
+	"atan2": binaryFunction(math.Atan2),
+	"hypot": binaryFunction(math.Hypot),
+	"mod":   binaryFunction(math.Mod),
+	"min":   fold(math.Min),
+	"max":   fold(math.Max),
+}
+
+// Evaluate computes the expression with its variables bound by bindings.  Every step is
+// held to policy as the Math methods are, so division by zero is refused and so is any NaN
+// or infinity the policy does not allow, wherever in the expression it turns up.
+func (e *Expression) Evaluate(bindings map[string]float64, policy config.Validation) (float64, error) {
+	return evaluate(e.root, bindings, policy)
+}
+
+func evaluate(n node, bindings map[string]float64, policy config.Validation) (float64, error) {
+	switch n := n.(type) {
+	case *number:
+		return n.value, nil
+	case *variable:
+		if value, ok := constants[n.name]; ok {
+			return value, nil
+		}
+		value, ok := bindings[n.name]
+		if !ok {
+			return 0, &Error{Offset: n.at, Message: fmt.Sprintf("%s is not bound", n.name)}
+		}
+		return value, nil
+	case *negation:
+		x, err := evaluate(n.x, bindings, policy)
+		return -x, err
+	case *binary:
+		x, err := evaluate(n.x, bindings, policy)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evaluate(n.y, bindings, policy)
+		if err != nil {
+			return 0, err
+		}
+		var result float64
+		switch n.op {
+		case '+':
+			result = x + y
+		case '-':
+			result = x - y
+		case '*':
+			result = x * y
+		case '/':
+			if y == 0 {
+				return 0, &Error{Offset: n.at, Message: "division by zero"}
+			}
+			result = x / y
+		case '^':
+			result = math.Pow(x, y)
+		}
+		return checked(policy, n.at, string(n.op), result)
+	case *call:
+		args := make([]float64, len(n.args))
+		for i, arg := range n.args {
+			x, err := evaluate(arg, bindings, policy)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = x
+		}
+		return checked(policy, n.at, n.name, functions[n.name].fn(args))
+	}
+	panic(fmt.Sprintf("expr: unknown node %T", n))
+}
+
+// checked refuses a result the policy does not allow.
+func checked(policy config.Validation, at int, what string, result float64) (float64, error) {
+	if validate.Refused(policy, result) {
+		return 0, &Error{Offset: at, Message: fmt.Sprintf("%s gives %v, which is not allowed", what, result)}
+	}
+	return result, nil
+}
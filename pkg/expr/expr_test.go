@@ -0,0 +1,138 @@
+package expr
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+)
+
+var limits = config.Expression{MaxLength: 100, MaxDepth: 8}
+
+func TestEvaluate(t *testing.T) {
+	x := map[string]float64{"x": 7, "y": -2}
+	var cases = []struct {
+		Case       string
+		Expression string
+		Policy     config.Validation
+		Want       float64
+		WantNormal string
+		WantError  string
+	}{
+		{Case: "Precedence", Expression: "(3+4)*2/x", Want: 2, WantNormal: "(3 + 4) * 2 / x"},
+		{Case: "Left to right", Expression: "8 - 3 - 2", Want: 3, WantNormal: "8 - 3 - 2"},
+		{Case: "Grouped right", Expression: "8 - (3 - 2)", Want: 7, WantNormal: "8 - (3 - 2)"},
+		{Case: "Redundant parentheses", Expression: "((1)) + (2 * 3)", Want: 7, WantNormal: "1 + 2 * 3"},
+		{Case: "Addition keeps its grouping", Expression: "1 + (2 + 3)", Want: 6, WantNormal: "1 + (2 + 3)"},
+		{Case: "Unary minus", Expression: "-x * --3", Want: -21, WantNormal: "-x * --3"},
+		{Case: "Unary plus", Expression: "+x", Want: 7, WantNormal: "x"},
+		{Case: "Power binds tighter than minus", Expression: "-2^2", Want: -4, WantNormal: "-2^2"},
+		{Case: "Power of a negative", Expression: "(-2)^2", Want: 4, WantNormal: "(-2)^2"},
+		{Case: "Power groups right", Expression: "2^3^2", Want: 512, WantNormal: "2^3^2"},
+		{Case: "Negative exponent", Expression: "2^-1", Want: 0.5, WantNormal: "2^-1"},
+		{Case: "Numbers", Expression: "1.50 + .5e1 + 2E-1", Want: 6.7, WantNormal: "1.5 + 5 + 0.2"},
+		{Case: "Functions", Expression: "max(abs(y), sqrt(16), 3) + min(x)", Want: 11, WantNormal: "max(abs(y), sqrt(16), 3) + min(x)"},
+		{Case: "Constants", Expression: "cos(pi) * ln(e)", Want: -1, WantNormal: "cos(pi) * ln(e)"},
+		{Case: "Division by zero", Expression: "1 / (x - 7)", WantError: "at offset 2: division by zero"},
+		{Case: "Domain", Expression: "1 + sqrt(y)", WantError: "at offset 4: sqrt gives NaN, which is not allowed"},
+		{Case: "Domain allowed", Expression: "sqrt(y)", Policy: config.Validation{AllowNaN: true}, Want: math.NaN(), WantNormal: "sqrt(y)"},
+		{Case: "Overflow", Expression: "10^400", WantError: "at offset 2: ^ gives +Inf, which is not allowed"},
+		{Case: "Unbound", Expression: "x + z", WantError: "at offset 4: z is not bound"},
+		{Case: "Unclosed", Expression: "(1 + 2", WantError: "at offset 6: the ( at offset 0 is never closed"},
+		{Case: "Unopened", Expression: "1 + 2)", WantError: `at offset 5: unexpected ")"`},
+		{Case: "Missing operand", Expression: "1 +", WantError: "at offset 3: unexpected end of expression"},
+		{Case: "Bad character", Expression: "1 % 2", WantError: `at offset 2: unexpected '%'`},
+		{Case: "Bad exponent", Expression: "1e+", WantError: "at offset 1: the exponent has no digits"},
+		{Case: "Out of range", Expression: "1e999", WantError: "at offset 0: 1e999 is out of range"},
+		{Case: "Unknown function", Expression: "2 * foo(1)", WantError: "at offset 4: foo is not a function"},
+		{Case: "Arity", Expression: "pow(2)", WantError: "at offset 0: pow takes 2 arguments, not 1"},
+		{Case: "Function without call", Expression: "sqrt + 1", WantError: "at offset 0: sqrt is a function, it needs ( after it"},
+		{Case: "Too long", Expression: strings.Repeat("1+", 50) + "1", WantError: "at offset 100: the expression is longer than 100 bytes"},
+		{Case: "Too deeply nested", Expression: "(((((((((1)))))))))", WantError: "at offset 8: the expression is nested deeper than 8"},
+		{Case: "Too deep a power", Expression: "2^2^2^2^2^2^2^2^2^2", WantError: "at offset 17: the expression is nested deeper than 8"},
+		{Case: "Long sum", Expression: strings.Repeat("1+", 24) + "1", Want: 25, WantNormal: strings.Repeat("1 + ", 24) + "1"},
+		{Case: "Long product", Expression: strings.Repeat("2*", 20) + "x/x", Want: 1 << 20, WantNormal: strings.Repeat("2 * ", 20) + "x / x"},
+	}
+	for n, c := range cases {
+		e, err := Parse(c.Expression, limits)
+		var got float64
+		if err == nil {
+			got, err = e.Evaluate(x, c.Policy)
+		}
+		if err != nil || c.WantError != "" {
+			if err == nil || err.Error() != c.WantError {
+				t.Errorf("Case: %d: %s: Got error %v, want %q", n, c.Case, err, c.WantError)
+			}
+			continue
+		}
+		if got != c.Want && !(math.IsNaN(got) && math.IsNaN(c.Want)) {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, c.Case, got, c.Want)
+		}
+		if normal := e.String(); normal != c.WantNormal {
+			t.Errorf("Case: %d: %s: Got normal form %q, want %q", n, c.Case, normal, c.WantNormal)
+		} else if again, err := Parse(normal, limits); err != nil || again.String() != normal {
+			t.Errorf("Case: %d: %s: The normal form does not parse back to itself: %v", n, c.Case, err)
+		}
+	}
+}
+
+func TestParse_FlatSum(t *testing.T) {
+	// An invoice total is a long list, not a deep one.
+	src := "1" + strings.Repeat(" + 1", 1000)
+	e, err := Parse(src, config.Default().Runtime.Expression)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if got, err := e.Evaluate(nil, config.Validation{}); err != nil || got != 1001 {
+		t.Errorf("Got %v, %v, want 1001", got, err)
+	}
+}
+
+func TestVariables(t *testing.T) {
+	e, err := Parse("y * x + pi - y", limits)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if got := strings.Join(e.Variables(), ","); got != "x,y" {
+		t.Errorf("Got variables %q, want x,y", got)
+	}
+	for name, want := range map[string]bool{"x": true, "_x1": true, "pi": false, "sqrt": false, "1x": false, "": false, "x-y": false, "é": false} {
+		if got := IsVariable(name); got != want {
+			t.Errorf("IsVariable(%q): Got %t, want %t", name, got, want)
+		}
+	}
+}
+
+// FuzzParse checks that parsing never panics, and that the normal form of anything that
+// parses reads back as the same expression.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"(3+4)*2/x", "-2^-x^2", "max(1, -(2), pi) - e", "1e5 / .5", "((x)", "a--b*+c"} {
+		f.Add(seed)
+	}
+	limits := config.Expression{MaxLength: 4096, MaxDepth: 64}
+	f.Fuzz(func(t *testing.T, src string) {
+		e, err := Parse(src, limits)
+		if err != nil {
+			return
+		}
+		normal := e.String()
+		again, err := Parse(normal, limits)
+		if err != nil {
+			t.Fatalf("%q normalizes to %q, which does not parse: %s", src, normal, err.Error())
+		}
+		if again.String() != normal {
+			t.Fatalf("%q normalizes to %q, then to %q", src, normal, again.String())
+		}
+		bindings := map[string]float64{}
+		for n, name := range e.Variables() {
+			bindings[name] = float64(n) + 0.5
+		}
+		policy := config.Validation{AllowNaN: true, AllowInfinity: true}
+		got, err1 := e.Evaluate(bindings, policy)
+		want, err2 := again.Evaluate(bindings, policy)
+		if (err1 == nil) != (err2 == nil) || err1 == nil && got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Fatalf("%q gives %v (%v), its normal form %q gives %v (%v)", src, got, err1, normal, want, err2)
+		}
+	})
+}
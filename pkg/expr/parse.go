@@ -0,0 +1,453 @@
+// Package expr parses and evaluates arithmetic expressions such as "(3 + 4) * 2 / x": numbers,
+// variables, + - * / and ^ with the usual precedence, unary minus, parentheses, the constants
+// pi and e, and a library of functions.  Every error says where in the expression it is.
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+)
+
+// Error is a parse or evaluation error at Offset bytes into the expression.
+type Error struct {
+	Offset  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("at offset %d: %s", e.Offset, e.Message)
+}
+
+// Expression is a parsed expression.
+type Expression struct {
+	root      node
+	variables []string
+}
+
+// Parse parses src, refusing one longer or nested deeper than limits allow.
+func Parse(src string, limits config.Expression) (*Expression, error) {
+	if len(src) > limits.MaxLength {
+		return nil, &Error{Offset: limits.MaxLength, Message: fmt.Sprintf("the expression is longer than %d bytes", limits.MaxLength)}
+	}
+	p := &parser{src: src, maxDepth: limits.MaxDepth, variables: map[string]bool{}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.binary(additive)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != eof {
+		return nil, p.unexpected()
+	}
+	e := &Expression{root: root}
+	for name := range p.variables {
+		e.variables = append(e.variables, name)
+	}
+	sort.Strings(e.variables)
+	return e, nil
+}
+
+// String is the expression in normal form: single spaces around binary operators, only the
+// parentheses precedence needs and numbers in their shortest form, so expressions that
+// differ only in how they are written are the same string.
+func (e *Expression) String() string {
+	var b strings.Builder
+	e.root.write(&b)
+	return b.String()
+}
+
+// Variables are the names the expression needs bound, sorted.
+func (e *Expression) Variables() []string {
+	return e.variables
+}
+
+// IsVariable reports whether name can be bound, it must be a name that is neither a
+// constant nor a function.
+func IsVariable(name string) bool {
+	if _, ok := constants[name]; ok {
+		return false
+	}
+	if _, ok := functions[name]; ok {
+		return false
+	}
+	return identifier.MatchString(name)
+}
+
+// identifier matches the names of variables, constants and functions.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Precedences, loosest first.
+const (
+	additive = iota + 1
+	multiplicative
+	prefix
+	power
+	atom
+)
+
+// precedences of the binary operators.
+var precedences = map[byte]int{'+': additive, '-': additive, '*': multiplicative, '/': multiplicative, '^': power}
+
+type node interface {
+	offset() int
+	precedence() int
+	write(b *strings.Builder)
+}
+
+type number struct {
+	at    int
+	value float64
+}
+
+type variable struct {
+	at   int
+	name string
+}
+
+type negation struct {
+	at int
+	x  node
+}
+
+type binary struct {
+	at   int
+	op   byte
+	x, y node
+}
+
+type call struct {
+	at   int
+	name string
+	args []node
+}
+
+func (n *number) offset() int   { return n.at }
+func (n *variable) offset() int { return n.at }
+func (n *negation) offset() int { return n.at }
+func (n *binary) offset() int   { return n.at }
+func (n *call) offset() int     { return n.at }
+
+func (n *number) precedence() int   { return atom }
+func (n *variable) precedence() int { return atom }
+func (n *negation) precedence() int { return prefix }
+func (n *binary) precedence() int   { return precedences[n.op] }
+func (n *call) precedence() int     { return atom }
+
+func (n *number) write(b *strings.Builder) {
+	b.WriteString(strconv.FormatFloat(n.value, 'g', -1, 64))
+}
+
+func (n *variable) write(b *strings.Builder) {
+	b.WriteString(n.name)
+}
+
+func (n *negation) write(b *strings.Builder) {
+	b.WriteByte('-')
+	operand(b, n.x, n.x.precedence() < prefix)
+}
+
+func (n *binary) write(b *strings.Builder) {
+	// + - * / group to the left and ^ to the right, the other side needs parentheses at the
+	// same precedence.  a + (b + c) keeps them too, floating point addition is not associative.
+	if n.op == '^' {
+		operand(b, n.x, n.x.precedence() <= power)
+		b.WriteByte('^')
+		operand(b, n.y, n.y.precedence() < prefix)
+		return
+	}
+	operand(b, n.x, n.x.precedence() < n.precedence())
+	b.WriteString(" " + string(n.op) + " ")
+	operand(b, n.y, n.y.precedence() <= n.precedence())
+}
+
+func (n *call) write(b *strings.Builder) {
+	b.WriteString(n.name + "(")
+	for i, arg := range n.args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		arg.write(b)
+	}
+	b.WriteByte(')')
+}
+
+// operand writes x, in parentheses if parenthesize.
+func operand(b *strings.Builder, x node, parenthesize bool) {
+	if parenthesize {
+		b.WriteByte('(')
+	}
+	x.write(b)
+	if parenthesize {
+		b.WriteByte(')')
+	}
+}
+
+type kind int
+
+const (
+	eof kind = iota
+	numeral
+	name
+	punctuation
+)
+
+type token struct {
+	kind  kind
+	at    int
+	text  string
+	value float64
+}
+
+// parser is a recursive descent parser, p.tok is the token it is looking at.
+type parser struct {
+	src       string
+	pos       int
+	tok       token
+	nesting   int
+	maxDepth  int
+	variables map[string]bool
+}
+
+// next moves to the next token.
+func (p *parser) next() error {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos == len(p.src) {
+		p.tok = token{kind: eof, at: start}
+		return nil
+	}
+	c := p.src[p.pos]
+	switch {
+	case isDigit(c) || c == '.':
+		p.digits()
+		if p.pos < len(p.src) && p.src[p.pos] == '.' {
+			p.pos++
+			p.digits()
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			exponent := p.pos
+			p.pos++
+			if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+				p.pos++
+			}
+			if p.pos == len(p.src) || !isDigit(p.src[p.pos]) {
+				return &Error{Offset: exponent, Message: "the exponent has no digits"}
+			}
+			p.digits()
+		}
+		text := p.src[start:p.pos]
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			if e, ok := err.(*strconv.NumError); ok && e.Err == strconv.ErrRange {
+				return &Error{Offset: start, Message: fmt.Sprintf("%s is out of range", text)}
+			}
+			return &Error{Offset: start, Message: fmt.Sprintf("%q is not a number", text)}
+		}
+		p.tok = token{kind: numeral, at: start, text: text, value: value}
+	case isLetter(c):
+		for p.pos < len(p.src) && (isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: name, at: start, text: p.src[start:p.pos]}
+	case strings.IndexByte("+-*/^(),", c) >= 0:
+		p.pos++
+		p.tok = token{kind: punctuation, at: start, text: string(c)}
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[start:])
+		return &Error{Offset: start, Message: fmt.Sprintf("unexpected %q", r)}
+	}
+	return nil
+}
+
+func (p *parser) digits() {
+	for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// is reports whether the current token is the punctuation s.
+func (p *parser) is(s string) bool {
+	return p.tok.kind == punctuation && p.tok.text == s
+}
+
+// unexpected is the error for a token that cannot go where it is.
+func (p *parser) unexpected() error {
+	if p.tok.kind == eof {
+		return &Error{Offset: p.tok.at, Message: "unexpected end of expression"}
+	}
+	return &Error{Offset: p.tok.at, Message: fmt.Sprintf("unexpected %q", p.tok.text)}
+}
+
+// nest goes one level deeper into the expression, as parentheses, prefix operators, ^ and
+// function calls do, and refuses to go past the depth limit.
+func (p *parser) nest(at int) error {
+	p.nesting++
+	if p.nesting > p.maxDepth {
+		return &Error{Offset: at, Message: fmt.Sprintf("the expression is nested deeper than %d", p.maxDepth)}
+	}
+	return nil
+}
+
+// binary parses operators of precedence prec and tighter, + and - then * and /.  A run of
+// them is a list rather than nesting, as long as the length limit allows.
+func (p *parser) binary(prec int) (node, error) {
+	if prec > multiplicative {
+		return p.prefix()
+	}
+	x, err := p.binary(prec + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == punctuation && precedences[p.tok.text[0]] == prec {
+		op := p.tok
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		y, err := p.binary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{at: op.at, op: op.text[0], x: x, y: y}
+	}
+	return x, nil
+}
+
+// prefix parses unary minus and plus, which bind looser than ^ so -2^2 is -4.
+func (p *parser) prefix() (node, error) {
+	if !p.is("-") && !p.is("+") {
+		return p.power()
+	}
+	op := p.tok
+	if err := p.nest(op.at); err != nil {
+		return nil, err
+	}
+	defer func() { p.nesting-- }()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	x, err := p.prefix()
+	if err != nil || op.text == "+" {
+		return x, err
+	}
+	return &negation{at: op.at, x: x}, nil
+}
+
+// power parses x^y, which groups to the right and takes a signed exponent, as in 2^-1.
+func (p *parser) power() (node, error) {
+	x, err := p.atom()
+	if err != nil || !p.is("^") {
+		return x, err
+	}
+	op := p.tok
+	if err := p.nest(op.at); err != nil {
+		return nil, err
+	}
+	defer func() { p.nesting-- }()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	y, err := p.prefix()
+	if err != nil {
+		return nil, err
+	}
+	return &binary{at: op.at, op: '^', x: x, y: y}, nil
+}
+
+// atom parses a number, a variable or constant, a function call or a parenthesized expression.
+func (p *parser) atom() (node, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == numeral:
+		return &number{at: tok.at, value: tok.value}, p.next()
+	case tok.kind == name:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.is("(") {
+			return p.call(tok)
+		}
+		if _, ok := functions[tok.text]; ok {
+			return nil, &Error{Offset: tok.at, Message: fmt.Sprintf("%s is a function, it needs ( after it", tok.text)}
+		}
+		if _, ok := constants[tok.text]; !ok {
+			p.variables[tok.text] = true
+		}
+		return &variable{at: tok.at, name: tok.text}, nil
+	case p.is("("):
+		if err := p.nest(tok.at); err != nil {
+			return nil, err
+		}
+		defer func() { p.nesting-- }()
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		x, err := p.binary(additive)
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			if p.tok.kind == eof {
+				return nil, &Error{Offset: p.tok.at, Message: fmt.Sprintf("the ( at offset %d is never closed", tok.at)}
+			}
+			return nil, p.unexpected()
+		}
+		return x, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+// call parses the arguments of the function fn, the current token is the ( after its name.
+func (p *parser) call(fn token) (node, error) {
+	f, ok := functions[fn.text]
+	if !ok {
+		return nil, &Error{Offset: fn.at, Message: fmt.Sprintf("%s is not a function", fn.text)}
+	}
+	if err := p.nest(fn.at); err != nil {
+		return nil, err
+	}
+	defer func() { p.nesting-- }()
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	c := &call{at: fn.at, name: fn.text}
+	for !p.is(")") {
+		if len(c.args) > 0 {
+			if !p.is(",") {
+				return nil, p.unexpected()
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.binary(additive)
+		if err != nil {
+			return nil, err
+		}
+		c.args = append(c.args, arg)
+	}
+	if f.arity >= 0 && len(c.args) != f.arity || f.arity < 0 && len(c.args) == 0 {
+		return nil, &Error{Offset: fn.at, Message: fmt.Sprintf("%s takes %s, not %d", fn.text, f.arguments(), len(c.args))}
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
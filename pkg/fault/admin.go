@@ -0,0 +1,93 @@
+package fault
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	adminpb "github.com/mangeshhendre/mathsvc/proto/services_fault_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Admin serves the FaultAdmin service, which changes the rules of an Injector over gRPC.
+// Only non-production builds register it.
+type Admin struct {
+	injector *Injector
+}
+
+// NewAdmin creates an Admin for injector.
+func NewAdmin(injector *Injector) *Admin {
+	return &Admin{injector: injector}
+}
+
+// RegisterServices registers the FaultAdmin service.
+func (a *Admin) RegisterServices(shim *grpc.Server) {
+	adminpb.RegisterFaultAdminServer(shim, a)
+}
+
+// SetFaults replaces every rule.
+func (a *Admin) SetFaults(ctx context.Context, in *adminpb.SetFaultsRequest) (*adminpb.FaultsResponse, error) {
+	rules := make([]Rule, len(in.Rules))
+	for n, r := range in.Rules {
+		rule, err := fromProto(r)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid Request: Rule %d: %v", n, err)
+		}
+		rules[n] = rule
+	}
+	if err := a.injector.Set(rules); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid Request: %v", err)
+	}
+	return a.response(), nil
+}
+
+// ListFaults returns the rules in force.
+func (a *Admin) ListFaults(ctx context.Context, in *adminpb.ListFaultsRequest) (*adminpb.FaultsResponse, error) {
+	return a.response(), nil
+}
+
+// ClearFaults removes every rule.
+func (a *Admin) ClearFaults(ctx context.Context, in *adminpb.ClearFaultsRequest) (*adminpb.FaultsResponse, error) {
+	a.injector.Clear()
+	return a.response(), nil
+}
+
+func (a *Admin) response() *adminpb.FaultsResponse {
+	response := &adminpb.FaultsResponse{}
+	for _, r := range a.injector.Rules() {
+		response.Rules = append(response.Rules, toProto(r))
+	}
+	return response
+}
+
+func fromProto(r *adminpb.FaultRule) (Rule, error) {
+	rule := Rule{
+		Target:      r.Target,
+		Every:       int(r.Every),
+		Probability: r.Probability,
+		Code:        codes.Code(r.Code),
+		Hang:        r.Hang,
+	}
+	if r.Latency != nil {
+		latency, err := ptypes.Duration(r.Latency)
+		if err != nil {
+			return Rule{}, err
+		}
+		rule.Latency = latency
+	}
+	return rule, rule.Validate()
+}
+
+func toProto(r Rule) *adminpb.FaultRule {
+	rule := &adminpb.FaultRule{
+		Target:      r.Target,
+		Every:       int32(r.Every),
+		Probability: r.Probability,
+		Code:        int32(r.Code),
+		Hang:        r.Hang,
+	}
+	if r.Latency > 0 {
+		rule.Latency = ptypes.DurationProto(r.Latency)
+	}
+	return rule
+}
@@ -0,0 +1,202 @@
+// Package fault makes the database and cache misbehave on purpose: slow, failing, hanging
+// or failing some of the time, so tests can see how the service copes.  Rules are held by
+// an Injector, which the Server and Store wrappers consult on every call.
+package fault
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Targets that rules can name.  A database rule may also name one method, as db.AddNumber.
+const (
+	DB       = "db"
+	CacheGet = "cache.get"
+	CacheSet = "cache.set"
+)
+
+var injected = metrics.DefaultRegistry.NewCounterVec(
+	"mathsvc_fault_injections_total",
+	"Faults injected, by target and fault (latency, error, hang).",
+	"target", "fault")
+
+// Rule says which calls to a target fail and how.
+type Rule struct {
+	// Target is DB, CacheGet or CacheSet, or DB and a method as db.AddNumber.
+	Target string
+	// Every fires the rule on every nth call to the target.
+	Every int
+	// Probability fires the rule on that fraction of calls, or of every nth call with
+	// Every too.  With neither the rule fires on every call.
+	Probability float64
+	// Latency delays the call when the rule fires.
+	Latency time.Duration
+	// Code fails the call with that status, OK only delays it.
+	Code codes.Code
+	// Hang holds the call until its context is done.
+	Hang bool
+}
+
+// Validate checks the rule makes sense.
+func (r Rule) Validate() error {
+	switch {
+	case r.Target != DB && r.Target != CacheGet && r.Target != CacheSet && !strings.HasPrefix(r.Target, DB+"."):
+		return fmt.Errorf("fault target %q is not db, db.<method>, cache.get or cache.set", r.Target)
+	case r.Every < 0:
+		return fmt.Errorf("fault every must not be negative, got %d", r.Every)
+	case r.Probability < 0 || r.Probability > 1:
+		return fmt.Errorf("fault probability must be between 0 and 1, got %g", r.Probability)
+	case r.Latency < 0:
+		return fmt.Errorf("fault latency must not be negative, got %s", r.Latency)
+	case r.Code > codes.Unauthenticated:
+		return fmt.Errorf("fault code %d is not a gRPC status code", r.Code)
+	}
+	return nil
+}
+
+// matches reports whether the rule covers target.
+func (r Rule) matches(target string) bool {
+	return r.Target == target || strings.HasPrefix(target, r.Target+".")
+}
+
+// rule is a Rule and how many calls it has seen.
+type rule struct {
+	Rule
+	calls int
+}
+
+// Injector holds the rules in force.  A nil Injector injects nothing.
+type Injector struct {
+	mu    sync.Mutex
+	rules []*rule
+	rand  *rand.Rand
+}
+
+// New creates an Injector with no rules.
+func New() *Injector {
+	return &Injector{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Add adds a rule.
+func (i *Injector) Add(r Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = append(i.rules, &rule{Rule: r})
+	return nil
+}
+
+// Set replaces every rule, or none of them if one is invalid.
+func (i *Injector) Set(rules []Rule) error {
+	fresh := make([]*rule, len(rules))
+	for n, r := range rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		fresh[n] = &rule{Rule: r}
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = fresh
+	return nil
+}
+
+// Rules returns the rules in force.
+func (i *Injector) Rules() []Rule {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	rules := make([]Rule, len(i.rules))
+	for n, r := range i.rules {
+		rules[n] = r.Rule
+	}
+	return rules
+}
+
+// Clear removes every rule.
+func (i *Injector) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = nil
+}
+
+// fired returns the rules that fire for this call to target.
+func (i *Injector) fired(target string) []Rule {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var fired []Rule
+	for _, r := range i.rules {
+		if !r.matches(target) {
+			continue
+		}
+		r.calls++
+		switch {
+		case r.Every > 0 && r.Probability > 0:
+			if r.calls%r.Every == 0 && i.rand.Float64() < r.Probability {
+				fired = append(fired, r.Rule)
+			}
+		case r.Every > 0:
+			if r.calls%r.Every == 0 {
+				fired = append(fired, r.Rule)
+			}
+		case r.Probability > 0:
+			if i.rand.Float64() < r.Probability {
+				fired = append(fired, r.Rule)
+			}
+		default:
+			fired = append(fired, r.Rule)
+		}
+	}
+	return fired
+}
+
+// Inject applies the rules that fire for this call to target, in the order they were
+// added, and returns the error the call should fail with, if any.
+func (i *Injector) Inject(ctx context.Context, target string) error {
+	if i == nil {
+		return nil
+	}
+	for _, r := range i.fired(target) {
+		if r.Latency > 0 {
+			injected.WithLabelValues(target, "latency").Inc()
+			timer := time.NewTimer(r.Latency)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return contextError(ctx, target)
+			}
+		}
+		if r.Hang {
+			injected.WithLabelValues(target, "hang").Inc()
+			<-ctx.Done()
+			return contextError(ctx, target)
+		}
+		if r.Code != codes.OK {
+			injected.WithLabelValues(target, "error").Inc()
+			return status.Errorf(r.Code, "Injected fault in %s", target)
+		}
+	}
+	return nil
+}
+
+// contextError is the status of a call whose context ended while a fault held it.
+func contextError(ctx context.Context, target string) error {
+	if ctx.Err() == context.Canceled {
+		return status.Errorf(codes.Canceled, "Injected fault in %s: %v", target, ctx.Err())
+	}
+	return status.Errorf(codes.DeadlineExceeded, "Injected fault in %s: %v", target, ctx.Err())
+}
@@ -0,0 +1,88 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInjector_Inject(t *testing.T) {
+	var cases = []struct {
+		Case   string
+		Rules  []Rule
+		Target string
+		Want   []codes.Code
+	}{
+		{Case: "No rules", Target: "db.AddNumber", Want: []codes.Code{codes.OK, codes.OK}},
+		{Case: "Every call", Rules: []Rule{{Target: DB, Code: codes.Unavailable}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.Unavailable, codes.Unavailable}},
+		{Case: "Every third", Rules: []Rule{{Target: CacheGet, Every: 3, Code: codes.Internal}}, Target: CacheGet,
+			Want: []codes.Code{codes.OK, codes.OK, codes.Internal, codes.OK, codes.OK, codes.Internal}},
+		{Case: "Other target", Rules: []Rule{{Target: CacheSet, Code: codes.Internal}}, Target: CacheGet,
+			Want: []codes.Code{codes.OK}},
+		{Case: "One method", Rules: []Rule{{Target: "db.DevideNumber", Code: codes.Internal}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.OK}},
+		{Case: "Never", Rules: []Rule{{Target: DB, Probability: 1e-300, Code: codes.Internal}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.OK, codes.OK, codes.OK}},
+		{Case: "Always", Rules: []Rule{{Target: DB, Probability: 1, Code: codes.Internal}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.Internal, codes.Internal}},
+		{Case: "Slow", Rules: []Rule{{Target: DB, Latency: time.Millisecond}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.OK}},
+		{Case: "Too slow", Rules: []Rule{{Target: DB, Latency: time.Minute, Code: codes.Internal}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.DeadlineExceeded}},
+		{Case: "Hang", Rules: []Rule{{Target: DB, Hang: true}}, Target: "db.AddNumber",
+			Want: []codes.Code{codes.DeadlineExceeded}},
+	}
+	for n, c := range cases {
+		injector := New()
+		if err := injector.Set(c.Rules); err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		for call, want := range c.Want {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			err := injector.Inject(ctx, c.Target)
+			cancel()
+			if got := status.Code(err); got != want {
+				t.Errorf("Case: %d: %s: Call %d: Got %s, want %s", n, c.Case, call+1, got, want)
+			}
+		}
+	}
+
+	var none *Injector
+	if err := none.Inject(context.Background(), DB); err != nil {
+		t.Errorf("A nil injector injected %v", err)
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	var cases = []struct {
+		Case    string
+		Rule    Rule
+		WantErr bool
+	}{
+		{Case: "Database", Rule: Rule{Target: DB, Code: codes.Unavailable}},
+		{Case: "Method", Rule: Rule{Target: "db.AddNumber", Every: 2}},
+		{Case: "Unknown target", Rule: Rule{Target: "disk"}, WantErr: true},
+		{Case: "Negative every", Rule: Rule{Target: DB, Every: -1}, WantErr: true},
+		{Case: "Probability above one", Rule: Rule{Target: DB, Probability: 1.5}, WantErr: true},
+		{Case: "Negative latency", Rule: Rule{Target: CacheGet, Latency: -time.Second}, WantErr: true},
+		{Case: "Unknown code", Rule: Rule{Target: CacheSet, Code: 99}, WantErr: true},
+	}
+	for n, c := range cases {
+		if err := c.Rule.Validate(); (err != nil) != c.WantErr {
+			t.Errorf("Case: %d: %s: Got %v, want error %t", n, c.Case, err, c.WantErr)
+		}
+	}
+
+	injector := New()
+	injector.Add(Rule{Target: DB})
+	if err := injector.Set([]Rule{{Target: CacheGet}, {Target: "disk"}}); err == nil {
+		t.Errorf("Set accepted an invalid rule")
+	}
+	if rules := injector.Rules(); len(rules) != 1 || rules[0].Target != DB {
+		t.Errorf("A failed Set changed the rules to %+v", rules)
+	}
+}
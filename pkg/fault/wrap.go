@@ -0,0 +1,92 @@
+package fault
+
+import (
+	"io"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/golang/protobuf/proto"
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+)
+
+// Server wraps a database tier pb.MathServer, each method is the target db.<method>.
+type Server struct {
+	next     pb.MathServer
+	injector *Injector
+}
+
+// NewServer wraps next with the faults in injector.
+func NewServer(next pb.MathServer, injector *Injector) *Server {
+	return &Server{next: next, injector: injector}
+}
+
+func (s *Server) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	if err := s.injector.Inject(ctx, DB+".AddNumber"); err != nil {
+		return nil, err
+	}
+	return s.next.AddNumber(ctx, in)
+}
+
+func (s *Server) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	if err := s.injector.Inject(ctx, DB+".MultiplyNumber"); err != nil {
+		return nil, err
+	}
+	return s.next.MultiplyNumber(ctx, in)
+}
+
+func (s *Server) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	if err := s.injector.Inject(ctx, DB+".DevideNumber"); err != nil {
+		return nil, err
+	}
+	return s.next.DevideNumber(ctx, in)
+}
+
+// Close closes the wrapped server, if it can be.
+func (s *Server) Close() error {
+	if closer, ok := s.next.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Store wraps a cache store, Get and GetMulti are the target cache.get and Set cache.set.
+// The store has no context, so a hang, or latency beyond it, lasts as long as memcache
+// would wait before timing out.
+type Store struct {
+	next     mathcache.Store
+	injector *Injector
+}
+
+// NewStore wraps next with the faults in injector.
+func NewStore(next mathcache.Store, injector *Injector) *Store {
+	return &Store{next: next, injector: injector}
+}
+
+func (s *Store) Get(primaryContext, secondaryContext, key string, result proto.Message) error {
+	if err := s.inject(CacheGet); err != nil {
+		return err
+	}
+	return s.next.Get(primaryContext, secondaryContext, key, result)
+}
+
+func (s *Store) GetMulti(keys []mathcache.Key, results []proto.Message) ([]bool, error) {
+	if err := s.inject(CacheGet); err != nil {
+		return nil, err
+	}
+	return s.next.GetMulti(keys, results)
+}
+
+func (s *Store) Set(primaryContext, secondaryContext, key string, value proto.Message, expiration time.Duration) error {
+	if err := s.inject(CacheSet); err != nil {
+		return err
+	}
+	return s.next.Set(primaryContext, secondaryContext, key, value, expiration)
+}
+
+func (s *Store) inject(target string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), memcache.DefaultTimeout)
+	defer cancel()
+	return s.injector.Inject(ctx, target)
+}
@@ -0,0 +1,259 @@
+package grpcserver
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/jwtauthfunc"
+	"github.com/mangeshhendre/jwtclient"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
+	logxi "github.com/mgutz/logxi/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"golang.org/x/net/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server owns the grpc and debug http servers and their shutdown.
+type Server struct {
+	logger     logxi.Logger
+	config     config.GRPC
+	grpcServer *grpc.Server
+	listen     net.Listener
+	httpServer *http.Server
+	health     *healthServer
+	myLife     time.Duration
+	signals    chan os.Signal
+}
+
+// New creates a new Server that requires TLS and a JWT signed by one of the certificates in
+// JWTCertPath.  Any interceptors run after authentication, in the order given.
+func New(name string, c config.GRPC, interceptors ...grpc.UnaryServerInterceptor) (*Server, error) {
+	logger := logging.New(name)
+
+	listen, err := listen(logger, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to create GRPC Server")
+	}
+	return NewWithListener(name, c, listen, interceptors...)
+}
+
+// NewWithListener is New serving on listen rather than on BindAddress and BindPort, such
+// as the in-memory listener of the end to end tests.
+func NewWithListener(name string, c config.GRPC, listen net.Listener, interceptors ...grpc.UnaryServerInterceptor) (*Server, error) {
+	logger := logging.New(name)
+
+	grpcServer, err := makeGRPCServer(logger, c, interceptors)
+	if err != nil {
+		listen.Close()
+		return nil, errors.Wrap(err, "Unable to create GRPC Server")
+	}
+
+	// Setup so that http debug will work.  Control security here by what hosts can get to the port.
+	trace.AuthRequest = func(req *http.Request) (any, sensitive bool) { return true, true }
+
+	return newServer(logger, c, grpcServer, listen), nil
+}
+
+// listen opens the TCP listener the configuration asks for.
+func listen(logger logxi.Logger, c config.GRPC) (net.Listener, error) {
+	listen, err := net.Listen("tcp", net.JoinHostPort(c.BindAddress, c.BindPort))
+	if err != nil {
+		return nil, logger.Error("Unable to create listener", "Address", c.BindAddress, "Port", c.BindPort, "Error", err)
+	}
+	return listen, nil
+}
+
+// makeGRPCServer follows grpcutils.MakeGRPCServer, which has no room for interceptors of our own.
+func makeGRPCServer(logger logxi.Logger, c config.GRPC, interceptors []grpc.UnaryServerInterceptor) (*grpc.Server, error) {
+	// We will ONLY accept rsa 256bit signatures, from the issuers in the cert dir.
+	parser := jwt.Parser{ValidMethods: []string{"RS256"}}
+	keyFunc, err := jwtclient.KeyFuncFromCertDir(c.JWTCertPath)
+	if err != nil {
+		return nil, logger.Error("Unable to create keyfunc", "Error", err)
+	}
+	authorizer, err := jwtauthfunc.New(&parser, keyFunc)
+	if err != nil {
+		return nil, logger.Error("Unable to create authorizer", "Error", err)
+	}
+
+	tlsCreds, err := credentials.NewServerTLSFromFile(c.SSLCertPath, c.SSLKeyPath)
+	if err != nil {
+		return nil, logger.Error("Unable to create tls server credentials", "certPath", c.SSLCertPath, "keyPath", c.SSLKeyPath, "Error", err)
+	}
+
+	unary := append([]grpc.UnaryServerInterceptor{grpc_auth.UnaryServerInterceptor(authorizer.Authorize)}, interceptors...)
+	grpcServer := grpc.NewServer(
+		grpc.StreamInterceptor(grpc_auth.StreamServerInterceptor(authorizer.Authorize)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unary...)),
+		grpc.Creds(tlsCreds),
+	)
+	reflection.Register(grpcServer)
+
+	return grpcServer, nil
+}
+
+// NewDev creates a Server for local development: plaintext and without JWT authentication.
+// The configuration is expected to have been validated as dev, which keeps it on loopback.
+func NewDev(name string, c config.GRPC, interceptors ...grpc.UnaryServerInterceptor) (*Server, error) {
+	listen, err := listen(logging.New(name), c)
+	if err != nil {
+		return nil, err
+	}
+	return NewDevWithListener(name, c, listen, interceptors...)
+}
+
+// NewDevWithListener is NewDev serving on listen rather than on BindAddress and BindPort.
+func NewDevWithListener(name string, c config.GRPC, listen net.Listener, interceptors ...grpc.UnaryServerInterceptor) (*Server, error) {
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(interceptors...)))
+	reflection.Register(grpcServer)
+
+	return newServer(logging.New(name), c, grpcServer, listen), nil
+}
+
+// Addr returns the address the grpc server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listen.Addr()
+}
+
+func newServer(logger logxi.Logger, c config.GRPC, grpcServer *grpc.Server, listen net.Listener) *Server {
+	// Make us a new random seed.
+	rand.Seed(time.Now().UnixNano())
+
+	// Establish life range, zero means live until told otherwise.
+	var myLife time.Duration
+	if c.MinLife > 0 || c.LifeRange > 0 {
+		myLife = time.Duration(c.MinLife) * time.Second
+		if c.LifeRange > 0 {
+			myLife += time.Duration(rand.Int63n(c.LifeRange)) * time.Second
+		}
+	}
+
+	healthServer := newHealthServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	return &Server{
+		logger:     logger,
+		config:     c,
+		grpcServer: grpcServer,
+		listen:     listen,
+		httpServer: &http.Server{Addr: net.JoinHostPort(c.DebugAddress, c.DebugPort)},
+		health:     healthServer,
+		myLife:     myLife,
+		signals:    make(chan os.Signal, 2),
+	}
+}
+
+// RegisterHandlers takes care of registering all the relevant handlers.
+func (s *Server) RegisterHandlers(handlers ...grpcutils.GRPCService) {
+	for _, v := range handlers {
+		v.RegisterServices(s.grpcServer)
+	}
+}
+
+// Run wraps starting, waiting for a signal or the end of our life, and draining.
+func (s *Server) Run() {
+	signal.Notify(s.signals, syscall.SIGTERM, syscall.SIGINT)
+	defer signal.Stop(s.signals)
+
+	s.Startup()
+	s.Wait()
+	s.Shutdown()
+}
+
+// Startup starts the grpc and debug http servers in goroutines and reports SERVING.
+func (s *Server) Startup() {
+	s.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	go func() {
+		s.logger.Info("GRPC Server starting up", "Address", s.listen.Addr().String())
+		s.logger.Info("GRPC Server stopped", "Result", s.grpcServer.Serve(s.listen))
+	}()
+
+	go func() {
+		s.logger.Info("Starting up debug http server", "Address", s.httpServer.Addr)
+		s.logger.Info("Server Stopped", "Result", s.httpServer.ListenAndServe())
+	}()
+}
+
+// Wait blocks until SIGTERM or SIGINT arrives, or our randomized life runs out.
+func (s *Server) Wait() {
+	var expired <-chan time.Time
+	if s.myLife > 0 {
+		s.logger.Info(fmt.Sprintf("Living for %d Seconds", s.myLife/time.Second))
+		timer := time.NewTimer(s.myLife)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case sig := <-s.signals:
+		s.logger.Info("Received signal", "Signal", sig.String())
+	case <-expired:
+		s.logger.Info(fmt.Sprintf("Done living for %d Seconds", s.myLife/time.Second))
+	}
+}
+
+// Shutdown drains the server: health goes NOT_SERVING for the drain period, then in flight
+// RPCs get up to the shutdown timeout to finish before they are cut off.  A second signal
+// skips straight to cutting them off.
+func (s *Server) Shutdown() {
+	s.logger.Info("Draining", "DrainPeriod", s.config.DrainPeriod)
+	s.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	select {
+	case <-time.After(s.config.DrainPeriod):
+	case sig := <-s.signals:
+		s.logger.Warn("Received second signal, skipping drain", "Signal", sig.String())
+		s.grpcServer.Stop()
+	}
+
+	s.logger.Info("Shutting down GRPC", "ShutdownTimeout", s.config.ShutdownTimeout)
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		s.logger.Info("Shut down GRPC")
+	case <-time.After(s.config.ShutdownTimeout):
+		s.logger.Warn("In flight RPCs did not finish in time, stopping GRPC")
+		s.grpcServer.Stop()
+	case sig := <-s.signals:
+		s.logger.Warn("Received second signal, stopping GRPC", "Signal", sig.String())
+		s.grpcServer.Stop()
+	}
+
+	s.logger.Info("Shutting down http server")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.httpServer.Shutdown(ctx)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("Error shutting down httpserver: %v", err))
+	}
+	s.logger.Info("Shutdown Complete")
+}
+
+// setServingStatus updates the overall health and that of every registered service.
+func (s *Server) setServingStatus(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", servingStatus)
+	for service := range s.grpcServer.GetServiceInfo() {
+		s.health.SetServingStatus(service, servingStatus)
+	}
+}
@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServer_Shutdown(t *testing.T) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %s", err.Error())
+	}
+
+	c := config.GRPC{
+		DebugAddress:    "127.0.0.1",
+		DebugPort:       "0",
+		DrainPeriod:     300 * time.Millisecond,
+		ShutdownTimeout: time.Second,
+	}
+	s := newServer(logxi.New("test"), c, grpc.NewServer(), listen)
+	s.Startup()
+
+	conn, err := grpc.Dial(listen.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	check := func() healthpb.HealthCheckResponse_ServingStatus {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("Health check failed: %s", err.Error())
+		}
+		return resp.Status
+	}
+
+	if got := check(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected SERVING before shutdown, got %s", got)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.Shutdown()
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := check(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING while draining, got %s", got)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Shutdown did not complete")
+	}
+}
@@ -0,0 +1,44 @@
+package grpcserver
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthServer implements the grpc health check service.  The stock grpc health server
+// always reports SERVING for the overall ("") service, which is exactly the one the
+// orchestrator asks about, so it cannot be used to drain.
+type healthServer struct {
+	mu        sync.RWMutex
+	statusMap map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func newHealthServer() *healthServer {
+	return &healthServer{
+		statusMap: map[string]healthpb.HealthCheckResponse_ServingStatus{
+			"": healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+}
+
+// Check implements healthpb.HealthServer.
+func (h *healthServer) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	servingStatus, ok := h.statusMap[in.Service]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// SetServingStatus records the status of a service, "" being the server as a whole.
+func (h *healthServer) SetServingStatus(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	h.mu.Lock()
+	h.statusMap[service] = servingStatus
+	h.mu.Unlock()
+}
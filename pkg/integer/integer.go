@@ -0,0 +1,84 @@
+// Package integer is int64 arithmetic that says when it overflows.  Each operation returns
+// the result wrapped to 64 bits, as Go's own operators do, and the direction of any
+// overflow: +1 when the true result is above math.MaxInt64, -1 when it is below
+// math.MinInt64, 0 when it fits.  That is all a caller needs to refuse, saturate or wrap.
+package integer
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Add is a + b.
+func Add(a, b int64) (int64, int) {
+	sum := a + b
+	switch {
+	case a > 0 && b > 0 && sum < 0:
+		return sum, 1
+	case a < 0 && b < 0 && sum >= 0:
+		return sum, -1
+	}
+	return sum, 0
+}
+
+// Sub is a - b.
+func Sub(a, b int64) (int64, int) {
+	difference := a - b
+	switch {
+	case a >= 0 && b < 0 && difference < 0:
+		return difference, 1
+	case a < 0 && b > 0 && difference >= 0:
+		return difference, -1
+	}
+	return difference, 0
+}
+
+// Mul is a × b.
+func Mul(a, b int64) (int64, int) {
+	product := a * b
+	negative := (a < 0) != (b < 0)
+	hi, lo := bits.Mul64(abs(a), abs(b))
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	if hi != 0 || lo > limit {
+		if negative {
+			return product, -1
+		}
+		return product, 1
+	}
+	return product, 0
+}
+
+// Quo is a / b and its remainder, the quotient truncated toward zero or, with floor,
+// rounded toward negative infinity, when the remainder has the sign of b.  b must not be
+// zero.  The only quotient that overflows is math.MinInt64 / -1.
+func Quo(a, b int64, floor bool) (q, r int64, overflow int) {
+	if a == math.MinInt64 && b == -1 {
+		return math.MinInt64, 0, 1
+	}
+	q, r = a/b, a%b
+	if floor && r != 0 && (r < 0) != (b < 0) {
+		q--
+		r += b
+	}
+	return q, r, 0
+}
+
+// Saturate is the int64 nearest the true result of an operation that overflowed toward
+// overflow.
+func Saturate(overflow int) int64 {
+	if overflow > 0 {
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// abs is |x| as a uint64, which holds it even for math.MinInt64.
+func abs(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
@@ -0,0 +1,95 @@
+package integer
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestOperations(t *testing.T) {
+	const max, min = math.MaxInt64, math.MinInt64
+	var cases = []struct {
+		Case         string
+		Op           func(a, b int64) (int64, int)
+		A, B         int64
+		Want         int64
+		WantOverflow int
+	}{
+		{Case: "Add", Op: Add, A: 3, B: 4, Want: 7},
+		{Case: "Add to the maximum", Op: Add, A: max - 1, B: 1, Want: max},
+		{Case: "Add past the maximum", Op: Add, A: max, B: 1, Want: min, WantOverflow: 1},
+		{Case: "Add past the minimum", Op: Add, A: min, B: -1, Want: max, WantOverflow: -1},
+		{Case: "Add the extremes", Op: Add, A: min, B: max, Want: -1},
+		{Case: "Subtract", Op: Sub, A: 3, B: 4, Want: -1},
+		{Case: "Subtract the minimum", Op: Sub, A: 0, B: min, Want: min, WantOverflow: 1},
+		{Case: "Subtract from the minimum", Op: Sub, A: min, B: 1, Want: max, WantOverflow: -1},
+		{Case: "Subtract to the minimum", Op: Sub, A: -1, B: max, Want: min},
+		{Case: "Multiply", Op: Mul, A: -3, B: 4, Want: -12},
+		{Case: "Multiply to the minimum", Op: Mul, A: min / 2, B: 2, Want: min},
+		{Case: "Multiply past the maximum", Op: Mul, A: 1 << 32, B: 1 << 31, Want: min, WantOverflow: 1},
+		{Case: "Multiply past the minimum", Op: Mul, A: max, B: -2, Want: 2, WantOverflow: -1},
+		{Case: "Negate the minimum", Op: Mul, A: min, B: -1, Want: min, WantOverflow: 1},
+		{Case: "Multiply the minimum by one", Op: Mul, A: min, B: 1, Want: min},
+		{Case: "Multiply by zero", Op: Mul, A: min, B: 0, Want: 0},
+	}
+	for n, c := range cases {
+		got, overflow := c.Op(c.A, c.B)
+		if got != c.Want || overflow != c.WantOverflow {
+			t.Errorf("Case: %d: %s: Got %d overflow %d, want %d overflow %d", n, c.Case, got, overflow, c.Want, c.WantOverflow)
+		}
+	}
+}
+
+func TestQuo(t *testing.T) {
+	var cases = []struct {
+		Case         string
+		A, B         int64
+		Floor        bool
+		WantQ, WantR int64
+		WantOverflow int
+	}{
+		{Case: "Exact", A: 12, B: 4, WantQ: 3},
+		{Case: "Truncated", A: -7, B: 2, WantQ: -3, WantR: -1},
+		{Case: "Floored", A: -7, B: 2, Floor: true, WantQ: -4, WantR: 1},
+		{Case: "Floored by a negative", A: 7, B: -2, Floor: true, WantQ: -4, WantR: -1},
+		{Case: "Floored both negative", A: -7, B: -2, Floor: true, WantQ: 3, WantR: -1},
+		{Case: "Floored exact", A: -8, B: 2, Floor: true, WantQ: -4},
+		{Case: "Overflow", A: math.MinInt64, B: -1, WantQ: math.MinInt64, WantOverflow: 1},
+	}
+	for n, c := range cases {
+		q, r, overflow := Quo(c.A, c.B, c.Floor)
+		if q != c.WantQ || r != c.WantR || overflow != c.WantOverflow {
+			t.Errorf("Case: %d: %s: Got %d r %d overflow %d, want %d r %d overflow %d", n, c.Case, q, r, overflow, c.WantQ, c.WantR, c.WantOverflow)
+		}
+	}
+}
+
+// FuzzOperations checks overflow detection against math/big.
+func FuzzOperations(f *testing.F) {
+	f.Add(int64(math.MaxInt64), int64(1))
+	f.Add(int64(math.MinInt64), int64(-1))
+	f.Add(int64(1<<32), int64(1<<31))
+	min, max := big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)
+	f.Fuzz(func(t *testing.T, a, b int64) {
+		x, y := big.NewInt(a), big.NewInt(b)
+		for name, op := range map[string]struct {
+			fn   func(a, b int64) (int64, int)
+			want *big.Int
+		}{
+			"Add": {Add, new(big.Int).Add(x, y)},
+			"Sub": {Sub, new(big.Int).Sub(x, y)},
+			"Mul": {Mul, new(big.Int).Mul(x, y)},
+		} {
+			got, overflow := op.fn(a, b)
+			want := 0
+			if op.want.Cmp(max) > 0 {
+				want = 1
+			} else if op.want.Cmp(min) < 0 {
+				want = -1
+			}
+			if overflow != want || want == 0 && got != op.want.Int64() {
+				t.Fatalf("%s(%d, %d): Got %d overflow %d, want %s", name, a, b, got, overflow, op.want)
+			}
+		}
+	})
+}
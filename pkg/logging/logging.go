@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"fmt"
+	"sync"
+
+	logxi "github.com/mgutz/logxi/v1"
+)
+
+var (
+	mu      sync.Mutex
+	loggers []logxi.Logger
+	level   *int
+)
+
+// New creates a logxi logger whose level can later be changed with SetLevel.
+func New(name string) logxi.Logger {
+	logger := logxi.New(name)
+
+	mu.Lock()
+	defer mu.Unlock()
+	loggers = append(loggers, logger)
+	if level != nil {
+		logger.SetLevel(*level)
+	}
+	return logger
+}
+
+// ParseLevel converts a level name (debug, info, warn, error, ...) into a logxi level.
+func ParseLevel(name string) (int, error) {
+	l, ok := logxi.LevelAtoi[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return l, nil
+}
+
+// SetLevel changes the level of every logger made through New, and of those made after.
+// An empty name leaves the LOGXI environment in charge.  Loggers that LOGXI switched off
+// entirely stay off.
+func SetLevel(name string) error {
+	if name == "" {
+		return nil
+	}
+	l, err := ParseLevel(name)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	level = &l
+	for _, logger := range loggers {
+		logger.SetLevel(l)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package mathcache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Item is one request of a batch, Method is the Math method that answers it.
+type Item struct {
+	Method string
+	In     *pb.MathRequest
+}
+
+// Result answers the Item at the same index.  Cache is hit, miss or bypass, what CacheHeader
+// would say for a single call.
+type Result struct {
+	Response *pb.MathResponse
+	Err      error
+	Cache    string
+}
+
+// Batch answers every item.  The cache is asked for all of them with one GetMulti, and the
+// backing server for the misses, at most concurrency at a time, whose answers are then
+// cached.  A cache that fails sends every item to the backing server, as a miss would.
+func (s *MathCache) Batch(ctx context.Context, items []Item, concurrency int) []Result {
+	settings := s.live.Load()
+	results := make([]Result, len(items))
+	cache := "bypass"
+	if atomic.LoadInt32(&s.closed) == 0 && settings.Features.Cache {
+		cache = "miss"
+		s.getMulti(ctx, items, results)
+	}
+
+	var misses []int
+	for n := range results {
+		if results[n].Cache != "hit" {
+			results[n].Cache = cache
+			misses = append(misses, n)
+		}
+	}
+	each(misses, concurrency, func(n int) {
+		response, err := s.call(ctx, items[n])
+		results[n].Response, results[n].Err = response, err
+		if err == nil && cache == "miss" {
+			s.set(ctx, cacheKey(items[n].Method, items[n].In), response, settings.CacheTTL)
+		}
+	})
+	return results
+}
+
+// getMulti fills in the results the cache has.
+func (s *MathCache) getMulti(ctx context.Context, items []Item, results []Result) {
+	keys := make([]Key, len(items))
+	responses := make([]proto.Message, len(items))
+	for n, item := range items {
+		keys[n] = cacheKey(item.Method, item.In)
+		responses[n] = &pb.MathResponse{}
+	}
+
+	span := startCacheSpan(ctx, "get_multi", "Math")
+	span.SetAttribute("cache.keys", len(keys))
+	found, err := s.cache.GetMulti(keys, responses)
+	if err != nil {
+		finishCacheSpan(span, "get_multi", "error", err)
+		s.logger.Debug("Unable to get from memcache", "Error", err)
+		return
+	}
+	for n, hit := range found {
+		if hit {
+			results[n] = Result{Response: responses[n].(*pb.MathResponse), Cache: "hit"}
+			cacheOperations.WithLabelValues("get_multi", "hit").Inc()
+		} else {
+			cacheOperations.WithLabelValues("get_multi", "miss").Inc()
+		}
+	}
+	finishCacheSpan(span, "get_multi", "ok", nil)
+}
+
+// call asks the backing server for the answer to item.
+func (s *MathCache) call(ctx context.Context, item Item) (*pb.MathResponse, error) {
+	switch item.Method {
+	case "AddNumber":
+		return s.server.AddNumber(ctx, item.In)
+	case "MultiplyNumber":
+		return s.server.MultiplyNumber(ctx, item.In)
+	case "DevideNumber":
+		return s.server.DevideNumber(ctx, item.In)
+	}
+	return nil, status.Errorf(codes.Unimplemented, "Unknown method %s", item.Method)
+}
+
+// each calls fn with every index, at most concurrency at a time, and waits for them all.
+func each(indexes []int, concurrency int, fn func(n int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, n := range indexes {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(n int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			fn(n)
+		}(n)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,44 @@
+package mathcache
+
+import (
+	"context"
+
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	"github.com/mangeshhendre/mathsvc/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CacheHeader is the response header that says whether an answer came from the cache:
+// hit, miss, or bypass when the cache is switched off.
+const CacheHeader = "x-mathsvc-cache"
+
+var cacheOperations = metrics.DefaultRegistry.NewCounterVec(
+	"mathsvc_cache_operations_total",
+	"Number of memcache operations, by operation (get, get_multi, set) and result (hit, miss, ok, error).  A get_multi counts a hit or miss for every key too.",
+	"operation", "result")
+
+// startCacheSpan opens a client span for a single memcache operation.
+func startCacheSpan(ctx context.Context, operation, key string) *tracing.Span {
+	_, span := tracing.Start(ctx, "cache."+operation, tracing.KindClient)
+	span.SetAttribute("cache.system", "memcached")
+	span.SetAttribute("cache.operation", operation)
+	span.SetAttribute("cache.key", key)
+	return span
+}
+
+// finishCacheSpan records the result of a memcache operation on both the span and the counters.
+func finishCacheSpan(span *tracing.Span, operation, result string, err error) {
+	cacheOperations.WithLabelValues(operation, result).Inc()
+	span.SetAttribute("cache.result", result)
+	if result == "error" {
+		span.Fail(err.Error())
+	}
+	span.Finish()
+}
+
+// setCacheHeader tells the caller how the cache was used.  Outside a gRPC call, as in the
+// unit tests, there is nowhere to send it and it is dropped.
+func setCacheHeader(ctx context.Context, result string) {
+	grpc.SetHeader(ctx, metadata.Pairs(CacheHeader, result))
+}
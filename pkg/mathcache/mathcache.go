@@ -3,84 +3,163 @@ package mathcache
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/golang/protobuf/proto"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	"github.com/mangeshhendre/protocache"
 	"github.com/mgutz/logxi/v1"
 )
 
 type MathCache struct {
-	cache  *protocache.PC
+	cache  Store
 	server pb.MathServer
+	live   *config.Live
 	logger log.Logger
+	closed int32
 }
 
-func New(imp pb.MathServer) (pb.MathServer, error) {
+// Store is the part of protocache the cache needs.
+type Store interface {
+	Get(primaryContext, secondaryContext, key string, result proto.Message) error
+	Set(primaryContext, secondaryContext, key string, value proto.Message, expiration time.Duration) error
+	// GetMulti looks every key up at once, unmarshalling each hit into the result at the same
+	// index.  found says which keys were hits, a miss is not an error.
+	GetMulti(keys []Key, results []proto.Message) (found []bool, err error)
+}
+
+// New wraps imp with a memcache lookaside, the TTL and whether to use the cache at all come from live.
+func New(imp pb.MathServer, c config.Memcache, live *config.Live) (pb.MathServer, error) {
+	return NewWithStore(imp, NewStore(c), live)
+}
+
+// NewStore creates the memcache store New uses.
+func NewStore(c config.Memcache) Store {
+	return protocacheStore{protocache.New(c.Scope, c.ServerList()...)}
+}
+
+// NewWithStore wraps imp with a lookaside on an arbitrary store.
+func NewWithStore(imp pb.MathServer, store Store, live *config.Live) (pb.MathServer, error) {
 	client := &MathCache{
 		server: imp,
-		cache:  protocache.New("MathSample", listMemcacheServers()...),
-		logger: log.New("MathCache"),
+		cache:  store,
+		live:   live,
+		logger: logging.New("MathCache"),
 	}
 	return client, nil
 }
 
-func listMemcacheServers() []string {
-	serverString := envOrDefault("MEMCACHE_SERVERS", "mem01:11211;mem01:11212;mem01:11213;mem02:11211;mem02:11212;mem03:11213;mem03:11211;mem03:11212;mem02:11213")
-	return strings.Split(serverString, ";")
-}
-
-func envOrDefault(input, override string) string {
-	foo := os.Getenv(input)
-	if len(foo) != 0 {
-		return foo
-	}
-	return override
+// Close stops the cache from being consulted, requests go straight to the backing server.
+// gomemcache has no way to drop its idle connections, those go with the process.
+func (s *MathCache) Close() error {
+	atomic.StoreInt32(&s.closed, 1)
+	return nil
 }
 
 func (s *MathCache) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
-	return s.getAdditionFromCache(ctx, in)
+	return s.getFromCache(ctx, "AddNumber", in, s.server.AddNumber)
 }
 
 func (s *MathCache) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
-	return s.getAdditionFromCache(ctx, in) //this needs to implement to get Multiplication
+	return s.getFromCache(ctx, "MultiplyNumber", in, s.server.MultiplyNumber)
 }
 
 func (s *MathCache) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
-	return s.getAdditionFromCache(ctx, in) //this needs to implement to get Division
+	return s.getFromCache(ctx, "DevideNumber", in, s.server.DevideNumber)
+}
+
+// getFromCache answers a request from the cache, or from the backing server's call on a miss.
+func (s *MathCache) getFromCache(ctx context.Context, method string, in *pb.MathRequest, call func(context.Context, *pb.MathRequest) (*pb.MathResponse, error)) (*pb.MathResponse, error) {
+	return s.mathLookaside(ctx, cacheKey(method, in), func(ctx context.Context) (*pb.MathResponse, error) {
+		return call(ctx, in)
+	})
+}
+
+// Evaluate answers an expression from the cache, or from evaluate on a miss.  normalized is
+// the expression in normal form and bindings the values of its variables, in a canonical
+// order, so requests that differ only in how they are written share an entry.  The policy
+// is part of the key, it decides which steps of an evaluation are refused.
+func (s *MathCache) Evaluate(ctx context.Context, normalized, bindings string, policy config.Validation, evaluate func(context.Context) (*pb.MathResponse, error)) (*pb.MathResponse, error) {
+	return s.mathLookaside(ctx, Key{
+		PrimaryContext:   "Expression:" + normalized,
+		SecondaryContext: "Bindings:" + bindings,
+		Key:              fmt.Sprintf("MathEvaluate:%t:%t", policy.AllowNaN, policy.AllowInfinity),
+	}, evaluate)
 }
 
-func (s *MathCache) getAdditionFromCache(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+// mathLookaside is Lookaside for a MathResponse.
+func (s *MathCache) mathLookaside(ctx context.Context, key Key, call func(context.Context) (*pb.MathResponse, error)) (*pb.MathResponse, error) {
 	response := &pb.MathResponse{}
-	primaryContext := fmt.Sprintf("Number1:%d", in.Number1)
-	secondaryContext := fmt.Sprintf("Number2:%d", in.Number2)
-	cacheKey := "MathAddNumber"
+	err := s.Lookaside(ctx, key, response, func(ctx context.Context) error {
+		answer, err := call(ctx)
+		if err != nil {
+			return err
+		}
+		*response = *answer
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Lookaside fills in response from the cache under key, or by call on a miss, caching what
+// call filled in.  An error from call is returned, and nothing is cached.
+func (s *MathCache) Lookaside(ctx context.Context, key Key, response proto.Message, call func(context.Context) error) error {
+	settings := s.live.Load()
+	if atomic.LoadInt32(&s.closed) == 1 || !settings.Features.Cache {
+		setCacheHeader(ctx, "bypass")
+		return call(ctx)
+	}
 
 	// Check the cache first.
-	err := s.cache.Get(primaryContext, secondaryContext, cacheKey, response)
+	getSpan := startCacheSpan(ctx, "get", key.Key)
+	err := s.cache.Get(key.PrimaryContext, key.SecondaryContext, key.Key, response)
 	if err == nil {
 		// Successful result from cache.
-		return response, nil
+		finishCacheSpan(getSpan, "get", "hit", nil)
+		setCacheHeader(ctx, "hit")
+		return nil
+	}
+	if err == memcache.ErrCacheMiss {
+		finishCacheSpan(getSpan, "get", "miss", err)
+	} else {
+		finishCacheSpan(getSpan, "get", "error", err)
 	}
 	s.logger.Debug("Unable to get from memcache", "Error", err)
+	setCacheHeader(ctx, "miss")
 
-	if in.Number1 != 0 && in.Number2 != 0 {
-		response, err = s.server.AddNumber(ctx, in)
-	} else {
-		err = s.logger.Error("Zero numbers cannot be added")
+	if err := call(ctx); err != nil {
+		return err
 	}
 
-	if err != nil {
-		return nil, err
+	s.set(ctx, key, response, settings.CacheTTL)
+	return nil
+}
+
+// cacheKey is where the answer to in is cached.
+func cacheKey(method string, in *pb.MathRequest) Key {
+	return Key{
+		PrimaryContext:   fmt.Sprintf("Number1:%v", in.Number1),
+		SecondaryContext: fmt.Sprintf("Number2:%v", in.Number2),
+		Key:              "Math" + method,
 	}
+}
 
-	memcacheErr := s.cache.Set(primaryContext, secondaryContext, cacheKey, response, 10*time.Second)
+// set caches response under key, a failure only costs a later miss.
+func (s *MathCache) set(ctx context.Context, key Key, response proto.Message, ttl time.Duration) {
+	setSpan := startCacheSpan(ctx, "set", key.Key)
+	memcacheErr := s.cache.Set(key.PrimaryContext, key.SecondaryContext, key.Key, response, ttl)
 	if memcacheErr != nil {
 		// We give no sh*ts.
-		s.logger.Debug("getAdditionFromCache: Unable to set record in memcache", "Error", memcacheErr)
+		finishCacheSpan(setSpan, "set", "error", memcacheErr)
+		s.logger.Debug("getFromCache: Unable to set record in memcache", "Error", memcacheErr)
+	} else {
+		finishCacheSpan(setSpan, "set", "ok", nil)
 	}
-
-	return response, nil
 }
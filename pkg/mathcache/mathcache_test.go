@@ -0,0 +1,63 @@
+package mathcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+)
+
+// arithmetic answers each method with its own operation and counts the calls that reach it.
+type arithmetic struct {
+	calls int
+}
+
+func (a *arithmetic) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	a.calls++
+	return &pb.MathResponse{Result: in.Number1 + in.Number2}, nil
+}
+
+func (a *arithmetic) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	a.calls++
+	return &pb.MathResponse{Result: in.Number1 * in.Number2}, nil
+}
+
+func (a *arithmetic) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	a.calls++
+	return &pb.MathResponse{Result: in.Number1 / in.Number2}, nil
+}
+
+func TestMathCache(t *testing.T) {
+	runtime := config.Default().Runtime
+	runtime.Features.Cache = true
+	backing := &arithmetic{}
+	cache, _ := NewWithStore(backing, NewMemoryStore(), config.NewLive(runtime))
+
+	var cases = []struct {
+		Case      string
+		Call      func(context.Context, *pb.MathRequest) (*pb.MathResponse, error)
+		In        *pb.MathRequest
+		Want      float64
+		WantCalls int
+	}{
+		{Case: "Add", Call: cache.AddNumber, In: &pb.MathRequest{Number1: 6, Number2: 3}, Want: 9, WantCalls: 1},
+		{Case: "Add again", Call: cache.AddNumber, In: &pb.MathRequest{Number1: 6, Number2: 3}, Want: 9, WantCalls: 1},
+		{Case: "Multiply", Call: cache.MultiplyNumber, In: &pb.MathRequest{Number1: 6, Number2: 3}, Want: 18, WantCalls: 2},
+		{Case: "Divide", Call: cache.DevideNumber, In: &pb.MathRequest{Number1: 6, Number2: 3}, Want: 2, WantCalls: 3},
+		{Case: "Divide again", Call: cache.DevideNumber, In: &pb.MathRequest{Number1: 6, Number2: 3}, Want: 2, WantCalls: 3},
+		{Case: "Fraction", Call: cache.AddNumber, In: &pb.MathRequest{Number1: 1.5, Number2: 2}, Want: 3.5, WantCalls: 4},
+		{Case: "Another fraction", Call: cache.AddNumber, In: &pb.MathRequest{Number1: 1.25, Number2: 2}, Want: 3.25, WantCalls: 5},
+		{Case: "Fraction again", Call: cache.AddNumber, In: &pb.MathRequest{Number1: 1.5, Number2: 2}, Want: 3.5, WantCalls: 5},
+	}
+	for n, c := range cases {
+		response, err := c.Call(context.Background(), c.In)
+		if err != nil {
+			t.Errorf("Case: %d: %s: Unexpected error: %s", n, c.Case, err.Error())
+			continue
+		}
+		if response.Result != c.Want || backing.calls != c.WantCalls {
+			t.Errorf("Case: %d: %s: Got %v after %d calls, want %v after %d", n, c.Case, response.Result, backing.calls, c.Want, c.WantCalls)
+		}
+	}
+}
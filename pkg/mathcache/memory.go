@@ -0,0 +1,75 @@
+package mathcache
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/golang/protobuf/proto"
+)
+
+// MemoryStore is an in-process Store for development and tests, it misses the way memcache does.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]memoryEntry{}, now: time.Now}
+}
+
+func memoryKey(primaryContext, secondaryContext, key string) string {
+	return strings.Join([]string{primaryContext, secondaryContext, key}, "|")
+}
+
+// Get implements Store.
+func (m *MemoryStore) Get(primaryContext, secondaryContext, key string, result proto.Message) error {
+	k := memoryKey(primaryContext, secondaryContext, key)
+
+	m.mu.Lock()
+	entry, ok := m.entries[k]
+	if ok && !entry.expires.After(m.now()) {
+		delete(m.entries, k)
+		ok = false
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return memcache.ErrCacheMiss
+	}
+	return proto.Unmarshal(entry.value, result)
+}
+
+// Set implements Store.
+func (m *MemoryStore) Set(primaryContext, secondaryContext, key string, value proto.Message, expiration time.Duration) error {
+	raw, err := proto.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.entries[memoryKey(primaryContext, secondaryContext, key)] = memoryEntry{value: raw, expires: m.now().Add(expiration)}
+	m.mu.Unlock()
+	return nil
+}
+
+// GetMulti implements Store.
+func (m *MemoryStore) GetMulti(keys []Key, results []proto.Message) ([]bool, error) {
+	found := make([]bool, len(keys))
+	for n, k := range keys {
+		err := m.Get(k.PrimaryContext, k.SecondaryContext, k.Key, results[n])
+		if err != nil && err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+		found[n] = err == nil
+	}
+	return found, nil
+}
@@ -0,0 +1,124 @@
+package mathcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mangeshhendre/protocache"
+)
+
+// Key names one cached result, in the three parts protocache keys are made of.
+type Key struct {
+	PrimaryContext   string
+	SecondaryContext string
+	Key              string
+}
+
+// protocacheStore adds GetMulti to protocache, which only gets one key at a time.
+type protocacheStore struct {
+	*protocache.PC
+}
+
+// GetMulti implements Store.  It derives the memcache keys the way protocache does, scope,
+// then primary, then secondary version counters, but a level at a time for every key at
+// once, so a batch costs four round trips whatever its size.  A counter that is missing or
+// unreadable counts as version 1, which is what protocache would reset it to.
+func (s protocacheStore) GetMulti(keys []Key, results []proto.Message) ([]bool, error) {
+	scope, err := s.versions([]string{s.HashKey(s.Scope)})
+	if err != nil {
+		return nil, err
+	}
+	scopeAlone := scope[s.HashKey(s.Scope)]
+
+	primaries := make([]string, len(keys))
+	for n, k := range keys {
+		primaries[n] = s.HashKey(s.ConcatKeys(scopeAlone, k.PrimaryContext))
+	}
+	primaryVersions, err := s.versions(primaries)
+	if err != nil {
+		return nil, err
+	}
+
+	secondaries := make([]string, len(keys))
+	for n, k := range keys {
+		secondaries[n] = s.HashKey(s.ConcatKeys(primaryVersions[primaries[n]], k.SecondaryContext))
+	}
+	secondaryVersions, err := s.versions(secondaries)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make([]string, len(keys))
+	for n, k := range keys {
+		encoded[n] = s.HashKey(secondaryVersions[secondaries[n]], k.Key)
+	}
+	items, err := s.Memcache.GetMulti(unique(encoded))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]bool, len(keys))
+	for n, key := range encoded {
+		item, ok := items[key]
+		if !ok {
+			continue
+		}
+		value := item.Value
+		if item.Flags > 0 {
+			if value, err = gunzip(value); err != nil {
+				return nil, err
+			}
+		}
+		if err := proto.Unmarshal(value, results[n]); err != nil {
+			return nil, err
+		}
+		found[n] = true
+	}
+	return found, nil
+}
+
+// versions gets the counters of hashed keys in one round trip and returns each key's
+// versioned form.
+func (s protocacheStore) versions(hashed []string) (map[string]string, error) {
+	items, err := s.Memcache.GetMulti(unique(hashed))
+	if err != nil {
+		return nil, err
+	}
+	versioned := make(map[string]string, len(hashed))
+	for _, key := range hashed {
+		counter := uint64(1)
+		if item, ok := items[key]; ok {
+			if c, err := strconv.ParseUint(string(item.Value), 10, 64); err == nil {
+				counter = c
+			}
+		}
+		versioned[key] = s.VersionedKey(key, counter)
+	}
+	return versioned, nil
+}
+
+// unique drops repeated keys, memcache answers each key once however often it is asked.
+func unique(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	var out []string
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// gunzip undoes the compression protocache applies to large values.
+func gunzip(value []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(value))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
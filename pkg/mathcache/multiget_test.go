@@ -0,0 +1,137 @@
+package mathcache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"github.com/mangeshhendre/protocache"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// memcached speaks enough of the memcache text protocol for protocache: gets and set.
+type memcached struct {
+	mu    sync.Mutex
+	items map[string][2]string // flags, value
+	gets  int
+}
+
+func startMemcached(t *testing.T) (*memcached, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %s", err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+	m := &memcached{items: map[string][2]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go m.serve(conn)
+		}
+	}()
+	return m, listener.Addr().String()
+}
+
+func (m *memcached) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		m.mu.Lock()
+		switch fields[0] {
+		case "gets":
+			m.gets++
+			for _, key := range fields[1:] {
+				if item, ok := m.items[key]; ok {
+					fmt.Fprintf(conn, "VALUE %s %s %d 1\r\n%s\r\n", key, item[0], len(item[1]), item[1])
+				}
+			}
+			io.WriteString(conn, "END\r\n")
+		case "set":
+			size, _ := strconv.Atoi(fields[4])
+			value := make([]byte, size+2)
+			io.ReadFull(r, value)
+			m.items[fields[1]] = [2]string{fields[2], string(value[:size])}
+			io.WriteString(conn, "STORED\r\n")
+		default:
+			io.WriteString(conn, "ERROR\r\n")
+		}
+		m.mu.Unlock()
+	}
+}
+
+func TestProtocacheStore_GetMulti(t *testing.T) {
+	server, address := startMemcached(t)
+	pc := protocache.New("Test", address)
+	store := protocacheStore{pc}
+
+	// Big enough for protocache to compress it.
+	big := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "number1", Description: strings.Repeat("big ", 500)}}}
+	if err := pc.Set("Number1:1", "Number2:2", "MathAddNumber", &pb.MathResponse{Result: 3}, time.Minute); err != nil {
+		t.Fatalf("Unable to set: %s", err.Error())
+	}
+	if err := pc.Set("Number1:1", "Number2:3", "MathAddNumber", &pb.MathResponse{Result: 4}, time.Minute); err != nil {
+		t.Fatalf("Unable to set: %s", err.Error())
+	}
+	if err := pc.Set("Number1:9", "Number2:9", "Big", big, time.Minute); err != nil {
+		t.Fatalf("Unable to set: %s", err.Error())
+	}
+
+	var cases = []struct {
+		Case      string
+		Key       Key
+		Result    proto.Message
+		WantFound bool
+		Want      proto.Message
+	}{
+		{Case: "Hit", Key: Key{"Number1:1", "Number2:2", "MathAddNumber"}, Result: &pb.MathResponse{}, WantFound: true, Want: &pb.MathResponse{Result: 3}},
+		{Case: "Same primary", Key: Key{"Number1:1", "Number2:3", "MathAddNumber"}, Result: &pb.MathResponse{}, WantFound: true, Want: &pb.MathResponse{Result: 4}},
+		{Case: "Other method", Key: Key{"Number1:1", "Number2:2", "MathMultiplyNumber"}, Result: &pb.MathResponse{}},
+		{Case: "Unknown primary", Key: Key{"Number1:5", "Number2:2", "MathAddNumber"}, Result: &pb.MathResponse{}},
+		{Case: "Compressed", Key: Key{"Number1:9", "Number2:9", "Big"}, Result: &errdetails.BadRequest{}, WantFound: true, Want: big},
+		{Case: "Repeated", Key: Key{"Number1:1", "Number2:2", "MathAddNumber"}, Result: &pb.MathResponse{}, WantFound: true, Want: &pb.MathResponse{Result: 3}},
+	}
+	keys := make([]Key, len(cases))
+	results := make([]proto.Message, len(cases))
+	for n, c := range cases {
+		keys[n], results[n] = c.Key, c.Result
+	}
+
+	server.mu.Lock()
+	server.gets = 0
+	server.mu.Unlock()
+	found, err := store.GetMulti(keys, results)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	for n, c := range cases {
+		if found[n] != c.WantFound {
+			t.Errorf("Case: %d: %s: Got found %t, want %t", n, c.Case, found[n], c.WantFound)
+			continue
+		}
+		if c.WantFound && !proto.Equal(results[n], c.Want) {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, c.Case, results[n], c.Want)
+		}
+	}
+	if server.gets != 4 {
+		t.Errorf("GetMulti made %d round trips, want 4", server.gets)
+	}
+}
@@ -0,0 +1,85 @@
+package mathclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/mangeshhendre/jwtclient"
+	"golang.org/x/net/context"
+)
+
+// tokenSource sends a JWT with every call and fetches a new one shortly before it expires,
+// or straight away once the server has turned it down.
+type tokenSource struct {
+	mu            sync.Mutex
+	fetch         func() (string, error)
+	token         string
+	expires       time.Time
+	refreshBefore time.Duration
+	now           func() time.Time
+}
+
+func newTokenSource(fetch func() (string, error), refreshBefore time.Duration) *tokenSource {
+	return &tokenSource{fetch: fetch, refreshBefore: refreshBefore, now: time.Now}
+}
+
+// authenticate always asks the authentication service for a new token, jwtclient would
+// hand back its old one until the moment it expires.
+func authenticate(jc *jwtclient.Client) func() (string, error) {
+	return func() (string, error) {
+		if err := jc.Authenticate(); err != nil {
+			return "", err
+		}
+		if !jc.StillValid() {
+			return "", fmt.Errorf("the authentication service returned an invalid token")
+		}
+		return jc.RetrieveToken()
+	}
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (t *tokenSource) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == "" || (!t.expires.IsZero() && t.now().Add(t.refreshBefore).After(t.expires)) {
+		token, err := t.fetch()
+		if err != nil {
+			return nil, fmt.Errorf("unable to authenticate: %v", err)
+		}
+		t.token, t.expires = token, expiry(token)
+	}
+	return map[string]string{"authorization": "bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+func (t *tokenSource) RequireTransportSecurity() bool {
+	return true
+}
+
+// invalidate forgets the token, so the next call fetches a new one.
+func (t *tokenSource) invalidate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = ""
+}
+
+// expiry reads the exp claim without checking the signature, that is the server's job.
+// A token without one is treated as never expiring.
+func expiry(raw string) time.Time {
+	token, _ := jwt.Parse(raw, nil)
+	if token == nil {
+		return time.Time{}
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return time.Time{}
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(exp), 0)
+}
@@ -0,0 +1,76 @@
+package mathclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type cacheKey struct {
+	method  string
+	number1 float64
+	number2 float64
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	result  float64
+	expires time.Time
+}
+
+// responseCache is a small LRU of answers, each kept for at most ttl.  A nil cache
+// never hits, so callers need not check whether caching is on.
+type responseCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[cacheKey]*list.Element
+	now     func() time.Time
+}
+
+func newResponseCache(size int, ttl time.Duration) *responseCache {
+	return &responseCache{size: size, ttl: ttl, order: list.New(), entries: map[cacheKey]*list.Element{}, now: time.Now}
+}
+
+func (c *responseCache) get(key cacheKey) (float64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if c.now().After(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return 0, false
+	}
+	c.order.MoveToFront(e)
+	return entry.result, true
+}
+
+func (c *responseCache) put(key cacheKey, result float64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+		entry.result, entry.expires = result, c.now().Add(c.ttl)
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, result: result, expires: c.now().Add(c.ttl)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,112 @@
+package mathclient
+
+import (
+	"sync"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Call is one call made on a Fake.
+type Call struct {
+	Method  string
+	Number1 float64
+	Number2 float64
+}
+
+// Fake is a Client for consumers' unit tests.  It does the arithmetic itself, refuses what
+// a server with the default validation policy refuses, records every call and can be told
+// to fail.
+type Fake struct {
+	mu     sync.Mutex
+	calls  []Call
+	errors map[string]error
+	closed bool
+}
+
+var _ Client = (*Fake)(nil)
+
+// NewFake creates a Fake that answers everything.
+func NewFake() *Fake {
+	return &Fake{errors: map[string]error{}}
+}
+
+// FailWith makes every later call to method (Add, Multiply or Divide) return err, a nil
+// err makes it answer again.
+func (f *Fake) FailWith(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[method] = err
+}
+
+// Calls returns the calls made so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Add returns number1 + number2.
+func (f *Fake) Add(ctx context.Context, number1, number2 float64) (float64, error) {
+	return f.call(ctx, "Add", number1, number2, func() (float64, error) {
+		return validated("AddNumber", number1, number2, number1+number2)
+	})
+}
+
+// Multiply returns number1 * number2.
+func (f *Fake) Multiply(ctx context.Context, number1, number2 float64) (float64, error) {
+	return f.call(ctx, "Multiply", number1, number2, func() (float64, error) {
+		return validated("MultiplyNumber", number1, number2, number1*number2)
+	})
+}
+
+// Divide returns number1 / number2.
+func (f *Fake) Divide(ctx context.Context, number1, number2 float64) (float64, error) {
+	return f.call(ctx, "Divide", number1, number2, func() (float64, error) {
+		return validated("DevideNumber", number1, number2, number1/number2)
+	})
+}
+
+// Close makes later calls fail the way they do on a closed connection.
+func (f *Fake) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+// validated returns result, or the error a server with the default validation policy
+// returns for method instead.
+func validated(method string, number1, number2, result float64) (float64, error) {
+	policy, in := config.Default().Validation, &pb.MathRequest{Number1: number1, Number2: number2}
+	if err := validate.Request(policy, method, in); err != nil {
+		return 0, err
+	}
+	if err := validate.Result(policy, &pb.MathResponse{Result: result}); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func (f *Fake) call(ctx context.Context, method string, number1, number2 float64, answer func() (float64, error)) (float64, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Method: method, Number1: number1, Number2: number2})
+	closed, err := f.closed, f.errors[method]
+	f.mu.Unlock()
+
+	switch {
+	case closed:
+		return 0, status.Error(codes.Canceled, "grpc: the client connection is closing")
+	case ctx.Err() == context.DeadlineExceeded:
+		return 0, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	case ctx.Err() != nil:
+		return 0, status.Error(codes.Canceled, ctx.Err().Error())
+	case err != nil:
+		return 0, err
+	}
+	return answer()
+}
@@ -0,0 +1,249 @@
+// Package mathclient is the Go client for mathsvc.  It wraps the generated client with
+// default deadlines, retries with backoff, JWT refresh and an optional response cache.
+package mathclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/jwtclient"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// Client is what consumers program against, Fake implements it for their tests.
+type Client interface {
+	Add(ctx context.Context, number1, number2 float64) (float64, error)
+	Multiply(ctx context.Context, number1, number2 float64) (float64, error)
+	Divide(ctx context.Context, number1, number2 float64) (float64, error)
+	Close() error
+}
+
+// Config says where mathsvc is and how to talk to it.
+type Config struct {
+	Host string
+	Port string
+
+	// AuthURL, Username and Password fetch the JWT sent with every call.
+	AuthURL  string
+	Username string
+	Password string
+
+	// Insecure skips TLS certificate checks, Plaintext drops TLS and the JWT altogether
+	// for a server started with -dev.
+	Insecure  bool
+	Plaintext bool
+
+	// Timeout bounds each attempt, the caller's context bounds the call as a whole.
+	Timeout time.Duration
+
+	// Retries is how many times a retryable failure is tried again, waiting Backoff,
+	// doubling up to MaxBackoff, with jitter.
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// CacheTTL keeps answers on the client for that long, zero turns the cache off.
+	CacheTTL  time.Duration
+	CacheSize int
+
+	// RefreshBefore fetches a new JWT that long before the current one expires.
+	RefreshBefore time.Duration
+}
+
+// Default returns a Config with the defaults, and nowhere to connect to.
+func Default() Config {
+	return Config{
+		Port:          "32363",
+		Timeout:       5 * time.Second,
+		Retries:       3,
+		Backoff:       100 * time.Millisecond,
+		MaxBackoff:    2 * time.Second,
+		CacheSize:     1024,
+		RefreshBefore: 30 * time.Second,
+	}
+}
+
+// FromEnv returns the defaults overridden by the same environment as cmd/client:
+// GRPC_HOST, GRPC_PORT, GRPC_AUTH_URL, GRPC_AUTH_USERNAME, GRPC_AUTH_PASSWORD and DOMAIN.
+func FromEnv() Config {
+	c := Default()
+	c.Host = grpcutils.EnvOrDefault("GRPC_HOST", "mathsvc.grpc."+grpcutils.EnvOrDefault("DOMAIN", "safeguardproperties.com"))
+	c.Port = grpcutils.EnvOrDefault("GRPC_PORT", c.Port)
+	c.AuthURL = grpcutils.EnvOrDefault("GRPC_AUTH_URL", "https://authentication."+grpcutils.EnvOrDefault("DOMAIN", "sgtec.io"))
+	c.Username = grpcutils.EnvOrDefault("GRPC_AUTH_USERNAME", "")
+	c.Password = grpcutils.EnvOrDefault("GRPC_AUTH_PASSWORD", "")
+	return c
+}
+
+// GRPCClient is the Client for a real server.
+type GRPCClient struct {
+	config Config
+	math   pb.MathClient
+	conn   *grpc.ClientConn
+	tokens *tokenSource
+	cache  *responseCache
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+var _ Client = (*GRPCClient)(nil)
+
+// New dials mathsvc.  The connection is made lazily, so New only fails on bad configuration.
+func New(c Config) (*GRPCClient, error) {
+	if c.Host == "" || c.Port == "" {
+		return nil, fmt.Errorf("mathclient: host and port are required")
+	}
+
+	var (
+		opts   []grpc.DialOption
+		tokens *tokenSource
+	)
+	if c.Plaintext {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		jc, err := jwtclient.New(&jwtclient.Config{AuthKey: c.Username, AuthSecret: c.Password, URL: c.AuthURL, Insecure: c.Insecure})
+		if err != nil {
+			return nil, fmt.Errorf("mathclient: unable to create jwtclient: %v", err)
+		}
+		tokens = newTokenSource(authenticate(jc), c.RefreshBefore)
+		opts = append(opts,
+			grpc.WithPerRPCCredentials(tokens),
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: c.Insecure})),
+		)
+	}
+
+	conn, err := grpc.Dial(net.JoinHostPort(c.Host, c.Port), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("mathclient: unable to dial: %v", err)
+	}
+
+	client := newClient(pb.NewMathClient(conn), c)
+	client.conn, client.tokens = conn, tokens
+	return client, nil
+}
+
+// NewWithConn uses a connection the caller made, and will not close it.
+func NewWithConn(conn *grpc.ClientConn, c Config) *GRPCClient {
+	return newClient(pb.NewMathClient(conn), c)
+}
+
+func newClient(math pb.MathClient, c Config) *GRPCClient {
+	client := &GRPCClient{
+		config: c,
+		math:   math,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if c.CacheTTL > 0 && c.CacheSize > 0 {
+		client.cache = newResponseCache(c.CacheSize, c.CacheTTL)
+	}
+	return client
+}
+
+// Add returns number1 + number2.
+func (c *GRPCClient) Add(ctx context.Context, number1, number2 float64) (float64, error) {
+	return c.call(ctx, "AddNumber", pb.MathClient.AddNumber, number1, number2)
+}
+
+// Multiply returns number1 * number2.
+func (c *GRPCClient) Multiply(ctx context.Context, number1, number2 float64) (float64, error) {
+	return c.call(ctx, "MultiplyNumber", pb.MathClient.MultiplyNumber, number1, number2)
+}
+
+// Divide returns number1 / number2.
+func (c *GRPCClient) Divide(ctx context.Context, number1, number2 float64) (float64, error) {
+	return c.call(ctx, "DevideNumber", pb.MathClient.DevideNumber, number1, number2)
+}
+
+// Close closes the connection, if New made it.
+func (c *GRPCClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+type rpc func(pb.MathClient, context.Context, *pb.MathRequest, ...grpc.CallOption) (*pb.MathResponse, error)
+
+// call answers from the cache, or makes the RPC, retrying what is worth retrying.
+func (c *GRPCClient) call(ctx context.Context, method string, do rpc, number1, number2 float64) (float64, error) {
+	key := cacheKey{method: method, number1: number1, number2: number2}
+	if result, ok := c.cache.get(key); ok {
+		return result, nil
+	}
+
+	request := &pb.MathRequest{Number1: number1, Number2: number2}
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		response, err := c.attempt(ctx, do, request)
+		if err == nil {
+			c.cache.put(key, response.Result)
+			return response.Result, nil
+		}
+
+		// An expired or revoked token gets one fresh token, outside the retry budget.
+		if status.Code(err) == codes.Unauthenticated && c.tokens != nil && !refreshed {
+			refreshed = true
+			c.tokens.invalidate()
+			attempt--
+			continue
+		}
+
+		if attempt >= c.config.Retries || !retryable(ctx, err) {
+			return 0, err
+		}
+		select {
+		case <-time.After(c.backoff(attempt)):
+		case <-ctx.Done():
+			return 0, err
+		}
+	}
+}
+
+// attempt makes one try, bounded by Timeout.
+func (c *GRPCClient) attempt(ctx context.Context, do rpc, request *pb.MathRequest) (*pb.MathResponse, error) {
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+	return do(c.math, ctx, request)
+}
+
+// retryable reports whether a failure may go away on its own.  A deadline counts only
+// when it was the attempt's Timeout that ran out, not the caller's.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// backoff is the wait before retry attempt+1: exponential, capped, with full jitter.
+func (c *GRPCClient) backoff(attempt int) time.Duration {
+	wait := c.config.Backoff << uint(attempt)
+	if wait <= 0 || (c.config.MaxBackoff > 0 && wait > c.config.MaxBackoff) {
+		wait = c.config.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Duration(c.rand.Int63n(int64(wait)) + 1)
+}
@@ -0,0 +1,210 @@
+package mathclient
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scripted fails with its errors in turn, then adds.
+type scripted struct {
+	mu     sync.Mutex
+	errors []error
+	calls  int
+}
+
+func (s *scripted) AddNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if len(s.errors) > 0 {
+		err := s.errors[0]
+		s.errors = s.errors[1:]
+		return nil, err
+	}
+	return &pb.MathResponse{Result: in.Number1 + in.Number2}, nil
+}
+
+func (s *scripted) MultiplyNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted")
+}
+
+func (s *scripted) DevideNumber(ctx context.Context, in *pb.MathRequest, opts ...grpc.CallOption) (*pb.MathResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted")
+}
+
+func testConfig() Config {
+	c := Default()
+	c.Backoff, c.MaxBackoff = time.Millisecond, 2*time.Millisecond
+	return c
+}
+
+func TestGRPCClient_Retry(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	var cases = []struct {
+		Case    string
+		Errors  []error
+		Calls   int
+		WantErr codes.Code
+	}{
+		{Case: "First Time", Errors: nil, Calls: 1},
+		{Case: "Recovers", Errors: []error{unavailable, status.Error(codes.ResourceExhausted, "slow down")}, Calls: 3},
+		{Case: "Gives Up", Errors: []error{unavailable, unavailable, unavailable, unavailable, unavailable}, Calls: 4, WantErr: codes.Unavailable},
+		{Case: "Not Retryable", Errors: []error{status.Error(codes.InvalidArgument, "no")}, Calls: 1, WantErr: codes.InvalidArgument},
+	}
+	for n, c := range cases {
+		server := &scripted{errors: c.Errors}
+		client := newClient(server, testConfig())
+
+		result, err := client.Add(context.Background(), 3, 4)
+		if got := status.Code(err); got != c.WantErr {
+			t.Errorf("Case: %d: %s: Got code %s, want %s", n, c.Case, got, c.WantErr)
+		}
+		if err == nil && result != 7 {
+			t.Errorf("Case: %d: %s: Got %v, want 7", n, c.Case, result)
+		}
+		if server.calls != c.Calls {
+			t.Errorf("Case: %d: %s: Got %d calls, want %d", n, c.Case, server.calls, c.Calls)
+		}
+	}
+}
+
+func TestGRPCClient_CallerDeadline(t *testing.T) {
+	server := &scripted{errors: []error{status.Error(codes.Unavailable, "down")}}
+	c := testConfig()
+	c.Backoff, c.MaxBackoff = time.Hour, time.Hour
+	client := newClient(server, c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Add(ctx, 1, 2); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected the last failure once the caller's deadline passed, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Backoff outlived the caller's deadline: %s", elapsed)
+	}
+}
+
+func TestGRPCClient_Cache(t *testing.T) {
+	server := &scripted{}
+	c := testConfig()
+	c.CacheTTL, c.CacheSize = time.Minute, 1
+	client := newClient(server, c)
+
+	for _, pair := range [][2]float64{{1, 2}, {1, 2}, {3, 4}, {1, 2}} {
+		if _, err := client.Add(context.Background(), pair[0], pair[1]); err != nil {
+			t.Fatalf("Unexpected Error: %s", err.Error())
+		}
+	}
+	if server.calls != 3 {
+		t.Errorf("Expected a repeat to hit and an eviction to miss, got %d calls", server.calls)
+	}
+
+	client.cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, ok := client.cache.get(cacheKey{method: "AddNumber", number1: 1, number2: 2}); ok {
+		t.Errorf("Expected an expired answer to miss")
+	}
+}
+
+func TestGRPCClient_Unauthenticated(t *testing.T) {
+	server := &scripted{errors: []error{status.Error(codes.Unauthenticated, "expired"), status.Error(codes.Unauthenticated, "still expired")}}
+	client := newClient(server, testConfig())
+
+	fetches := 0
+	client.tokens = newTokenSource(func() (string, error) { fetches++; return "token", nil }, 0)
+	client.tokens.GetRequestMetadata(context.Background())
+
+	if _, err := client.Add(context.Background(), 1, 2); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected a second rejection to be returned, got %v", err)
+	}
+	if server.calls != 2 {
+		t.Errorf("Expected one retry with a fresh token, got %d calls", server.calls)
+	}
+	client.tokens.GetRequestMetadata(context.Background())
+	if fetches != 2 {
+		t.Errorf("Expected the rejected token to be replaced, got %d fetches", fetches)
+	}
+}
+
+func TestTokenSource_Refresh(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	token := func(exp int64) string {
+		enc := base64.RawURLEncoding.EncodeToString
+		return enc([]byte(`{"alg":"none"}`)) + "." + enc([]byte(`{"exp":`+strconv.FormatInt(exp, 10)+`}`)) + "."
+	}
+
+	var issued []string
+	source := newTokenSource(func() (string, error) {
+		t := token(now.Add(5 * time.Minute).Unix())
+		issued = append(issued, t)
+		return t, nil
+	}, 30*time.Second)
+	source.now = func() time.Time { return now }
+
+	var cases = []struct {
+		Case    string
+		Advance time.Duration
+		Fetches int
+	}{
+		{Case: "First Call", Advance: 0, Fetches: 1},
+		{Case: "Still Fresh", Advance: 4 * time.Minute, Fetches: 1},
+		{Case: "Near Expiry", Advance: 45 * time.Second, Fetches: 2},
+		{Case: "Fresh Again", Advance: time.Minute, Fetches: 2},
+	}
+	for n, c := range cases {
+		now = now.Add(c.Advance)
+		md, err := source.GetRequestMetadata(context.Background())
+		if err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if len(issued) != c.Fetches || md["authorization"] != "bearer "+issued[len(issued)-1] {
+			t.Errorf("Case: %d: %s: Got %d fetches and %q, want %d", n, c.Case, len(issued), md["authorization"], c.Fetches)
+		}
+	}
+
+	failing := newTokenSource(func() (string, error) { return "", errors.New("nope") }, 0)
+	if _, err := failing.GetRequestMetadata(context.Background()); err == nil {
+		t.Errorf("Expected a failed fetch to fail the call")
+	}
+}
+
+func TestFake(t *testing.T) {
+	fake := NewFake()
+	var client Client = fake
+	ctx := context.Background()
+
+	if got, err := client.Divide(ctx, 1, 4); err != nil || got != 0.25 {
+		t.Errorf("Divide: Got %v, %v", got, err)
+	}
+	if _, err := client.Divide(ctx, 1, 0); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected dividing by zero to be refused, got %v", err)
+	}
+
+	fake.FailWith("Add", status.Error(codes.Unavailable, "down"))
+	if _, err := client.Add(ctx, 1, 2); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected the injected error, got %v", err)
+	}
+	fake.FailWith("Add", nil)
+	if got, err := client.Add(ctx, 1, 2); err != nil || got != 3 {
+		t.Errorf("Add: Got %v, %v", got, err)
+	}
+
+	client.Close()
+	if _, err := client.Multiply(ctx, 2, 3); status.Code(err) != codes.Canceled {
+		t.Errorf("Expected a closed Fake to fail, got %v", err)
+	}
+	if calls := fake.Calls(); len(calls) != 5 || calls[4] != (Call{Method: "Multiply", Number1: 2, Number2: 3}) {
+		t.Errorf("Unexpected calls: %+v", calls)
+	}
+}
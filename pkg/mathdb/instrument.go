@@ -0,0 +1,102 @@
+package mathdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	"github.com/mangeshhendre/mathsvc/pkg/tracing"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	queriesTotal = metrics.DefaultRegistry.NewCounterVec(
+		"mathsvc_db_queries_total",
+		"Number of database queries, by query and status code.",
+		"query", "code")
+	queryDuration = metrics.DefaultRegistry.NewHistogramVec(
+		"mathsvc_db_query_duration_seconds",
+		"Latency of database queries, by query.",
+		nil, "query")
+	queriesInFlight = metrics.DefaultRegistry.NewGaugeVec(
+		"mathsvc_db_queries_in_flight",
+		"Number of database queries currently executing.",
+		"query")
+)
+
+// query is the in flight state for a single database query.
+type query struct {
+	name  string
+	start time.Time
+	span  *tracing.Span
+}
+
+// startQuery marks a query as in flight and opens its span, it is meant to be handed to finishQuery.
+func startQuery(ctx context.Context, system, name, statement string) (context.Context, query) {
+	queriesInFlight.WithLabelValues(name).Inc()
+
+	ctx, span := tracing.Start(ctx, "db."+name, tracing.KindClient)
+	span.SetAttribute("db.system", system)
+	span.SetAttribute("db.operation", name)
+	span.SetAttribute("db.statement", statement)
+
+	return ctx, query{name: name, start: time.Now(), span: span}
+}
+
+// finishQuery records the outcome of a query, it is meant to be deferred with a pointer to the named error.
+func finishQuery(q query, err *error) {
+	queriesInFlight.WithLabelValues(q.name).Dec()
+	queriesTotal.WithLabelValues(q.name, status.Code(*err).String()).Inc()
+	queryDuration.WithLabelValues(q.name).Observe(metrics.Since(q.start))
+
+	q.span.SetError(*err)
+	q.span.Finish()
+}
+
+// pool tracks the most recently opened database so its pool can be reported.
+var pool = &poolStats{}
+
+type poolStats struct {
+	mu sync.RWMutex
+	db *sql.DB
+}
+
+func (p *poolStats) track(db *sql.DB) {
+	p.mu.Lock()
+	p.db = db
+	p.mu.Unlock()
+}
+
+func (p *poolStats) stat(fn func(sql.DBStats) float64) func() float64 {
+	return func() float64 {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		if p.db == nil {
+			return 0
+		}
+		return fn(p.db.Stats())
+	}
+}
+
+func init() {
+	metrics.DefaultRegistry.NewGaugeFunc("mathsvc_db_pool_max_open_connections",
+		"Maximum number of open connections to the database.",
+		pool.stat(func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }))
+	metrics.DefaultRegistry.NewGaugeFunc("mathsvc_db_pool_open_connections",
+		"Number of established connections, both in use and idle.",
+		pool.stat(func(s sql.DBStats) float64 { return float64(s.OpenConnections) }))
+	metrics.DefaultRegistry.NewGaugeFunc("mathsvc_db_pool_in_use_connections",
+		"Number of connections currently in use.",
+		pool.stat(func(s sql.DBStats) float64 { return float64(s.InUse) }))
+	metrics.DefaultRegistry.NewGaugeFunc("mathsvc_db_pool_idle_connections",
+		"Number of idle connections.",
+		pool.stat(func(s sql.DBStats) float64 { return float64(s.Idle) }))
+	metrics.DefaultRegistry.NewCounterFunc("mathsvc_db_pool_wait_count_total",
+		"Total number of connections waited for.",
+		pool.stat(func(s sql.DBStats) float64 { return float64(s.WaitCount) }))
+	metrics.DefaultRegistry.NewCounterFunc("mathsvc_db_pool_wait_duration_seconds_total",
+		"Total time spent waiting for a connection.",
+		pool.stat(func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }))
+}
@@ -6,8 +6,6 @@ import (
 
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	context "golang.org/x/net/context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // AddNumber will retrieve database details given the request.
@@ -15,12 +13,8 @@ func (c *Client) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathRes
 	c.logger.Info("AddNumber")
 	defer c.tracer.Statsd("AddNumber", time.Now())
 
-	if in.Number1 == 0 || in.Number2 == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "Zero is invalid for Number1:%f or Number2:%f", in.Number1, in.Number2)
-	}
-
 	//this is sample how to call Db results.
-	dbResults, err := c.getSomeInfoFromDb(in)
+	dbResults, err := c.getSomeInfoFromDb(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -38,12 +32,8 @@ func (c *Client) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.Ma
 	c.logger.Info("AddNumber")
 	defer c.tracer.Statsd("AddNumber", time.Now())
 
-	if in.Number1 == 0 || in.Number2 == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "Zero is invalid for Number1:%f or Number2:%f", in.Number1, in.Number2)
-	}
-
 	//this is sample how to call Db results.
-	dbResults, err := c.getSomeInfoFromDb(in)
+	dbResults, err := c.getSomeInfoFromDb(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -61,12 +51,8 @@ func (c *Client) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.Math
 	c.logger.Info("AddNumber")
 	defer c.tracer.Statsd("AddNumber", time.Now())
 
-	if in.Number1 == 0 || in.Number2 == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "Zero is invalid for Number1:%f or Number2:%f", in.Number1, in.Number2)
-	}
-
 	//this is sample how to call Db results.
-	dbResults, err := c.getSomeInfoFromDb(in)
+	dbResults, err := c.getSomeInfoFromDb(ctx, in)
 	if err != nil {
 		return nil, err
 	}
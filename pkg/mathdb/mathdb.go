@@ -1,9 +1,12 @@
 package mathdb
 
 import (
+	"context"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	"github.com/mangeshhendre/tracer"
 	logxi "github.com/mgutz/logxi/v1"
@@ -14,30 +17,72 @@ import (
 // Client is the actual database client.
 type Client struct {
 	DB     *sqlx.DB
+	store  store
 	logger logxi.Logger
 	tracer *tracer.Tracer
 }
 
+// store is where the client looks up rows, Oracle in production and memory in development.
+type store interface {
+	system() string
+	getSomeInfo(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error)
+}
+
 // New is the new database/sql version of the processing.
-func New(DB *sqlx.DB) (*Client, error) {
+func New(DB *sqlx.DB, statsd config.Statsd) (*Client, error) {
+	pool.track(DB.DB)
 
 	return &Client{
 		DB:     DB,
-		logger: logxi.New("sql.go"),
-		tracer: tracer.New("graphite:8125", "grpc.mathsvc.adb", 1),
+		store:  &sqlStore{DB: DB},
+		logger: logging.New("sql.go"),
+		tracer: tracer.New(statsd.Address, statsd.Prefix+".adb", statsd.Sample),
 	}, nil
 }
 
+// NewMemory creates a client backed by an in-memory table instead of Oracle.
+func NewMemory(statsd config.Statsd) *Client {
+	return &Client{
+		store:  newMemoryStore(),
+		logger: logging.New("sql.go"),
+		tracer: tracer.New(statsd.Address, statsd.Prefix+".adb", statsd.Sample),
+	}
+}
+
+// Close closes the database, if there is one.
+func (c *Client) Close() error {
+	if c.DB == nil {
+		return nil
+	}
+	return c.DB.Close()
+}
+
 // GetWorkOrderDate is a function that given the work order number, it will return the time.Time that represents the audited result for that order.
-func (c *Client) getSomeInfoFromDb(in *pb.MathRequest) (mathResp *pb.MathResponse, err error) {
+func (c *Client) getSomeInfoFromDb(ctx context.Context, in *pb.MathRequest) (mathResp *pb.MathResponse, err error) {
 	c.logger.Info("getSomeInfoFromDb")
 	defer c.tracer.Statsd("getSomeInfoFromDb", time.Now())
 
+	ctx, q := startQuery(ctx, c.store.system(), "getSomeInfoFromDb", someDBQuery)
+	defer finishQuery(q, &err)
+
+	return c.store.getSomeInfo(ctx, in)
+}
+
+// sqlStore looks rows up in Oracle.
+type sqlStore struct {
+	DB *sqlx.DB
+}
+
+func (s *sqlStore) system() string {
+	return "oracle"
+}
+
+func (s *sqlStore) getSomeInfo(ctx context.Context, in *pb.MathRequest) (mathResp *pb.MathResponse, err error) {
 	mathResp = &pb.MathResponse{}
 
-	row := c.DB.QueryRowx(someDBQuery, in.Number1, in.Number2)
-	if row.Err() != nil {
-		return nil, status.Errorf(codes.Internal, "getSomeInfoFromDb: query error, number1: %f, number2 %f, error: %s", in.Number1, in.Number2, err.Error())
+	row := s.DB.QueryRowxContext(ctx, someDBQuery, in.Number1, in.Number2)
+	if err = row.Err(); err != nil {
+		return nil, status.Errorf(queryCode(err), "getSomeInfoFromDb: query error, number1: %f, number2 %f, error: %s", in.Number1, in.Number2, err.Error())
 	}
 
 	err = row.StructScan(mathResp)
@@ -52,3 +97,15 @@ func (c *Client) getSomeInfoFromDb(in *pb.MathRequest) (mathResp *pb.MathRespons
 
 	return mathResp, err
 }
+
+// queryCode is the status code of a query that failed with err.  A query the caller gave up
+// on, or ran out of time for, is not an internal error.
+func queryCode(err error) codes.Code {
+	switch err {
+	case context.Canceled:
+		return codes.Canceled
+	case context.DeadlineExceeded:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
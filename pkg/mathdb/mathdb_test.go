@@ -1,162 +1,132 @@
 package mathdb
 
 import (
-	"os"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/jmoiron/sqlx/reflectx"
 	"github.com/mangeshhendre/grpcutils"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	_ "github.com/mattn/go-oci8"
-	"github.com/mgutz/logxi/v1"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-var globalADB *Client
-var logger log.Logger
-
-func TestMain(m *testing.M) {
-	//objMapperDsn := "acc_crd_conveyor/acc_crd_conveyor@cardsdb00.sgpd.us:1521/crdd01.sgpd.us"
-	// your func
-	logger = log.New("TestMain")
-	dsn := grpcutils.EnvOrDefault("DSN", "userid/password@db_fqdn:1521/service_name?prefetch_rows=5000") //use the connectionstring for database
+// clients returns the clients to test: the in-memory one always, and Oracle as well when
+// DSN names a database to use.
+func clients(t *testing.T) map[string]*Client {
+	clients := map[string]*Client{"memory": NewMemory(config.Default().Statsd)}
 
+	dsn := grpcutils.EnvOrDefault("DSN", "")
+	if dsn == "" {
+		return clients
+	}
 	DB, err := sqlx.Connect("oci8", dsn)
 	if err != nil {
-		logger.Fatal("Unable to establish mattn database connection: ", "Error", err)
+		t.Fatalf("Unable to establish mattn database connection: %s", err.Error())
 	}
 	DB = DB.Unsafe()
+	DB.Mapper = reflectx.NewMapperTagFunc("json", strings.ToUpper, func(value string) string {
+		if strings.Contains(value, ",") {
+			value = strings.Split(value, ",")[0]
+		}
+		return strings.ToUpper(strings.Replace(value, "_", "", -1))
+	})
+	t.Cleanup(func() { DB.Close() })
 
-	DB.Mapper = reflectx.NewMapperTagFunc("json", func(str string) string {
-		// strToReturn := strings.ToUpper(str)
-		// fmt.Println("String", str, "Returning", strToReturn)
-		return strings.ToUpper(str)
-	},
-		func(value string) string {
-			//fmt.Println("Value", value)
-			var valueToReturn string
-			if strings.Contains(value, ",") {
-				valueToReturn = strings.Split(value, ",")[0]
-			}
-			valueToReturn = strings.Replace(valueToReturn, "_", "", -1)
-			valueToReturn = strings.ToUpper(valueToReturn)
-			//fmt.Println("Value", value, "Returning", valueToReturn)
-			return valueToReturn
-		})
-
-	// Bypass the cache.
-	globalDB, err = New(DB)
-
-	retCode := m.Run()
-
-	defer DB.Close()
-
-	// your func
-	//teardown()
-
-	// call with result of m.Run()
-	os.Exit(retCode)
+	clients["oracle"], err = New(DB, config.Default().Statsd)
+	if err != nil {
+		t.Fatalf("Unable to create the oracle client: %s", err.Error())
+	}
+	return clients
 }
 
 var mathCases = []struct {
-	Case         string
-	PropertyID   int64
-	OrderNumber  int64
-	WantErr      bool
-	ObservedDate string
+	Case     string
+	Number1  float64
+	Number2  float64
+	Add      float64
+	Multiply float64
+	Divide   float64
 }{
-	{
-		Case:    "No Results",
-		Number1: 0,
-		Number2: 0,
-		WantErr: true,
-	},
-	{
-		Case:    "Good",
-		Number1: 15266709,
-		Number2: 600015141,
-		WantErr: false,
-	},
+	// The database does the arithmetic it is asked to, refusing operands is the handler's job.
+	{Case: "Zero Number1", Number1: 0, Number2: 4, Add: 4, Multiply: 0, Divide: 0},
+	{Case: "Zero Number2", Number1: 3, Number2: 0, Add: 3, Multiply: 0, Divide: math.Inf(1)},
+	{Case: "Good", Number1: 15266709, Number2: 600015141, Add: 615281850, Multiply: 15266709.0 * 600015141, Divide: 15266709.0 / 600015141},
+	{Case: "Negative", Number1: -6, Number2: 4, Add: -2, Multiply: -24, Divide: -1.5},
 }
 
-//func TestClient_GetWorkOrderDate(t *testing.T) {
-//	foo, err := globalADB.getWorkOrderDate((600015141))
-//	if err != nil {
-//		t.Error("Unable to get work order date", err)
-//	}
-//	t.Logf("Sec: %d, nsec: %d , loc: %#v", foo.Second(), foo.Nanosecond(), foo.Location())
-//}
-
-func TestClient_AddNumber(t *testing.T) {
-	for n, c := range mathCases {
-		if c.Number1 == 0 || c.Number2 == 0 {
-			continue
-		}
-		request := &pb.MathRequest{
-			Number1: c.PropertyID,
-			Number2: c.OrderNumber,
+func TestClient_Math(t *testing.T) {
+	for name, client := range clients(t) {
+		calls := []struct {
+			Name string
+			Call func(context.Context, *pb.MathRequest) (*pb.MathResponse, error)
+			Want func(n int) float64
+		}{
+			{Name: "AddNumber", Call: client.AddNumber, Want: func(n int) float64 { return mathCases[n].Add }},
+			{Name: "MultiplyNumber", Call: client.MultiplyNumber, Want: func(n int) float64 { return mathCases[n].Multiply }},
+			{Name: "DevideNumber", Call: client.DevideNumber, Want: func(n int) float64 { return mathCases[n].Divide }},
 		}
-		_, err := globalDB.AddNumber(context.TODO(), request)
-		if err != nil {
-			if !c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Unexpected Error: %s", n, c.Case, err.Error())
-			}
-			continue
-		} else {
-			if c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Expected error, none reported.", n, c.Case)
-				continue
+		for _, call := range calls {
+			for n, c := range mathCases {
+				response, err := call.Call(context.TODO(), &pb.MathRequest{Number1: c.Number1, Number2: c.Number2})
+				if err != nil {
+					t.Errorf("Case: %d: %s: %s %s: Unexpected Error: %s", n, c.Case, name, call.Name, err.Error())
+					continue
+				}
+				if want := call.Want(n); response.Result != want {
+					t.Errorf("Case: %d: %s: %s %s: Got %g, want %g", n, c.Case, name, call.Name, response.Result, want)
+				}
 			}
 		}
 	}
 }
 
-func TestClient_MultiplyNumber(t *testing.T) {
-	for n, c := range mathCases {
-		if c.Number1 == 0 || c.Number2 == 0 {
-			continue
-		}
-		request := &pb.MathRequest{
-			Number1: c.PropertyID,
-			Number2: c.OrderNumber,
-		}
-		_, err := globalDB.AddNumber(context.TODO(), request)
-		if err != nil {
-			if !c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Unexpected Error: %s", n, c.Case, err.Error())
-			}
-			continue
-		} else {
-			if c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Expected error, none reported.", n, c.Case)
-				continue
-			}
-		}
-	}
+// unreachable is a database/sql driver that cannot connect.
+type unreachable struct{}
+
+func (unreachable) Open(string) (driver.Conn, error) {
+	return nil, errors.New("unreachable")
 }
 
-func TestClient_DevideNumber(t *testing.T) {
-	for n, c := range mathCases {
-		if c.Number1 == 0 || c.Number2 == 0 {
-			continue
-		}
-		request := &pb.MathRequest{
-			Number1: c.PropertyID,
-			Number2: c.OrderNumber,
-		}
-		_, err := globalDB.AddNumber(context.TODO(), request)
-		if err != nil {
-			if !c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Unexpected Error: %s", n, c.Case, err.Error())
-			}
-			continue
-		} else {
-			if c.WantErr {
-				t.Errorf("Case: %d: %s: Unable to get work order date. Expected error, none reported.", n, c.Case)
-				continue
-			}
+func init() {
+	sql.Register("mathdb-unreachable", unreachable{})
+}
+
+func TestSQLStore_Errors(t *testing.T) {
+	DB, err := sql.Open("mathdb-unreachable", "")
+	if err != nil {
+		t.Fatalf("Unable to open the database: %s", err.Error())
+	}
+	defer DB.Close()
+	store := &sqlStore{DB: sqlx.NewDb(DB, "oci8")}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	var cases = []struct {
+		Case     string
+		Ctx      context.Context
+		WantCode codes.Code
+	}{
+		{Case: "Canceled", Ctx: canceled, WantCode: codes.Canceled},
+		{Case: "Deadline", Ctx: expired, WantCode: codes.DeadlineExceeded},
+		{Case: "Unreachable", Ctx: context.Background(), WantCode: codes.Internal},
+	}
+	for n, c := range cases {
+		_, err := store.getSomeInfo(c.Ctx, &pb.MathRequest{Number1: 1, Number2: 2})
+		if got := status.Code(err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, err)
 		}
 	}
 }
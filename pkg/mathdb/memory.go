@@ -0,0 +1,23 @@
+package mathdb
+
+import (
+	"context"
+
+	pb "github.com/mangeshhendre/models/services_math_v1"
+)
+
+// memoryStore stands in for the Oracle table in development, every pair of numbers has the
+// same row.  Nothing is stored, so it does not grow however many pairs it is asked about.
+type memoryStore struct{}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) system() string {
+	return "memory"
+}
+
+func (s *memoryStore) getSomeInfo(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: 1}, nil
+}
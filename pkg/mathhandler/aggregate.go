@@ -0,0 +1,87 @@
+package mathhandler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/aggregate"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	aggregatepb "github.com/mangeshhendre/mathsvc/proto/services_aggregate_v1"
+	"golang.org/x/net/context"
+)
+
+// aggregateStream is the server side of any MathAggregate stream.
+type aggregateStream interface {
+	Recv() (*aggregatepb.AggregateNumber, error)
+	SendAndClose(*aggregatepb.AggregateResponse) error
+	Context() context.Context
+}
+
+// Sum adds the streamed numbers with compensated summation.
+func (s *Server) Sum(stream aggregatepb.MathAggregate_SumServer) error {
+	return s.aggregate("Sum", stream, &aggregate.Sum{}, false)
+}
+
+// Product multiplies the streamed numbers.
+func (s *Server) Product(stream aggregatepb.MathAggregate_ProductServer) error {
+	return s.aggregate("Product", stream, &aggregate.Product{}, false)
+}
+
+// Min is the smallest of the streamed numbers.
+func (s *Server) Min(stream aggregatepb.MathAggregate_MinServer) error {
+	return s.aggregate("Min", stream, &aggregate.Min{}, true)
+}
+
+// Max is the largest of the streamed numbers.
+func (s *Server) Max(stream aggregatepb.MathAggregate_MaxServer) error {
+	return s.aggregate("Max", stream, &aggregate.Max{}, true)
+}
+
+// Mean is the mean of the streamed numbers.
+func (s *Server) Mean(stream aggregatepb.MathAggregate_MeanServer) error {
+	return s.aggregate("Mean", stream, &aggregate.Mean{}, true)
+}
+
+// aggregate folds every number of stream into fold and answers once the client closes it.
+// Each number is checked against the validation policy as it arrives, the result when the
+// stream ends.  needsOne refuses an empty stream, which has no min, max or mean.  The
+// stream takes a single rate limit token.
+func (s *Server) aggregate(method string, stream aggregateStream, fold aggregate.Fold, needsOne bool) (err error) {
+	defer s.tracer.Statsd(method, time.Now())
+	_, req := start(stream.Context(), "MathAggregate", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return err
+	}
+
+	policy := s.live.Load().Validation
+	response := &aggregatepb.AggregateResponse{}
+	for {
+		number, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if validate.Refused(policy, number.Value) {
+			return validate.Violation(fmt.Sprintf("value[%d]", response.Count), fmt.Sprintf("%v is not allowed", number.Value))
+		}
+		response.HasNan = response.HasNan || math.IsNaN(number.Value)
+		response.HasInfinity = response.HasInfinity || math.IsInf(number.Value, 0)
+		fold.Add(number.Value)
+		response.Count++
+	}
+	req.span.SetAttribute("aggregate.count", response.Count)
+
+	if needsOne && response.Count == 0 {
+		return validate.Violation("value", method+" needs at least one number")
+	}
+	response.Result = fold.Result()
+	if validate.Refused(policy, response.Result) {
+		return validate.Violation("value", fmt.Sprintf("the %s, %v, is not allowed", method, response.Result))
+	}
+	return stream.SendAndClose(response)
+}
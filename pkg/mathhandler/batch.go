@@ -0,0 +1,82 @@
+package mathhandler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	batchpb "github.com/mangeshhendre/mathsvc/proto/services_batch_v1"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// operations are the Math methods each batch operation is answered by.
+var operations = map[batchpb.Operation]string{
+	batchpb.Operation_OPERATION_ADD:      "AddNumber",
+	batchpb.Operation_OPERATION_MULTIPLY: "MultiplyNumber",
+	batchpb.Operation_OPERATION_DIVIDE:   "DevideNumber",
+}
+
+// batcher answers many requests at once, as mathcache.MathCache does.
+type batcher interface {
+	Batch(ctx context.Context, items []mathcache.Item, concurrency int) []mathcache.Result
+}
+
+// Batch evaluates every item the way its Math method would, the cache lookups for all of
+// them together.  The whole batch takes a single rate limit token.
+func (s *Server) Batch(ctx context.Context, in *batchpb.BatchRequest) (response *batchpb.BatchResponse, err error) {
+	defer s.tracer.Statsd("Batch", time.Now())
+	ctx, req := startBatch(ctx, in)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	if s.batcher == nil {
+		return nil, status.Errorf(codes.Unimplemented, "The cache tier cannot answer batches")
+	}
+	settings := s.live.Load()
+	if len(in.Items) > settings.Batch.MaxItems {
+		return nil, validate.Violation("items", fmt.Sprintf("%d items is more than the %d a batch may have", len(in.Items), settings.Batch.MaxItems))
+	}
+
+	errs := make([]error, len(in.Items))
+	var items []mathcache.Item
+	var at []int
+	for n, item := range in.Items {
+		method, ok := operations[item.Operation]
+		if !ok {
+			errs[n] = validate.Violation("operation", fmt.Sprintf("%s is not an operation", item.Operation))
+			continue
+		}
+		request := &pb.MathRequest{Number1: item.Number1, Number2: item.Number2}
+		if errs[n] = validate.Request(settings.Validation, method, request); errs[n] != nil {
+			continue
+		}
+		items = append(items, mathcache.Item{Method: method, In: request})
+		at = append(at, n)
+	}
+
+	response = &batchpb.BatchResponse{Results: make([]*batchpb.BatchResult, len(in.Items))}
+	for n, err := range errs {
+		if err != nil {
+			response.Results[n] = &batchpb.BatchResult{Error: status.Convert(err).Proto()}
+		}
+	}
+	for i, result := range s.batcher.Batch(ctx, items, settings.Batch.Concurrency) {
+		err := result.Err
+		if err == nil {
+			err = validate.Result(settings.Validation, result.Response)
+		}
+		answer := &batchpb.BatchResult{Cache: result.Cache}
+		if err != nil {
+			answer.Error = status.Convert(err).Proto()
+		} else {
+			answer.Result = result.Response.Result
+		}
+		response.Results[at[i]] = answer
+	}
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package mathhandler
+
+import (
+	"fmt"
+	"math/cmplx"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	complexpb "github.com/mangeshhendre/mathsvc/proto/services_complex_v1"
+	"golang.org/x/net/context"
+)
+
+// complexServer is the MathComplex service, complex arithmetic in float64 parts.
+type complexServer struct {
+	*Server
+}
+
+// Add is number1 + number2.
+func (s complexServer) Add(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Add", in, func(a, b complex128) complex128 { return a + b })
+}
+
+// Subtract is number1 - number2.
+func (s complexServer) Subtract(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Subtract", in, func(a, b complex128) complex128 { return a - b })
+}
+
+// Multiply is number1 × number2.
+func (s complexServer) Multiply(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Multiply", in, func(a, b complex128) complex128 { return a * b })
+}
+
+// Divide is number1 / number2.
+func (s complexServer) Divide(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Divide", in, func(a, b complex128) complex128 { return a / b })
+}
+
+// Pow is number1 raised to number2.
+func (s complexServer) Pow(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Pow", in, cmplx.Pow)
+}
+
+// Abs is |number1|.
+func (s complexServer) Abs(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Abs", in, func(a, _ complex128) complex128 { return complex(cmplx.Abs(a), 0) })
+}
+
+// Phase is the argument of number1.
+func (s complexServer) Phase(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Phase", in, func(a, _ complex128) complex128 { return complex(cmplx.Phase(a), 0) })
+}
+
+// Conjugate is number1 with its imaginary part negated.
+func (s complexServer) Conjugate(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Conjugate", in, func(a, _ complex128) complex128 { return cmplx.Conj(a) })
+}
+
+// Exp is e raised to number1.
+func (s complexServer) Exp(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Exp", in, func(a, _ complex128) complex128 { return cmplx.Exp(a) })
+}
+
+// Log is the natural logarithm of number1.
+func (s complexServer) Log(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Log", in, func(a, _ complex128) complex128 { return cmplx.Log(a) })
+}
+
+// Sqrt is the square root of number1.
+func (s complexServer) Sqrt(ctx context.Context, in *complexpb.ComplexRequest) (*complexpb.ComplexResponse, error) {
+	return s.compute(ctx, "Sqrt", in, func(a, _ complex128) complex128 { return cmplx.Sqrt(a) })
+}
+
+// complexBinary are the MathComplex methods that take number2 as well as number1.
+var complexBinary = map[string]bool{"Add": true, "Subtract": true, "Multiply": true, "Divide": true, "Pow": true}
+
+// compute checks the operands the method takes against the policy, applies op to them and
+// checks the result.
+func (s complexServer) compute(ctx context.Context, method string, in *complexpb.ComplexRequest, op func(a, b complex128) complex128) (response *complexpb.ComplexResponse, err error) {
+	defer s.tracer.Statsd("Complex"+method, time.Now())
+	_, req := start(ctx, "MathComplex", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	policy := s.live.Load().Validation
+
+	a := complex(in.GetNumber1().GetReal(), in.GetNumber1().GetImaginary())
+	b := complex(in.GetNumber2().GetReal(), in.GetNumber2().GetImaginary())
+	if refusedComplex(policy, a) {
+		return nil, validate.Violation("number1", fmt.Sprintf("%v is not allowed", a))
+	}
+	if complexBinary[method] && refusedComplex(policy, b) {
+		return nil, validate.Violation("number2", fmt.Sprintf("%v is not allowed", b))
+	}
+	if method == "Divide" && b == 0 {
+		return nil, validate.Violation("number2", "cannot divide by zero")
+	}
+
+	result := op(a, b)
+	if refusedComplex(policy, result) {
+		description := fmt.Sprintf("the result %v is not allowed", result)
+		if complexBinary[method] {
+			return nil, validate.Violations(description, "number1", "number2")
+		}
+		return nil, validate.Violation("number1", description)
+	}
+	return &complexpb.ComplexResponse{
+		Result: &complexpb.Complex{Real: real(result), Imaginary: imag(result)},
+		Polar:  &complexpb.Polar{Magnitude: cmplx.Abs(result), Phase: cmplx.Phase(result)},
+	}, nil
+}
+
+// refusedComplex reports whether policy refuses either part of z.
+func refusedComplex(policy config.Validation, z complex128) bool {
+	return validate.Refused(policy, real(z)) || validate.Refused(policy, imag(z))
+}
@@ -0,0 +1,100 @@
+package mathhandler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/expr"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	exprpb "github.com/mangeshhendre/mathsvc/proto/services_expression_v1"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+)
+
+// evaluator caches expression results, as mathcache.MathCache does.
+type evaluator interface {
+	Evaluate(ctx context.Context, normalized, bindings string, policy config.Validation, evaluate func(context.Context) (*pb.MathResponse, error)) (*pb.MathResponse, error)
+}
+
+// Evaluate parses and computes an expression.  Results are cached by the expression's
+// normal form and the bindings of the variables it uses, so "(1+x)" and "1 + x" share one.
+func (s *Server) Evaluate(ctx context.Context, in *exprpb.EvaluateRequest) (response *exprpb.EvaluateResponse, err error) {
+	defer s.tracer.Statsd("Evaluate", time.Now())
+	ctx, req := start(ctx, "MathExpression", "Evaluate")
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	settings := s.live.Load()
+	policy := settings.Validation
+
+	e, err := expr.Parse(in.Expression, settings.Expression)
+	if err != nil {
+		return nil, validate.Violation("expression", err.Error())
+	}
+	if err := checkBindings(policy, in.Bindings); err != nil {
+		return nil, err
+	}
+	normalized := e.String()
+	req.span.SetAttribute("expression.normalized", normalized)
+
+	evaluate := func(context.Context) (*pb.MathResponse, error) {
+		result, err := e.Evaluate(in.Bindings, policy)
+		if err != nil {
+			return nil, validate.Violation("expression", err.Error())
+		}
+		return &pb.MathResponse{Result: result}, nil
+	}
+	var result *pb.MathResponse
+	if s.evaluator != nil {
+		result, err = s.evaluator.Evaluate(ctx, normalized, bindings(e, in.Bindings), policy, evaluate)
+	} else {
+		result, err = evaluate(ctx)
+	}
+	if err != nil {
+		s.logger.Debug("Unable to evaluate", "Expression", in.Expression, "Error", err)
+		return nil, err
+	}
+	return &exprpb.EvaluateResponse{Result: result.Result, Normalized: normalized}, nil
+}
+
+// checkBindings refuses a binding that names no variable, or whose value the policy does
+// not allow, whether or not the expression uses it.
+func checkBindings(policy config.Validation, values map[string]float64) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := fmt.Sprintf("bindings[%s]", name)
+		if !expr.IsVariable(name) {
+			return validate.Violation(field, "is not a variable name, or is a constant or function")
+		}
+		if validate.Refused(policy, values[name]) {
+			return validate.Violation(field, fmt.Sprintf("%v is not allowed", values[name]))
+		}
+	}
+	return nil
+}
+
+// bindings are the values of the variables e uses, in order, as part of a cache key.
+func bindings(e *expr.Expression, values map[string]float64) string {
+	var b strings.Builder
+	for n, name := range e.Variables() {
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		value, ok := values[name]
+		if !ok {
+			// Unbound, Evaluate will refuse it, so this entry is never set.
+			continue
+		}
+		b.WriteString(name + "=" + strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package mathhandler
+
+import (
+	"golang.org/x/net/context"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	"github.com/mangeshhendre/mathsvc/pkg/tracing"
+	batchpb "github.com/mangeshhendre/mathsvc/proto/services_batch_v1"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	requestsTotal = metrics.DefaultRegistry.NewCounterVec(
+		"mathsvc_grpc_requests_total",
+		"Number of Math RPCs handled, by method and status code.",
+		"method", "code")
+	requestDuration = metrics.DefaultRegistry.NewHistogramVec(
+		"mathsvc_grpc_request_duration_seconds",
+		"Latency of Math RPCs, by method and status code.",
+		nil, "method", "code")
+	requestsInFlight = metrics.DefaultRegistry.NewGaugeVec(
+		"mathsvc_grpc_requests_in_flight",
+		"Number of Math RPCs currently being handled.",
+		"method")
+)
+
+// request is the in flight state for a single rpc.
+type request struct {
+	method string
+	start  time.Time
+	span   *tracing.Span
+}
+
+// startRequest marks an rpc as in flight and opens its server span, it is meant to be handed to observe.
+func startRequest(ctx context.Context, method string, in *pb.MathRequest) (context.Context, request) {
+	ctx, r := start(ctx, "Math", method)
+	r.span.SetAttribute("math.number1", in.Number1)
+	r.span.SetAttribute("math.number2", in.Number2)
+	return ctx, r
+}
+
+// startBatch is startRequest for the Batch rpc.
+func startBatch(ctx context.Context, in *batchpb.BatchRequest) (context.Context, request) {
+	ctx, r := start(ctx, "MathBatch", "Batch")
+	r.span.SetAttribute("batch.items", len(in.Items))
+	return ctx, r
+}
+
+func start(ctx context.Context, service, method string) (context.Context, request) {
+	requestsInFlight.WithLabelValues(method).Inc()
+
+	ctx, span := tracing.Start(tracing.FromIncomingContext(ctx), service+"/"+method, tracing.KindServer)
+	span.SetAttribute("rpc.system", "grpc")
+	span.SetAttribute("rpc.service", "services.luggage.v1."+service)
+	span.SetAttribute("rpc.method", method)
+
+	return ctx, request{method: method, start: time.Now(), span: span}
+}
+
+// observe records the outcome of an rpc, it is meant to be deferred with a pointer to the named error.
+func observe(r request, err *error) {
+	code := status.Code(*err).String()
+	requestsInFlight.WithLabelValues(r.method).Dec()
+	requestsTotal.WithLabelValues(r.method, code).Inc()
+	requestDuration.WithLabelValues(r.method, code).Observe(metrics.Since(r.start))
+
+	r.span.SetError(*err)
+	r.span.Finish()
+}
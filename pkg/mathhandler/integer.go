@@ -0,0 +1,81 @@
+package mathhandler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/integer"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	integerpb "github.com/mangeshhendre/mathsvc/proto/services_integer_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// integerServer is the MathInteger service, int64 arithmetic that says when it overflows.
+type integerServer struct {
+	*Server
+}
+
+// Add is number1 + number2.
+func (s integerServer) Add(ctx context.Context, in *integerpb.IntegerRequest) (*integerpb.IntegerResponse, error) {
+	return s.integer(ctx, "Add", in, func() (int64, int64, int) {
+		sum, overflow := integer.Add(in.Number1, in.Number2)
+		return sum, 0, overflow
+	})
+}
+
+// Subtract is number1 - number2.
+func (s integerServer) Subtract(ctx context.Context, in *integerpb.IntegerRequest) (*integerpb.IntegerResponse, error) {
+	return s.integer(ctx, "Subtract", in, func() (int64, int64, int) {
+		difference, overflow := integer.Sub(in.Number1, in.Number2)
+		return difference, 0, overflow
+	})
+}
+
+// Multiply is number1 × number2.
+func (s integerServer) Multiply(ctx context.Context, in *integerpb.IntegerRequest) (*integerpb.IntegerResponse, error) {
+	return s.integer(ctx, "Multiply", in, func() (int64, int64, int) {
+		product, overflow := integer.Mul(in.Number1, in.Number2)
+		return product, 0, overflow
+	})
+}
+
+// Divide is number1 / number2 and the remainder, truncated or floored as asked.
+func (s integerServer) Divide(ctx context.Context, in *integerpb.IntegerRequest) (*integerpb.IntegerResponse, error) {
+	return s.integer(ctx, "Divide", in, func() (int64, int64, int) {
+		return integer.Quo(in.Number1, in.Number2, in.Division == integerpb.Division_DIVISION_FLOOR)
+	})
+}
+
+// integer checks the modes, runs op and handles an overflow as in.Overflow says.
+func (s integerServer) integer(ctx context.Context, method string, in *integerpb.IntegerRequest, op func() (result, remainder int64, overflow int)) (response *integerpb.IntegerResponse, err error) {
+	defer s.tracer.Statsd("Integer"+method, time.Now())
+	_, req := start(ctx, "MathInteger", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	if _, ok := integerpb.Overflow_name[int32(in.Overflow)]; !ok {
+		return nil, validate.Violation("overflow", fmt.Sprintf("%d is not an overflow mode", in.Overflow))
+	}
+	if _, ok := integerpb.Division_name[int32(in.Division)]; !ok {
+		return nil, validate.Violation("division", fmt.Sprintf("%d is not a division mode", in.Division))
+	}
+	if method == "Divide" && in.Number2 == 0 {
+		return nil, validate.Violation("number2", "cannot be zero")
+	}
+
+	result, remainder, overflow := op()
+	if overflow == 0 {
+		return &integerpb.IntegerResponse{Result: result, Remainder: remainder}, nil
+	}
+	switch in.Overflow {
+	case integerpb.Overflow_OVERFLOW_SATURATING:
+		result = integer.Saturate(overflow)
+	case integerpb.Overflow_OVERFLOW_WRAPPING:
+	default:
+		return nil, status.Errorf(codes.OutOfRange, "%s of %d and %d does not fit in an int64", method, in.Number1, in.Number2)
+	}
+	return &integerpb.IntegerResponse{Result: result, Remainder: remainder, Overflowed: true}, nil
+}
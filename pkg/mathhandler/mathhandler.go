@@ -1,21 +1,35 @@
 package mathhandler
 
 import (
+	"io"
 	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/fault"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
 	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
 	"github.com/mangeshhendre/mathsvc/pkg/mathdb"
+	"github.com/mangeshhendre/mathsvc/pkg/session"
+	"github.com/mangeshhendre/mathsvc/pkg/tracing"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	aggregatepb "github.com/mangeshhendre/mathsvc/proto/services_aggregate_v1"
+	batchpb "github.com/mangeshhendre/mathsvc/proto/services_batch_v1"
+	complexpb "github.com/mangeshhendre/mathsvc/proto/services_complex_v1"
+	exprpb "github.com/mangeshhendre/mathsvc/proto/services_expression_v1"
+	integerpb "github.com/mangeshhendre/mathsvc/proto/services_integer_v1"
+	moneypb "github.com/mangeshhendre/mathsvc/proto/services_money_v1"
+	precisepb "github.com/mangeshhendre/mathsvc/proto/services_precise_v1"
+	rationalpb "github.com/mangeshhendre/mathsvc/proto/services_rational_v1"
+	sessionpb "github.com/mangeshhendre/mathsvc/proto/services_session_v1"
 	pb "github.com/mangeshhendre/models/services_math_v1"
 	"github.com/mangeshhendre/tracer"
 	_ "github.com/mattn/go-oci8"
 	"github.com/mgutz/logxi/v1"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // Server is the local server handler.
@@ -24,17 +38,26 @@ type Server struct {
 	LibraryDebug  bool
 	DB            *sqlx.DB
 	cacheInstance pb.MathServer
+	batcher       batcher
+	evaluator     evaluator
+	lookasider    lookasider
 	dbInstance    pb.MathServer
 	tracer        *tracer.Tracer
+	limiter       *rateLimiter
+	live          *config.Live
+	session       config.Session
+	history       *session.History
 	logger        log.Logger
 }
 
-// New creates a new server handler instance.
-func New(dsn string) (*Server, error) {
+// New creates a new server handler instance, settings that can be reloaded are read from live.
+// faults, when not nil, can make the database and cache misbehave, it is for tests and
+// non-production builds.
+func New(c *config.Config, live *config.Live, faults *fault.Injector) (*Server, error) {
 	// Need a logger.
-	logger := log.New("mathsvc.Handler")
+	logger := logging.New("mathsvc.Handler")
 	//Create database things here.
-	DB, err := sqlx.Connect("oci8", dsn)
+	DB, err := sqlx.Connect("oci8", c.DSN)
 	if err != nil {
 		return nil, logger.Error("Unable to establish mattn database connection: ", "Error", err)
 	}
@@ -57,54 +80,164 @@ func New(dsn string) (*Server, error) {
 			return valueToReturn
 		})
 
-	dbInstance, err := mathdb.New(DB)
+	dbInstance, err := mathdb.New(DB, c.Statsd)
 	if err != nil {
 		return nil, err
 	}
 
-	cacheInstance, err := mathcache.New(dbInstance)
+	cacheInstance, err := newCache(dbInstance, mathcache.NewStore(c.Memcache), live, faults)
 	if err != nil {
 		return nil, err
 	}
 
+	server, err := newServer(c, live, dbInstance, cacheInstance)
+	if err != nil {
+		return nil, err
+	}
+	server.DB = DB
+	return server, nil
+}
+
+// NewInMemory creates a server handler instance whose database and cache live in memory, for development and tests.
+func NewInMemory(c *config.Config, live *config.Live, faults *fault.Injector) (*Server, error) {
+	dbInstance := mathdb.NewMemory(c.Statsd)
+
+	cacheInstance, err := newCache(dbInstance, mathcache.NewMemoryStore(), live, faults)
+	if err != nil {
+		return nil, err
+	}
+
+	return newServer(c, live, dbInstance, cacheInstance)
+}
+
+// newCache puts the cache in front of the database, with faults around both when asked.
+func newCache(dbInstance pb.MathServer, store mathcache.Store, live *config.Live, faults *fault.Injector) (pb.MathServer, error) {
+	if faults != nil {
+		store = fault.NewStore(store, faults)
+	}
+	return mathcache.NewWithStore(withFaults(dbInstance, faults), store, live)
+}
+
+// withFaults wraps the database with faults, if there are any to inject.
+func withFaults(dbInstance pb.MathServer, faults *fault.Injector) pb.MathServer {
+	if faults == nil {
+		return dbInstance
+	}
+	return fault.NewServer(dbInstance, faults)
+}
+
+func newServer(c *config.Config, live *config.Live, dbInstance, cacheInstance pb.MathServer) (*Server, error) {
+	history, err := session.NewHistory(c.Session)
+	if err != nil {
+		return nil, err
+	}
+	batcher, _ := cacheInstance.(batcher)
+	evaluator, _ := cacheInstance.(evaluator)
+	lookasider, _ := cacheInstance.(lookasider)
 	return &Server{
-		DB:            DB,
 		cacheInstance: cacheInstance,
+		batcher:       batcher,
+		evaluator:     evaluator,
+		lookasider:    lookasider,
 		dbInstance:    dbInstance,
-		tracer:        tracer.New("graphite:8125", "grpc.mathsvc", 1),
-		logger:        logger,
+		tracer:        tracer.New(c.Statsd.Address, c.Statsd.Prefix, c.Statsd.Sample),
+		limiter:       newRateLimiter(live),
+		live:          live,
+		session:       c.Session,
+		history:       history,
+		logger:        logging.New("mathsvc.Handler"),
 	}, nil
 }
 
-// Close will shut it all down.
-func (s *Server) Close() {
-	s.DB.Close()
+// Close will shut it all down, in dependency order: the session history, the cache, then
+// the tracers so the last spans are flushed, then the database.
+func (s *Server) Close() error {
+	if s.history != nil {
+		if err := s.history.Close(); err != nil {
+			s.logger.Warn("Unable to close session history", "Error", err)
+		}
+	}
+	if closer, ok := s.cacheInstance.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.Warn("Unable to close cache", "Error", err)
+		}
+	}
+	if err := tracing.DefaultTracer.Close(); err != nil {
+		s.logger.Warn("Unable to close tracer", "Error", err)
+	}
+	if closer, ok := s.dbInstance.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 //AddNumber retrieves the lite math for the specified propertyID
-func (s *Server) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+func (s *Server) AddNumber(ctx context.Context, in *pb.MathRequest) (response *pb.MathResponse, err error) {
 	defer s.tracer.Statsd("AddNumber", time.Now())
-	return s.cacheInstance.AddNumber(ctx, in)
+	ctx, req := startRequest(ctx, "AddNumber", in)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	return s.validated(ctx, "AddNumber", in, s.cacheInstance.AddNumber)
 }
 
 //MultiplyNumber retrieves the lite math for the specified propertyID
-func (s *Server) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+func (s *Server) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (response *pb.MathResponse, err error) {
 	defer s.tracer.Statsd("MultiplyNumber", time.Now())
-	return s.cacheInstance.MultiplyNumber(ctx, in)
+	ctx, req := startRequest(ctx, "MultiplyNumber", in)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	return s.validated(ctx, "MultiplyNumber", in, s.cacheInstance.MultiplyNumber)
 }
 
 //DevideNumber retrieves math for the specified propertyID
-func (s *Server) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+func (s *Server) DevideNumber(ctx context.Context, in *pb.MathRequest) (response *pb.MathResponse, err error) {
 	defer s.tracer.Statsd("DevideNumber", time.Now())
-	if in.Number2 == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "Invalid Request: Number2: %f", in.Number2)
+	ctx, req := startRequest(ctx, "DevideNumber", in)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	return s.validated(ctx, "DevideNumber", in, s.cacheInstance.DevideNumber)
+}
+
+// validated checks in against the validation policy, calls call with it and checks the
+// result too.  This is the only place operands and results are validated, the cache and
+// database do the arithmetic they are asked to.
+func (s *Server) validated(ctx context.Context, method string, in *pb.MathRequest, call func(context.Context, *pb.MathRequest) (*pb.MathResponse, error)) (*pb.MathResponse, error) {
+	policy := s.live.Load().Validation
+	if err := validate.Request(policy, method, in); err != nil {
+		s.logger.Debug("Invalid request", "Method", method, "Error", err)
+		return nil, err
+	}
+	response, err := call(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate.Result(policy, response); err != nil {
+		s.logger.Debug("Invalid result", "Method", method, "Error", err)
+		return nil, err
 	}
-	return s.cacheInstance.DevideNumber(ctx, in)
+	return response, nil
 }
 
 // RegisterServices wraps setup of services in the handler library.
 func (s *Server) RegisterServices(shim *grpc.Server) {
 	defer s.tracer.Statsd("RegisterServices", time.Now())
 	pb.RegisterMathServer(shim, s)
+	batchpb.RegisterMathBatchServer(shim, s)
+	aggregatepb.RegisterMathAggregateServer(shim, s)
+	sessionpb.RegisterMathSessionServer(shim, s)
+	exprpb.RegisterMathExpressionServer(shim, s)
+	// These services all have an Add, a Multiply and so on, so each is registered as a type
+	// of its own around s, which can only have one method of a name.
+	precisepb.RegisterMathPreciseServer(shim, preciseServer{s})
+	moneypb.RegisterMathMoneyServer(shim, moneyServer{s})
+	integerpb.RegisterMathIntegerServer(shim, integerServer{s})
+	rationalpb.RegisterMathRationalServer(shim, rationalServer{s})
+	complexpb.RegisterMathComplexServer(shim, complexServer{s})
 
 }
@@ -0,0 +1,130 @@
+package mathhandler
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/mangeshhendre/mathsvc/pkg/decimal"
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	moneypb "github.com/mangeshhendre/mathsvc/proto/services_money_v1"
+	"golang.org/x/net/context"
+)
+
+// lookasider caches any response, as mathcache.MathCache does.
+type lookasider interface {
+	Lookaside(ctx context.Context, key mathcache.Key, response proto.Message, call func(context.Context) error) error
+}
+
+// roundings are the decimal rounding modes behind the protocol's.
+var roundings = map[moneypb.Rounding]decimal.Rounding{
+	moneypb.Rounding_ROUNDING_HALF_EVEN: decimal.HalfEven,
+	moneypb.Rounding_ROUNDING_HALF_UP:   decimal.HalfUp,
+	moneypb.Rounding_ROUNDING_DOWN:      decimal.Down,
+	moneypb.Rounding_ROUNDING_CEILING:   decimal.Ceiling,
+	moneypb.Rounding_ROUNDING_FLOOR:     decimal.Floor,
+}
+
+// currencyCode matches an ISO 4217 alphabetic code.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// moneyOp computes a result with scale places.
+type moneyOp func(a, b decimal.Decimal, scale int, mode decimal.Rounding) (decimal.Decimal, bool)
+
+// moneyServer is the MathMoney service, decimal amounts of a currency at a fixed scale.
+type moneyServer struct {
+	*Server
+}
+
+// Add is number1 + number2, in the same currency.
+func (s moneyServer) Add(ctx context.Context, in *moneypb.MoneyRequest) (*moneypb.MoneyResponse, error) {
+	return s.money(ctx, "Add", in, func(a, b decimal.Decimal, scale int, mode decimal.Rounding) (decimal.Decimal, bool) {
+		return decimal.Add(a, b).Rescale(scale, mode)
+	})
+}
+
+// Subtract is number1 - number2, in the same currency.
+func (s moneyServer) Subtract(ctx context.Context, in *moneypb.MoneyRequest) (*moneypb.MoneyResponse, error) {
+	return s.money(ctx, "Subtract", in, func(a, b decimal.Decimal, scale int, mode decimal.Rounding) (decimal.Decimal, bool) {
+		return decimal.Sub(a, b).Rescale(scale, mode)
+	})
+}
+
+// Multiply is number1 times the quantity number2.
+func (s moneyServer) Multiply(ctx context.Context, in *moneypb.MoneyRequest) (*moneypb.MoneyResponse, error) {
+	return s.money(ctx, "Multiply", in, func(a, b decimal.Decimal, scale int, mode decimal.Rounding) (decimal.Decimal, bool) {
+		return decimal.Mul(a, b).Rescale(scale, mode)
+	})
+}
+
+// Divide is number1 divided by the quantity number2.
+func (s moneyServer) Divide(ctx context.Context, in *moneypb.MoneyRequest) (*moneypb.MoneyResponse, error) {
+	return s.money(ctx, "Divide", in, decimal.QuoScale)
+}
+
+// money checks the request, currencies included, and answers it from the cache or op.
+// The cache key has the scale and rounding mode in it beside the operands.
+func (s moneyServer) money(ctx context.Context, method string, in *moneypb.MoneyRequest, op moneyOp) (response *moneypb.MoneyResponse, err error) {
+	defer s.tracer.Statsd("Money"+method, time.Now())
+	ctx, req := start(ctx, "MathMoney", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	settings := s.live.Load()
+
+	if int(in.Scale) > settings.Money.MaxScale {
+		return nil, validate.Violation("scale", fmt.Sprintf("is more than %d places", settings.Money.MaxScale))
+	}
+	mode, ok := roundings[in.Rounding]
+	if !ok {
+		return nil, validate.Violation("rounding", fmt.Sprintf("%d is not a rounding mode", in.Rounding))
+	}
+	currency, other := in.GetNumber1().GetCurrency(), in.GetNumber2().GetCurrency()
+	if !currencyCode.MatchString(currency) {
+		return nil, validate.Violation("number1.currency", fmt.Sprintf("%q is not an ISO 4217 code such as USD", currency))
+	}
+	switch method {
+	case "Add", "Subtract":
+		if other != currency {
+			return nil, validate.Violation("number2.currency", fmt.Sprintf("is %q, it cannot be mixed with %s", other, currency))
+		}
+	default:
+		if other != "" {
+			return nil, validate.Violation("number2.currency", fmt.Sprintf("must be empty, number2 is a quantity, not an amount of %s", other))
+		}
+	}
+	a, err := operand("number1.amount", in.GetNumber1().GetAmount(), settings.Precise.MaxLength, settings.Precise.MaxExponent)
+	if err != nil {
+		return nil, err
+	}
+	b, err := operand("number2.amount", in.GetNumber2().GetAmount(), settings.Precise.MaxLength, settings.Precise.MaxExponent)
+	if err != nil {
+		return nil, err
+	}
+	if method == "Divide" && b.Sign() == 0 {
+		return nil, validate.Violation("number2.amount", "cannot be zero")
+	}
+
+	response = &moneypb.MoneyResponse{}
+	compute := func(context.Context) error {
+		result, rounded := op(a, b, int(in.Scale), mode)
+		response.Result = &moneypb.Money{Amount: result.Plain(), Currency: currency}
+		response.Rounded = rounded
+		return nil
+	}
+	if s.lookasider == nil {
+		return response, compute(ctx)
+	}
+	key := mathcache.Key{
+		PrimaryContext:   fmt.Sprintf("Money:%s:%s", currency, a),
+		SecondaryContext: fmt.Sprintf("Money:%s:%s", other, b),
+		Key:              fmt.Sprintf("MathMoney%s:%d:%s", method, in.Scale, in.Rounding),
+	}
+	if err := s.lookasider.Lookaside(ctx, key, response, compute); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
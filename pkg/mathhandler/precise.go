@@ -0,0 +1,81 @@
+package mathhandler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/decimal"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	precisepb "github.com/mangeshhendre/mathsvc/proto/services_precise_v1"
+	"golang.org/x/net/context"
+)
+
+// preciseServer is the MathPrecise service, decimal arithmetic to any precision.
+type preciseServer struct {
+	*Server
+}
+
+// Add is number1 + number2, exact unless it has more digits than the precision.
+func (s preciseServer) Add(ctx context.Context, in *precisepb.PreciseRequest) (*precisepb.PreciseResponse, error) {
+	return s.precise(ctx, "Add", in, func(a, b decimal.Decimal, digits int) (decimal.Decimal, bool) {
+		return decimal.Add(a, b).Round(digits)
+	})
+}
+
+// Multiply is number1 × number2, exact unless it has more digits than the precision.
+func (s preciseServer) Multiply(ctx context.Context, in *precisepb.PreciseRequest) (*precisepb.PreciseResponse, error) {
+	return s.precise(ctx, "Multiply", in, func(a, b decimal.Decimal, digits int) (decimal.Decimal, bool) {
+		return decimal.Mul(a, b).Round(digits)
+	})
+}
+
+// Divide is number1 / number2 to the precision.
+func (s preciseServer) Divide(ctx context.Context, in *precisepb.PreciseRequest) (*precisepb.PreciseResponse, error) {
+	return s.precise(ctx, "Divide", in, decimal.Quo)
+}
+
+// precise parses the operands, refusing any the limits do not allow, and applies op to them.
+func (s preciseServer) precise(ctx context.Context, method string, in *precisepb.PreciseRequest, op func(a, b decimal.Decimal, digits int) (decimal.Decimal, bool)) (response *precisepb.PreciseResponse, err error) {
+	defer s.tracer.Statsd("Precise"+method, time.Now())
+	_, req := start(ctx, "MathPrecise", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	limits := s.live.Load().Precise
+
+	digits := int(in.Precision)
+	if digits == 0 {
+		digits = limits.Precision
+	}
+	if digits > limits.MaxPrecision {
+		return nil, validate.Violation("precision", fmt.Sprintf("is more than %d digits", limits.MaxPrecision))
+	}
+	a, err := operand("number1", in.Number1, limits.MaxLength, limits.MaxExponent)
+	if err != nil {
+		return nil, err
+	}
+	b, err := operand("number2", in.Number2, limits.MaxLength, limits.MaxExponent)
+	if err != nil {
+		return nil, err
+	}
+	if method == "Divide" && b.Sign() == 0 {
+		return nil, validate.Violation("number2", "cannot be zero")
+	}
+	req.span.SetAttribute("precise.precision", digits)
+
+	result, rounded := op(a, b, digits)
+	return &precisepb.PreciseResponse{Result: result.String(), Rounded: rounded, Precision: uint32(digits)}, nil
+}
+
+// operand parses the decimal string in field.
+func operand(field, s string, maxLength, maxExponent int) (decimal.Decimal, error) {
+	if len(s) > maxLength {
+		return decimal.Decimal{}, validate.Violation(field, fmt.Sprintf("is longer than %d bytes", maxLength))
+	}
+	d, err := decimal.Parse(s, maxExponent)
+	if err != nil {
+		return decimal.Decimal{}, validate.Violation(field, err.Error())
+	}
+	return d, nil
+}
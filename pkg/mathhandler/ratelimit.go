@@ -0,0 +1,50 @@
+package mathhandler
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimiter is a token bucket across all RPCs, its rate and burst follow the live config.
+type rateLimiter struct {
+	live *config.Live
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(live *config.Live) *rateLimiter {
+	return &rateLimiter{live: live}
+}
+
+// allow takes a token, or returns ResourceExhausted if there are none left.
+func (r *rateLimiter) allow() error {
+	settings := r.live.Load()
+	if settings.RateLimit <= 0 {
+		return nil
+	}
+	burst := math.Max(float64(settings.RateBurst), 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if r.last.IsZero() {
+		r.tokens = burst
+	} else {
+		r.tokens = math.Min(burst, r.tokens+now.Sub(r.last).Seconds()*settings.RateLimit)
+	}
+	r.last = now
+
+	if r.tokens < 1 {
+		return status.Errorf(codes.ResourceExhausted, "Rate limit of %g requests per second exceeded", settings.RateLimit)
+	}
+	r.tokens--
+	return nil
+}
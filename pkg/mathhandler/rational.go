@@ -0,0 +1,97 @@
+package mathhandler
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/rational"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	rationalpb "github.com/mangeshhendre/mathsvc/proto/services_rational_v1"
+	"golang.org/x/net/context"
+)
+
+// rationalServer is the MathRational service, exact arithmetic on fractions.
+type rationalServer struct {
+	*Server
+}
+
+// Add is number1 + number2.
+func (s rationalServer) Add(ctx context.Context, in *rationalpb.RationalRequest) (*rationalpb.RationalResponse, error) {
+	return s.rational(ctx, "Add", in, new(big.Rat).Add)
+}
+
+// Subtract is number1 - number2.
+func (s rationalServer) Subtract(ctx context.Context, in *rationalpb.RationalRequest) (*rationalpb.RationalResponse, error) {
+	return s.rational(ctx, "Subtract", in, new(big.Rat).Sub)
+}
+
+// Multiply is number1 × number2.
+func (s rationalServer) Multiply(ctx context.Context, in *rationalpb.RationalRequest) (*rationalpb.RationalResponse, error) {
+	return s.rational(ctx, "Multiply", in, new(big.Rat).Mul)
+}
+
+// Divide is number1 / number2.
+func (s rationalServer) Divide(ctx context.Context, in *rationalpb.RationalRequest) (*rationalpb.RationalResponse, error) {
+	return s.rational(ctx, "Divide", in, new(big.Rat).Quo)
+}
+
+// rational parses the operands, refusing any the limits do not allow, and applies op to them.
+func (s rationalServer) rational(ctx context.Context, method string, in *rationalpb.RationalRequest, op func(a, b *big.Rat) *big.Rat) (response *rationalpb.RationalResponse, err error) {
+	defer s.tracer.Statsd("Rational"+method, time.Now())
+	_, req := start(ctx, "MathRational", method)
+	defer observe(req, &err)
+	if err := s.limiter.allow(); err != nil {
+		return nil, err
+	}
+	settings := s.live.Load()
+
+	digits := int(in.Digits)
+	if digits == 0 {
+		digits = settings.Rational.Digits
+	}
+	if digits > settings.Rational.MaxDigits {
+		return nil, validate.Violation("digits", fmt.Sprintf("is more than %d places", settings.Rational.MaxDigits))
+	}
+	a, err := fraction("number1", in.Number1, settings.Precise.MaxLength)
+	if err != nil {
+		return nil, err
+	}
+	b, err := fraction("number2", in.Number2, settings.Precise.MaxLength)
+	if err != nil {
+		return nil, err
+	}
+	if method == "Divide" && b.Sign() == 0 {
+		return nil, validate.Violation("number2", fmt.Sprintf("is %s, and a rational cannot be divided by zero", b.RatString()))
+	}
+
+	result := op(a, b)
+	decimal, rounded := rational.Decimal(result, digits)
+	return &rationalpb.RationalResponse{
+		Result:  &rationalpb.Rational{Numerator: result.Num().String(), Denominator: result.Denom().String()},
+		Decimal: decimal,
+		Rounded: rounded,
+		Mixed:   rational.Mixed(result),
+	}, nil
+}
+
+// fraction parses the rational in field.  A violation names its numerator or denominator
+// when one of them is at fault.
+func fraction(field string, r *rationalpb.Rational, maxLength int) (*big.Rat, error) {
+	if r == nil {
+		return nil, validate.Violation(field, "is required")
+	}
+	if len(r.Numerator) > maxLength {
+		return nil, validate.Violation(field+".numerator", fmt.Sprintf("is longer than %d bytes", maxLength))
+	}
+	if len(r.Denominator) > maxLength {
+		return nil, validate.Violation(field+".denominator", fmt.Sprintf("is longer than %d bytes", maxLength))
+	}
+	f, err := rational.Parse(r.Numerator, r.Denominator)
+	if e, ok := err.(*rational.Error); ok {
+		return nil, validate.Violation(field+"."+e.Part, e.Problem)
+	} else if err != nil {
+		return nil, validate.Violation(field, err.Error())
+	}
+	return f, nil
+}
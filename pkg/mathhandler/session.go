@@ -0,0 +1,102 @@
+package mathhandler
+
+import (
+	"io"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/session"
+	sessionpb "github.com/mangeshhendre/mathsvc/proto/services_session_v1"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Session runs a calculator session, answering every command with the state after it.
+// The arithmetic goes through AddNumber, MultiplyNumber and DevideNumber, so each of those
+// commands is rate limited, validated and cached like a single call.  A session that sends
+// nothing for the idle timeout is ended with DeadlineExceeded.
+func (s *Server) Session(stream sessionpb.MathSession_SessionServer) (err error) {
+	defer s.tracer.Statsd("Session", time.Now())
+	ctx, req := start(stream.Context(), "MathSession", "Session")
+	defer observe(req, &err)
+
+	calculator := session.New(s.arithmetic, s.live.Load().Validation, s.history != nil)
+	defer func() {
+		if _, _, keep := calculator.Transcript(); keep {
+			if writeErr := s.history.Write(calculator, err); writeErr != nil {
+				s.logger.Warn("Unable to write session transcript", "Error", writeErr)
+			}
+		}
+	}()
+
+	commands, errs := receive(ctx, stream)
+	var timer *time.Timer
+	var idle <-chan time.Time
+	if s.session.IdleTimeout > 0 {
+		timer = time.NewTimer(s.session.IdleTimeout)
+		defer timer.Stop()
+		idle = timer.C
+	}
+	for count := 0; ; count++ {
+		select {
+		case command := <-commands:
+			if timer != nil && !timer.Stop() {
+				<-timer.C
+			}
+			entry := calculator.Apply(ctx, command.Command)
+			state := &sessionpb.SessionState{Command: entry.Command, Accumulator: entry.Accumulator}
+			if entry.Err != nil {
+				state.Error = status.Convert(entry.Err).Proto()
+			}
+			if err := stream.Send(state); err != nil {
+				return err
+			}
+			if timer != nil {
+				timer.Reset(s.session.IdleTimeout)
+			}
+		case err := <-errs:
+			req.span.SetAttribute("session.commands", count)
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-idle:
+			req.span.SetAttribute("session.commands", count)
+			return status.Errorf(codes.DeadlineExceeded, "Session idle for %v", s.session.IdleTimeout)
+		}
+	}
+}
+
+// arithmetic is the session's way to the Math methods.
+func (s *Server) arithmetic(ctx context.Context, method string, in *pb.MathRequest) (*pb.MathResponse, error) {
+	switch method {
+	case "AddNumber":
+		return s.AddNumber(ctx, in)
+	case "MultiplyNumber":
+		return s.MultiplyNumber(ctx, in)
+	default:
+		return s.DevideNumber(ctx, in)
+	}
+}
+
+// receive reads stream until it ends, so that Session can wait on a command and a timer at
+// once.  The error that ends the stream, io.EOF when the client closes it, goes to errs.
+func receive(ctx context.Context, stream sessionpb.MathSession_SessionServer) (<-chan *sessionpb.SessionCommand, <-chan error) {
+	commands, errs := make(chan *sessionpb.SessionCommand), make(chan error, 1)
+	go func() {
+		for {
+			command, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case commands <- command:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return commands, errs
+}
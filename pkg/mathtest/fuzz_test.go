@@ -0,0 +1,166 @@
+package mathtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	"github.com/mangeshhendre/mathsvc/pkg/mathdb"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/status"
+)
+
+type tierCall func(context.Context, *pb.MathRequest) (*pb.MathResponse, error)
+
+// tier is one layer of the service, each method of it by name.  Only the validated tiers
+// apply the validation policy, the others do whatever arithmetic they are asked to.
+type tier struct {
+	Name      string
+	Methods   map[string]tierCall
+	Validated bool
+}
+
+func methods(s pb.MathServer) map[string]tierCall {
+	return map[string]tierCall{"AddNumber": s.AddNumber, "MultiplyNumber": s.MultiplyNumber, "DevideNumber": s.DevideNumber}
+}
+
+// missed calls each method through a cache with an empty store of its own, so every call
+// misses.
+func missed(db pb.MathServer, live *config.Live) map[string]tierCall {
+	calls := map[string]tierCall{}
+	for name := range methods(db) {
+		name := name
+		calls[name] = func(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+			cache, _ := mathcache.NewWithStore(db, mathcache.NewMemoryStore(), live)
+			return methods(cache)[name](ctx, in)
+		}
+	}
+	return calls
+}
+
+// hit calls each method of cache twice and answers with the second call, which the first
+// cached.
+func hit(cache pb.MathServer) map[string]tierCall {
+	calls := map[string]tierCall{}
+	for name, call := range methods(cache) {
+		call := call
+		calls[name] = func(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+			call(ctx, in)
+			return call(ctx, in)
+		}
+	}
+	return calls
+}
+
+// tiers starts a server and returns every tier from the database out to a gRPC client.
+// The cache tier comes twice: a result must be the same whether it is cached or not.
+func tiers(f *testing.F) []tier {
+	s, err := Start(nil)
+	if err != nil {
+		f.Fatalf("Unable to start the server: %s", err.Error())
+	}
+	conn, err := s.Dial()
+	if err != nil {
+		s.Close()
+		f.Fatalf("Unable to dial the server: %s", err.Error())
+	}
+	f.Cleanup(func() {
+		conn.Close()
+		s.Close()
+	})
+
+	db := mathdb.NewMemory(config.Default().Statsd)
+	live := config.NewLive(Config().Runtime)
+	cache, _ := mathcache.NewWithStore(db, mathcache.NewMemoryStore(), live)
+	client := pb.NewMathClient(conn)
+	return []tier{
+		{Name: "db", Methods: methods(db)},
+		{Name: "cache miss", Methods: missed(db, live)},
+		{Name: "cache hit", Methods: hit(cache)},
+		{Name: "handler", Methods: methods(s.Handler), Validated: true},
+		{Name: "grpc", Validated: true, Methods: map[string]tierCall{
+			"AddNumber": func(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+				return client.AddNumber(ctx, in)
+			},
+			"MultiplyNumber": func(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+				return client.MultiplyNumber(ctx, in)
+			},
+			"DevideNumber": func(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+				return client.DevideNumber(ctx, in)
+			},
+		}},
+	}
+}
+
+// addEdges seeds a target with the awkward doubles, every one against every other.
+func addEdges(f *testing.F, add func(a, b float64)) {
+	edges := []float64{
+		1, -1, 0.1, 3,
+		math.Copysign(0, -1), 0,
+		math.MaxFloat64, -math.MaxFloat64,
+		math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64,
+		2.2250738585072014e-308, // the smallest normal
+		math.Inf(1), math.Inf(-1), math.NaN(),
+	}
+	for _, a := range edges {
+		for _, b := range edges {
+			add(a, b)
+		}
+	}
+}
+
+// FuzzTiers checks the validated tiers against Reference and the others against Arithmetic.
+func FuzzTiers(f *testing.F) {
+	addEdges(f, func(a, b float64) {
+		for op := range Methods {
+			f.Add(uint8(op), a, b)
+		}
+	})
+	all := tiers(f)
+	policy := Config().Validation
+
+	f.Fuzz(func(t *testing.T, op uint8, number1, number2 float64) {
+		method := Methods[int(op)%len(Methods)]
+
+		for _, tier := range all {
+			want, wantCode := Arithmetic(method, number1, number2)
+			if tier.Validated {
+				want, wantCode = Reference(policy, method, number1, number2)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			response, err := tier.Methods[method](ctx, &pb.MathRequest{Number1: number1, Number2: number2})
+			cancel()
+			if got := status.Code(err); got != wantCode {
+				t.Fatalf("%s %s(%v, %v): Got code %s, want %s (%v)", tier.Name, method, number1, number2, got, wantCode, err)
+			}
+			if err == nil && !Same(response.Result, want) {
+				t.Fatalf("%s %s(%v, %v): Got %v, want %v", tier.Name, method, number1, number2, response.Result, want)
+			}
+		}
+	})
+}
+
+// FuzzCommutative checks that the order of the operands does not matter to add or multiply.
+func FuzzCommutative(f *testing.F) {
+	addEdges(f, func(a, b float64) { f.Add(a, b) })
+	all := tiers(f)
+	client := all[len(all)-1]
+
+	f.Fuzz(func(t *testing.T, number1, number2 float64) {
+		for _, method := range []string{"AddNumber", "MultiplyNumber"} {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			forward, err1 := client.Methods[method](ctx, &pb.MathRequest{Number1: number1, Number2: number2})
+			backward, err2 := client.Methods[method](ctx, &pb.MathRequest{Number1: number2, Number2: number1})
+			cancel()
+			if status.Code(err1) != status.Code(err2) {
+				t.Fatalf("%s(%v, %v) and (%v, %v): Got codes %s and %s", method, number1, number2, number2, number1, status.Code(err1), status.Code(err2))
+			}
+			if err1 == nil && !Same(forward.Result, backward.Result) {
+				t.Fatalf("%s(%v, %v) and (%v, %v): Got %v and %v", method, number1, number2, number2, number1, forward.Result, backward.Result)
+			}
+		}
+	})
+}
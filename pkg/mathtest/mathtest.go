@@ -0,0 +1,230 @@
+// Package mathtest runs the real mathsvc server inside the test process: the Math handler
+// with its in-memory database and cache, behind the same TLS, JWT authentication and
+// interceptors as production, on a bufconn listener.  Nothing outside the process is
+// needed.  Faults can be injected into the database and cache, directly or through the
+// FaultAdmin service, which the test server always serves.
+//
+// Reference is the arithmetic the service promises under a validation policy, Arithmetic
+// what the unvalidated database and cache tiers do.  The fuzz targets check every tier
+// against one or the other, run them with go test -fuzz FuzzTiers ./pkg/mathtest, and
+// check inputs that once broke a tier into testdata/fuzz so they are tried on every
+// go test.
+package mathtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/fault"
+	"github.com/mangeshhendre/mathsvc/pkg/grpcserver"
+	"github.com/mangeshhendre/mathsvc/pkg/mathhandler"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Issuer is the issuer of the tokens the test server trusts.
+const Issuer = "mathtest"
+
+// serverName is the name in the test server's certificate.
+const serverName = "localhost"
+
+// bufferSize is how much each direction of a connection buffers.
+const bufferSize = 1 << 20
+
+// Server is a running test server.
+type Server struct {
+	// Config is the configuration the server was started with.
+	Config *config.Config
+	// Live holds the runtime settings, Store new ones to change them mid test.
+	Live *config.Live
+	// Handler is the Math handler being served.
+	Handler *mathhandler.Server
+	// Faults injects faults into the database and cache, it starts without rules.
+	Faults *fault.Injector
+
+	server *grpcserver.Server
+	listen *bufconn.Listener
+	dir    string
+	key    *rsa.PrivateKey
+	roots  *x509.CertPool
+}
+
+// Config returns a configuration for a test server: no rate limit, the cache on, and
+// statsd, tracing and the debug server kept off the network.
+func Config() *config.Config {
+	c := config.Default()
+	c.GRPC.MinLife, c.GRPC.LifeRange = 0, 0
+	c.GRPC.DebugAddress, c.GRPC.DebugPort = "127.0.0.1", "0"
+	c.GRPC.DrainPeriod = 0
+	c.GRPC.ShutdownTimeout = time.Second
+	c.Statsd.Address = ""
+	c.Trace.Exporter = "none"
+	c.Memcache.Servers = ""
+	c.RateLimit = 0
+	return c
+}
+
+// Start starts a server with the configuration c, Config if it is nil.  The certificate
+// and key paths in c are replaced with ones made for the test unless c is Dev, which is
+// plaintext and unauthenticated as it is in main.
+func Start(c *config.Config, interceptors ...grpc.UnaryServerInterceptor) (*Server, error) {
+	if c == nil {
+		c = Config()
+	}
+	s := &Server{Config: c, Live: config.NewLive(c.Runtime), Faults: fault.New(), listen: bufconn.Listen(bufferSize)}
+
+	handler, err := mathhandler.NewInMemory(c, s.Live, s.Faults)
+	if err != nil {
+		return nil, err
+	}
+	s.Handler = handler
+
+	if c.Dev {
+		s.server, err = grpcserver.NewDevWithListener("mathtest", c.GRPC, s.listen, interceptors...)
+	} else {
+		if err = s.makeCredentials(); err != nil {
+			return nil, err
+		}
+		s.server, err = grpcserver.NewWithListener("mathtest", c.GRPC, s.listen, interceptors...)
+	}
+	if err != nil {
+		s.removeCredentials()
+		return nil, err
+	}
+
+	s.server.RegisterHandlers(handler, fault.NewAdmin(s.Faults))
+	s.server.Startup()
+	return s, nil
+}
+
+// makeCredentials writes a key and a self signed certificate, which serves both for TLS
+// and as the certificate of Issuer in the JWT certificate directory.
+func (s *Server) makeCredentials() error {
+	dir, err := ioutil.TempDir("", "mathtest")
+	if err != nil {
+		return err
+	}
+	s.dir = dir
+
+	s.key, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: serverName},
+		DNSNames:              []string{serverName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &s.key.PublicKey, s.key)
+	if err != nil {
+		return err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return err
+	}
+	s.roots = x509.NewCertPool()
+	s.roots.AddCert(cert)
+
+	certs := filepath.Join(dir, "jwt_certs")
+	if err := os.Mkdir(certs, 0700); err != nil {
+		return err
+	}
+	certPath, keyPath := filepath.Join(certs, Issuer+".pem"), filepath.Join(dir, "server.key")
+	if err := ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(s.key)}), 0600); err != nil {
+		return err
+	}
+
+	s.Config.GRPC.JWTCertPath = certs
+	s.Config.GRPC.SSLCertPath, s.Config.GRPC.SSLKeyPath = certPath, keyPath
+	return nil
+}
+
+func (s *Server) removeCredentials() {
+	if s.dir != "" {
+		os.RemoveAll(s.dir)
+	}
+}
+
+// Token returns a JWT from Issuer that expires after ttl, a negative ttl gives one that has
+// already expired.
+func (s *Server) Token(ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": Issuer,
+		"sub": "mathtest",
+		"exp": time.Now().Add(ttl).Unix(),
+	})
+	return token.SignedString(s.key)
+}
+
+// Dial connects to the server as a client would, with a valid token unless it is Dev.
+func (s *Server) Dial(opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if s.Config.Dev {
+		return s.DialToken("", opts...)
+	}
+	token, err := s.Token(time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	return s.DialToken(token, opts...)
+}
+
+// DialToken connects to the server sending token, or no token at all if it is empty.
+func (s *Server) DialToken(token string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{grpc.WithDialer(s.dial)}, opts...)
+	if s.Config.Dev {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: s.roots, ServerName: serverName})))
+	}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearer(token)))
+	}
+	return grpc.Dial(s.listen.Addr().String(), opts...)
+}
+
+// dial adapts the listener to grpc.WithDialer, there is only the one address.
+func (s *Server) dial(string, time.Duration) (net.Conn, error) {
+	return s.listen.Dial()
+}
+
+// Close stops the server and removes its credentials.
+func (s *Server) Close() error {
+	s.server.Shutdown()
+	s.removeCredentials()
+	return s.Handler.Close()
+}
+
+// bearer sends a fixed token with every call.
+type bearer string
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return false
+}
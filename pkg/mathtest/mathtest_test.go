@@ -0,0 +1,833 @@
+package mathtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/fault"
+	"github.com/mangeshhendre/mathsvc/pkg/mathcache"
+	"github.com/mangeshhendre/mathsvc/pkg/session"
+	aggregatepb "github.com/mangeshhendre/mathsvc/proto/services_aggregate_v1"
+	batchpb "github.com/mangeshhendre/mathsvc/proto/services_batch_v1"
+	complexpb "github.com/mangeshhendre/mathsvc/proto/services_complex_v1"
+	exprpb "github.com/mangeshhendre/mathsvc/proto/services_expression_v1"
+	adminpb "github.com/mangeshhendre/mathsvc/proto/services_fault_v1"
+	integerpb "github.com/mangeshhendre/mathsvc/proto/services_integer_v1"
+	moneypb "github.com/mangeshhendre/mathsvc/proto/services_money_v1"
+	precisepb "github.com/mangeshhendre/mathsvc/proto/services_precise_v1"
+	rationalpb "github.com/mangeshhendre/mathsvc/proto/services_rational_v1"
+	sessionpb "github.com/mangeshhendre/mathsvc/proto/services_session_v1"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type call func(pb.MathClient, context.Context, *pb.MathRequest, ...grpc.CallOption) (*pb.MathResponse, error)
+
+var (
+	add      call = pb.MathClient.AddNumber
+	multiply call = pb.MathClient.MultiplyNumber
+	divide   call = pb.MathClient.DevideNumber
+)
+
+// start starts a server and connects to it, both are closed when the test ends.
+func start(t *testing.T, dev bool, interceptors ...grpc.UnaryServerInterceptor) (*Server, pb.MathClient) {
+	c := Config()
+	c.Dev = dev
+	s, err := Start(c, interceptors...)
+	if err != nil {
+		t.Fatalf("Unable to start the server: %s", err.Error())
+	}
+	conn, err := s.Dial()
+	if err != nil {
+		s.Close()
+		t.Fatalf("Unable to dial the server: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Close()
+	})
+	return s, pb.NewMathClient(conn)
+}
+
+// dial starts a server and returns a connection to it for a service's own client.
+func dial(t *testing.T) *grpc.ClientConn {
+	s, _ := start(t, false)
+	conn, err := s.Dial()
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// answered checks that a case's call ended with the code it wants, and reports whether it
+// also has a response to check.
+func answered(t *testing.T, n int, name string, err error, want codes.Code) bool {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, name, got, want, err)
+		return false
+	}
+	return err == nil
+}
+
+// violated is the fields an InvalidArgument error blames, in order.
+func violated(err error) []string {
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if request, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range request.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestMath(t *testing.T) {
+	var cases = []struct {
+		Case     string
+		Call     call
+		Number1  float64
+		Number2  float64
+		Want     float64
+		WantCode codes.Code
+		Cache    string
+	}{
+		{Case: "Add", Call: add, Number1: 3, Number2: 4, Want: 7, Cache: "miss"},
+		{Case: "Add again", Call: add, Number1: 3, Number2: 4, Want: 7, Cache: "hit"},
+		{Case: "Multiply the same numbers", Call: multiply, Number1: 3, Number2: 4, Want: 12, Cache: "miss"},
+		{Case: "Multiply again", Call: multiply, Number1: 3, Number2: 4, Want: 12, Cache: "hit"},
+		{Case: "Divide", Call: divide, Number1: 12, Number2: 4, Want: 3, Cache: "miss"},
+		{Case: "Negative", Call: add, Number1: -2.5, Number2: 1, Want: -1.5, Cache: "miss"},
+		{Case: "Zero operand", Call: add, Number1: 0, Number2: 4, Want: 4, Cache: "miss"},
+		{Case: "Divide zero", Call: divide, Number1: 0, Number2: 4, Want: 0, Cache: "miss"},
+		// Operands are refused before the cache is asked, results only once it has answered.
+		{Case: "Divide by zero", Call: divide, Number1: 1, Number2: 0, WantCode: codes.InvalidArgument},
+		{Case: "Overflow", Call: multiply, Number1: math.MaxFloat64, Number2: 2, WantCode: codes.InvalidArgument, Cache: "miss"},
+	}
+	for _, dev := range []bool{false, true} {
+		_, client := start(t, dev)
+		for n, c := range cases {
+			var header metadata.MD
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			response, err := c.Call(client, ctx, &pb.MathRequest{Number1: c.Number1, Number2: c.Number2}, grpc.Header(&header))
+			cancel()
+
+			if got := status.Code(err); got != c.WantCode {
+				t.Errorf("Case: %d: %s: Dev %t: Got code %s, want %s (%v)", n, c.Case, dev, got, c.WantCode, err)
+				continue
+			}
+			if err == nil && response.Result != c.Want {
+				t.Errorf("Case: %d: %s: Dev %t: Got %g, want %g", n, c.Case, dev, response.Result, c.Want)
+			}
+			var cache string
+			if values := header[mathcache.CacheHeader]; len(values) > 0 {
+				cache = values[0]
+			}
+			if cache != c.Cache {
+				t.Errorf("Case: %d: %s: Dev %t: Got cache %q, want %q", n, c.Case, dev, cache, c.Cache)
+			}
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	s, client := start(t, true)
+
+	var cases = []struct {
+		Case       string
+		Policy     config.Validation
+		Call       call
+		Number1    float64
+		Number2    float64
+		WantCode   codes.Code
+		WantFields []string
+	}{
+		{Case: "Divide by zero", Call: divide, Number1: 1, Number2: 0, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+		{Case: "Infinite operand", Call: add, Number1: math.Inf(1), Number2: 1, WantCode: codes.InvalidArgument, WantFields: []string{"number1"}},
+		{Case: "Infinity allowed", Policy: config.Validation{AllowInfinity: true}, Call: add, Number1: math.Inf(1), Number2: 1},
+		{Case: "NaN result", Policy: config.Validation{AllowInfinity: true}, Call: add, Number1: math.Inf(1), Number2: math.Inf(-1), WantCode: codes.InvalidArgument, WantFields: []string{"number1", "number2"}},
+		{Case: "NaN allowed", Policy: config.Validation{AllowNaN: true, AllowInfinity: true}, Call: add, Number1: math.Inf(1), Number2: math.Inf(-1)},
+		{Case: "Divide by zero allowing everything", Policy: config.Validation{AllowNaN: true, AllowInfinity: true}, Call: divide, Number1: 1, Number2: 0, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+	}
+	for n, c := range cases {
+		settings := s.Live.Load()
+		settings.Validation = c.Policy
+		if err := s.Live.Store(settings); err != nil {
+			t.Fatalf("Case: %d: %s: Unable to change the policy: %s", n, c.Case, err.Error())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := c.Call(client, ctx, &pb.MathRequest{Number1: c.Number1, Number2: c.Number2})
+		cancel()
+		if got := status.Code(err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, err)
+			continue
+		}
+		if fields := violated(err); !reflect.DeepEqual(fields, c.WantFields) {
+			t.Errorf("Case: %d: %s: Got field violations %v, want %v", n, c.Case, fields, c.WantFields)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	s, client := start(t, false)
+	conn, err := s.Dial()
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	defer conn.Close()
+	batch := batchpb.NewMathBatchClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A single call first, so the batch finds it in the cache.
+	if _, err := client.AddNumber(ctx, &pb.MathRequest{Number1: 1, Number2: 2}); err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+
+	var cases = []struct {
+		Case      string
+		Item      batchpb.BatchItem
+		Want      float64
+		WantCode  codes.Code
+		WantCache string
+	}{
+		{Case: "Cached", Item: batchpb.BatchItem{Operation: batchpb.Operation_OPERATION_ADD, Number1: 1, Number2: 2}, Want: 3, WantCache: "hit"},
+		{Case: "Multiply", Item: batchpb.BatchItem{Operation: batchpb.Operation_OPERATION_MULTIPLY, Number1: 3, Number2: 4}, Want: 12, WantCache: "miss"},
+		{Case: "Divide by zero", Item: batchpb.BatchItem{Operation: batchpb.Operation_OPERATION_DIVIDE, Number1: 1}, WantCode: codes.InvalidArgument},
+		{Case: "No operation", Item: batchpb.BatchItem{Number1: 1, Number2: 2}, WantCode: codes.InvalidArgument},
+		{Case: "Overflow", Item: batchpb.BatchItem{Operation: batchpb.Operation_OPERATION_MULTIPLY, Number1: math.MaxFloat64, Number2: 2}, WantCode: codes.InvalidArgument, WantCache: "miss"},
+		{Case: "Divide", Item: batchpb.BatchItem{Operation: batchpb.Operation_OPERATION_DIVIDE, Number1: 1, Number2: 4}, Want: 0.25, WantCache: "miss"},
+	}
+	in := &batchpb.BatchRequest{}
+	for n := range cases {
+		in.Items = append(in.Items, &cases[n].Item)
+	}
+	response, err := batch.Batch(ctx, in)
+	if err != nil {
+		t.Fatalf("Unexpected Error: %s", err.Error())
+	}
+	if len(response.Results) != len(cases) {
+		t.Fatalf("Got %d results, want %d", len(response.Results), len(cases))
+	}
+	for n, c := range cases {
+		result := response.Results[n]
+		var code codes.Code
+		if result.Error != nil {
+			code = codes.Code(result.Error.Code)
+		}
+		if code != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, code, c.WantCode, result.Error)
+			continue
+		}
+		if code == codes.OK && result.Result != c.Want {
+			t.Errorf("Case: %d: %s: Got %g, want %g", n, c.Case, result.Result, c.Want)
+		}
+		if result.Cache != c.WantCache {
+			t.Errorf("Case: %d: %s: Got cache %q, want %q", n, c.Case, result.Cache, c.WantCache)
+		}
+	}
+
+	settings := s.Live.Load()
+	settings.Batch.MaxItems = 2
+	if err := s.Live.Store(settings); err != nil {
+		t.Fatalf("Unable to change the batch limit: %s", err.Error())
+	}
+	if _, err := batch.Batch(ctx, in); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Got %v for a batch over the limit, want InvalidArgument", err)
+	}
+}
+
+// aggregateClient is the client side of any MathAggregate stream.
+type aggregateClient interface {
+	Send(*aggregatepb.AggregateNumber) error
+	CloseAndRecv() (*aggregatepb.AggregateResponse, error)
+}
+
+func TestAggregate(t *testing.T) {
+	s, _ := start(t, false)
+	conn, err := s.Dial()
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	defer conn.Close()
+	client := aggregatepb.NewMathAggregateClient(conn)
+	sum := func(ctx context.Context) (aggregateClient, error) { return client.Sum(ctx) }
+	product := func(ctx context.Context) (aggregateClient, error) { return client.Product(ctx) }
+	min := func(ctx context.Context) (aggregateClient, error) { return client.Min(ctx) }
+	mean := func(ctx context.Context) (aggregateClient, error) { return client.Mean(ctx) }
+
+	var cases = []struct {
+		Case         string
+		Policy       config.Validation
+		Open         func(context.Context) (aggregateClient, error)
+		Numbers      []float64
+		Want         float64
+		WantCount    int64
+		WantInfinity bool
+		WantCode     codes.Code
+	}{
+		{Case: "Sum", Open: sum, Numbers: []float64{1, 1e100, 1, -1e100}, Want: 2, WantCount: 4},
+		{Case: "Empty sum", Open: sum, Want: 0},
+		{Case: "Product", Open: product, Numbers: []float64{2, 3, 0.5}, Want: 3, WantCount: 3},
+		{Case: "Min", Open: min, Numbers: []float64{4, -2, 9}, Want: -2, WantCount: 3},
+		{Case: "Empty min", Open: min, WantCode: codes.InvalidArgument},
+		{Case: "Mean", Open: mean, Numbers: []float64{1, 2, 3, 4}, Want: 2.5, WantCount: 4},
+		{Case: "Infinity refused", Open: sum, Numbers: []float64{1, math.Inf(1)}, WantCode: codes.InvalidArgument},
+		{Case: "Infinity allowed", Policy: config.Validation{AllowInfinity: true}, Open: sum, Numbers: []float64{1, math.Inf(1)}, Want: math.Inf(1), WantCount: 2, WantInfinity: true},
+		{Case: "Overflow refused", Open: sum, Numbers: []float64{math.MaxFloat64, math.MaxFloat64}, WantCode: codes.InvalidArgument},
+	}
+	for n, c := range cases {
+		settings := s.Live.Load()
+		settings.Validation = c.Policy
+		if err := s.Live.Store(settings); err != nil {
+			t.Fatalf("Case: %d: %s: Unable to change the policy: %s", n, c.Case, err.Error())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		stream, err := c.Open(ctx)
+		if err != nil {
+			cancel()
+			t.Fatalf("Case: %d: %s: Unable to open the stream: %s", n, c.Case, err.Error())
+		}
+		for _, number := range c.Numbers {
+			// A refused number ends the stream, the reason comes from CloseAndRecv.
+			if stream.Send(&aggregatepb.AggregateNumber{Value: number}) != nil {
+				break
+			}
+		}
+		response, err := stream.CloseAndRecv()
+		cancel()
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		if response.Result != c.Want || response.Count != c.WantCount || response.HasInfinity != c.WantInfinity {
+			t.Errorf("Case: %d: %s: Got %+v, want %g from %d numbers", n, c.Case, response, c.Want, c.WantCount)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	client := exprpb.NewMathExpressionClient(dial(t))
+
+	var cases = []struct {
+		Case           string
+		Expression     string
+		Bindings       map[string]float64
+		Want           float64
+		WantNormalized string
+		WantCode       codes.Code
+		WantViolation  string
+		Cache          string
+	}{
+		{Case: "Evaluate", Expression: "(3+4)*2/x", Bindings: map[string]float64{"x": 7}, Want: 2, WantNormalized: "(3 + 4) * 2 / x", Cache: "miss"},
+		{Case: "Written differently", Expression: " ( 3 + 4.0 ) * 2/ (x)", Bindings: map[string]float64{"x": 7}, Want: 2, WantNormalized: "(3 + 4) * 2 / x", Cache: "hit"},
+		{Case: "Unused binding", Expression: "(3+4)*2/x", Bindings: map[string]float64{"x": 7, "y": 1}, Want: 2, WantNormalized: "(3 + 4) * 2 / x", Cache: "hit"},
+		{Case: "Other binding", Expression: "(3+4)*2/x", Bindings: map[string]float64{"x": 2}, Want: 7, WantNormalized: "(3 + 4) * 2 / x", Cache: "miss"},
+		{Case: "Functions", Expression: "hypot(3, 4) - -pi^0", Want: 6, WantNormalized: "hypot(3, 4) - -pi^0", Cache: "miss"},
+		{Case: "Parse error", Expression: "(1 + 2", WantCode: codes.InvalidArgument, WantViolation: "expression: at offset 6: the ( at offset 0 is never closed"},
+		{Case: "Division by zero", Expression: "1 / (x - 2)", Bindings: map[string]float64{"x": 2}, WantCode: codes.InvalidArgument, WantViolation: "expression: at offset 2: division by zero", Cache: "miss"},
+		{Case: "Constant binding", Expression: "pi", Bindings: map[string]float64{"pi": 3}, WantCode: codes.InvalidArgument, WantViolation: "bindings[pi]: is not a variable name, or is a constant or function"},
+		{Case: "Infinite binding", Expression: "x", Bindings: map[string]float64{"x": math.Inf(1)}, WantCode: codes.InvalidArgument, WantViolation: "bindings[x]: +Inf is not allowed"},
+	}
+	for n, c := range cases {
+		var header metadata.MD
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := client.Evaluate(ctx, &exprpb.EvaluateRequest{Expression: c.Expression, Bindings: c.Bindings}, grpc.Header(&header))
+		cancel()
+		if got := status.Code(err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, err)
+			continue
+		}
+		var cache string
+		if values := header[mathcache.CacheHeader]; len(values) > 0 {
+			cache = values[0]
+		}
+		if cache != c.Cache {
+			t.Errorf("Case: %d: %s: Got cache %q, want %q", n, c.Case, cache, c.Cache)
+		}
+		if err != nil {
+			var violation string
+			for _, detail := range status.Convert(err).Details() {
+				if request, ok := detail.(*errdetails.BadRequest); ok && len(request.FieldViolations) == 1 {
+					violation = request.FieldViolations[0].Field + ": " + request.FieldViolations[0].Description
+				}
+			}
+			if violation != c.WantViolation {
+				t.Errorf("Case: %d: %s: Got violation %q, want %q", n, c.Case, violation, c.WantViolation)
+			}
+			continue
+		}
+		if response.Result != c.Want || response.Normalized != c.WantNormalized {
+			t.Errorf("Case: %d: %s: Got %+v, want %v from %q", n, c.Case, response, c.Want, c.WantNormalized)
+		}
+	}
+}
+
+func TestPrecise(t *testing.T) {
+	client := precisepb.NewMathPreciseClient(dial(t))
+	add, multiply, divide := client.Add, client.Multiply, client.Divide
+
+	var cases = []struct {
+		Case          string
+		Call          func(context.Context, *precisepb.PreciseRequest, ...grpc.CallOption) (*precisepb.PreciseResponse, error)
+		In            *precisepb.PreciseRequest
+		Want          string
+		WantRounded   bool
+		WantPrecision uint32
+		WantCode      codes.Code
+	}{
+		{Case: "Tenths", Call: add, In: &precisepb.PreciseRequest{Number1: "0.1", Number2: "0.2"}, Want: "0.3", WantPrecision: 34},
+		{Case: "Past float64", Call: add, In: &precisepb.PreciseRequest{Number1: "9007199254740993", Number2: "9007199254740993"}, Want: "18014398509481986", WantPrecision: 34},
+		{Case: "Rounded to the precision", Call: multiply, In: &precisepb.PreciseRequest{Number1: "123456", Number2: "654321", Precision: 5}, Want: "8.0780e+10", WantRounded: true, WantPrecision: 5},
+		{Case: "A third", Call: divide, In: &precisepb.PreciseRequest{Number1: "1", Number2: "3", Precision: 20}, Want: "0.33333333333333333333", WantRounded: true, WantPrecision: 20},
+		{Case: "Divide by zero", Call: divide, In: &precisepb.PreciseRequest{Number1: "1", Number2: "0.0"}, WantCode: codes.InvalidArgument},
+		{Case: "Not a number", Call: add, In: &precisepb.PreciseRequest{Number1: "one", Number2: "1"}, WantCode: codes.InvalidArgument},
+		{Case: "Too precise", Call: add, In: &precisepb.PreciseRequest{Number1: "1", Number2: "1", Precision: 1001}, WantCode: codes.InvalidArgument},
+	}
+	for n, c := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := c.Call(ctx, c.In)
+		cancel()
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		if response.Result != c.Want || response.Rounded != c.WantRounded || response.Precision != c.WantPrecision {
+			t.Errorf("Case: %d: %s: Got %+v, want %s rounded %t to %d digits", n, c.Case, response, c.Want, c.WantRounded, c.WantPrecision)
+		}
+	}
+}
+
+func TestMoney(t *testing.T) {
+	client := moneypb.NewMathMoneyClient(dial(t))
+	add, subtract, multiply, divide := client.Add, client.Subtract, client.Multiply, client.Divide
+	usd := func(amount string) *moneypb.Money { return &moneypb.Money{Amount: amount, Currency: "USD"} }
+	quantity := func(amount string) *moneypb.Money { return &moneypb.Money{Amount: amount} }
+
+	var cases = []struct {
+		Case        string
+		Call        func(context.Context, *moneypb.MoneyRequest, ...grpc.CallOption) (*moneypb.MoneyResponse, error)
+		In          *moneypb.MoneyRequest
+		Want        string
+		WantRounded bool
+		WantCode    codes.Code
+		Cache       string
+	}{
+		{Case: "Tenths", Call: add, In: &moneypb.MoneyRequest{Number1: usd("0.1"), Number2: usd("0.2"), Scale: 2}, Want: "0.30", Cache: "miss"},
+		{Case: "Tenths again", Call: add, In: &moneypb.MoneyRequest{Number1: usd("0.1"), Number2: usd("0.2"), Scale: 2}, Want: "0.30", Cache: "hit"},
+		{Case: "Another scale", Call: add, In: &moneypb.MoneyRequest{Number1: usd("0.1"), Number2: usd("0.2"), Scale: 4}, Want: "0.3000", Cache: "miss"},
+		{Case: "Subtract", Call: subtract, In: &moneypb.MoneyRequest{Number1: usd("10"), Number2: usd("10.015"), Scale: 2}, Want: "-0.02", WantRounded: true, Cache: "miss"},
+		{Case: "Subtract half up", Call: subtract, In: &moneypb.MoneyRequest{Number1: usd("10"), Number2: usd("10.015"), Scale: 2, Rounding: moneypb.Rounding_ROUNDING_HALF_UP}, Want: "-0.02", WantRounded: true, Cache: "miss"},
+		{Case: "Subtract down", Call: subtract, In: &moneypb.MoneyRequest{Number1: usd("10"), Number2: usd("10.015"), Scale: 2, Rounding: moneypb.Rounding_ROUNDING_DOWN}, Want: "-0.01", WantRounded: true, Cache: "miss"},
+		{Case: "Tax half even", Call: multiply, In: &moneypb.MoneyRequest{Number1: usd("20.00"), Number2: quantity("0.06125"), Scale: 2}, Want: "1.22", WantRounded: true, Cache: "miss"},
+		{Case: "Tax half up", Call: multiply, In: &moneypb.MoneyRequest{Number1: usd("20.00"), Number2: quantity("0.06125"), Scale: 2, Rounding: moneypb.Rounding_ROUNDING_HALF_UP}, Want: "1.23", WantRounded: true, Cache: "miss"},
+		{Case: "Tax ceiling", Call: multiply, In: &moneypb.MoneyRequest{Number1: usd("20.00"), Number2: quantity("0.06125"), Scale: 2, Rounding: moneypb.Rounding_ROUNDING_CEILING}, Want: "1.23", WantRounded: true, Cache: "miss"},
+		{Case: "Split three ways", Call: divide, In: &moneypb.MoneyRequest{Number1: usd("100"), Number2: quantity("3"), Scale: 2, Rounding: moneypb.Rounding_ROUNDING_FLOOR}, Want: "33.33", WantRounded: true, Cache: "miss"},
+		{Case: "Mixed currencies", Call: add, In: &moneypb.MoneyRequest{Number1: usd("1"), Number2: &moneypb.Money{Amount: "1", Currency: "EUR"}, Scale: 2}, WantCode: codes.InvalidArgument},
+		{Case: "Multiply by money", Call: multiply, In: &moneypb.MoneyRequest{Number1: usd("1"), Number2: usd("2"), Scale: 2}, WantCode: codes.InvalidArgument},
+		{Case: "No currency", Call: add, In: &moneypb.MoneyRequest{Number1: quantity("1"), Number2: quantity("2"), Scale: 2}, WantCode: codes.InvalidArgument},
+		{Case: "Divide by zero", Call: divide, In: &moneypb.MoneyRequest{Number1: usd("1"), Number2: quantity("0"), Scale: 2}, WantCode: codes.InvalidArgument},
+		{Case: "Unknown rounding", Call: add, In: &moneypb.MoneyRequest{Number1: usd("1"), Number2: usd("2"), Rounding: 9}, WantCode: codes.InvalidArgument},
+		{Case: "Scale too large", Call: add, In: &moneypb.MoneyRequest{Number1: usd("1"), Number2: usd("2"), Scale: 19}, WantCode: codes.InvalidArgument},
+	}
+	for n, c := range cases {
+		var header metadata.MD
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := c.Call(ctx, c.In, grpc.Header(&header))
+		cancel()
+		var cache string
+		if values := header[mathcache.CacheHeader]; len(values) > 0 {
+			cache = values[0]
+		}
+		if cache != c.Cache {
+			t.Errorf("Case: %d: %s: Got cache %q, want %q", n, c.Case, cache, c.Cache)
+		}
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		if response.Result.Amount != c.Want || response.Result.Currency != "USD" || response.Rounded != c.WantRounded {
+			t.Errorf("Case: %d: %s: Got %+v, want %s USD rounded %t", n, c.Case, response, c.Want, c.WantRounded)
+		}
+	}
+}
+
+func TestInteger(t *testing.T) {
+	client := integerpb.NewMathIntegerClient(dial(t))
+	add, subtract, multiply, divide := client.Add, client.Subtract, client.Multiply, client.Divide
+	const max, min = math.MaxInt64, math.MinInt64
+	saturating, wrapping := integerpb.Overflow_OVERFLOW_SATURATING, integerpb.Overflow_OVERFLOW_WRAPPING
+
+	var cases = []struct {
+		Case           string
+		Call           func(context.Context, *integerpb.IntegerRequest, ...grpc.CallOption) (*integerpb.IntegerResponse, error)
+		In             *integerpb.IntegerRequest
+		Want           int64
+		WantRemainder  int64
+		WantOverflowed bool
+		WantCode       codes.Code
+	}{
+		{Case: "Add", Call: add, In: &integerpb.IntegerRequest{Number1: 9007199254740993, Number2: 1}, Want: 9007199254740994},
+		{Case: "Add checked", Call: add, In: &integerpb.IntegerRequest{Number1: max, Number2: 1}, WantCode: codes.OutOfRange},
+		{Case: "Add saturating", Call: add, In: &integerpb.IntegerRequest{Number1: max, Number2: 1, Overflow: saturating}, Want: max, WantOverflowed: true},
+		{Case: "Add wrapping", Call: add, In: &integerpb.IntegerRequest{Number1: max, Number2: 1, Overflow: wrapping}, Want: min, WantOverflowed: true},
+		{Case: "Subtract saturating", Call: subtract, In: &integerpb.IntegerRequest{Number1: min, Number2: 1, Overflow: saturating}, Want: min, WantOverflowed: true},
+		{Case: "Multiply checked", Call: multiply, In: &integerpb.IntegerRequest{Number1: 1 << 32, Number2: 1 << 31}, WantCode: codes.OutOfRange},
+		{Case: "Multiply saturating", Call: multiply, In: &integerpb.IntegerRequest{Number1: max, Number2: -2, Overflow: saturating}, Want: min, WantOverflowed: true},
+		{Case: "Divide truncated", Call: divide, In: &integerpb.IntegerRequest{Number1: -7, Number2: 2}, Want: -3, WantRemainder: -1},
+		{Case: "Divide floored", Call: divide, In: &integerpb.IntegerRequest{Number1: -7, Number2: 2, Division: integerpb.Division_DIVISION_FLOOR}, Want: -4, WantRemainder: 1},
+		{Case: "Negate the minimum", Call: divide, In: &integerpb.IntegerRequest{Number1: min, Number2: -1}, WantCode: codes.OutOfRange},
+		{Case: "Negate the minimum saturating", Call: divide, In: &integerpb.IntegerRequest{Number1: min, Number2: -1, Overflow: saturating}, Want: max, WantOverflowed: true},
+		{Case: "Divide by zero", Call: divide, In: &integerpb.IntegerRequest{Number1: 1, Overflow: wrapping}, WantCode: codes.InvalidArgument},
+		{Case: "Unknown overflow", Call: add, In: &integerpb.IntegerRequest{Number1: 1, Number2: 1, Overflow: 9}, WantCode: codes.InvalidArgument},
+	}
+	for n, c := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := c.Call(ctx, c.In)
+		cancel()
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		if response.Result != c.Want || response.Remainder != c.WantRemainder || response.Overflowed != c.WantOverflowed {
+			t.Errorf("Case: %d: %s: Got %+v, want %d remainder %d overflowed %t", n, c.Case, response, c.Want, c.WantRemainder, c.WantOverflowed)
+		}
+	}
+}
+
+func TestRational(t *testing.T) {
+	client := rationalpb.NewMathRationalClient(dial(t))
+	add, subtract, multiply, divide := client.Add, client.Subtract, client.Multiply, client.Divide
+	fraction := func(numerator, denominator string) *rationalpb.Rational {
+		return &rationalpb.Rational{Numerator: numerator, Denominator: denominator}
+	}
+
+	var cases = []struct {
+		Case        string
+		Call        func(context.Context, *rationalpb.RationalRequest, ...grpc.CallOption) (*rationalpb.RationalResponse, error)
+		In          *rationalpb.RationalRequest
+		Want        string
+		WantDecimal string
+		WantRounded bool
+		WantMixed   string
+		WantCode    codes.Code
+		WantFields  []string
+	}{
+		{Case: "Third and sixth", Call: add, In: &rationalpb.RationalRequest{Number1: fraction("1", "3"), Number2: fraction("1", "6")}, Want: "1/2", WantDecimal: "0.5000000000", WantMixed: "1/2"},
+		{Case: "Subtract", Call: subtract, In: &rationalpb.RationalRequest{Number1: fraction("1", "4"), Number2: fraction("2", ""), Digits: 2}, Want: "-7/4", WantDecimal: "-1.75", WantMixed: "-1 3/4"},
+		{Case: "Scale a recipe", Call: multiply, In: &rationalpb.RationalRequest{Number1: fraction("3", "4"), Number2: fraction("8", "3"), Digits: 1}, Want: "2/1", WantDecimal: "2.0", WantMixed: "2"},
+		{Case: "Divide", Call: divide, In: &rationalpb.RationalRequest{Number1: fraction("2", "3"), Number2: fraction("-4", "5"), Digits: 3}, Want: "-5/6", WantDecimal: "-0.833", WantRounded: true, WantMixed: "-5/6"},
+		{Case: "Divide by zero", Call: divide, In: &rationalpb.RationalRequest{Number1: fraction("1", "2"), Number2: fraction("0", "7")}, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+		{Case: "Zero denominator", Call: add, In: &rationalpb.RationalRequest{Number1: fraction("1", "0"), Number2: fraction("1", "2")}, WantCode: codes.InvalidArgument, WantFields: []string{"number1.denominator"}},
+		{Case: "Not an integer", Call: add, In: &rationalpb.RationalRequest{Number1: fraction("0.5", "1"), Number2: fraction("1", "2")}, WantCode: codes.InvalidArgument, WantFields: []string{"number1.numerator"}},
+		{Case: "Missing operand", Call: add, In: &rationalpb.RationalRequest{Number1: fraction("1", "2")}, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+		{Case: "Too many digits", Call: add, In: &rationalpb.RationalRequest{Number1: fraction("1", "2"), Number2: fraction("1", "2"), Digits: 1001}, WantCode: codes.InvalidArgument, WantFields: []string{"digits"}},
+	}
+	for n, c := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := c.Call(ctx, c.In)
+		cancel()
+		if fields := violated(err); !reflect.DeepEqual(fields, c.WantFields) {
+			t.Errorf("Case: %d: %s: Got field violations %v, want %v", n, c.Case, fields, c.WantFields)
+		}
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		got := response.Result.Numerator + "/" + response.Result.Denominator
+		if got != c.Want || response.Decimal != c.WantDecimal || response.Rounded != c.WantRounded || response.Mixed != c.WantMixed {
+			t.Errorf("Case: %d: %s: Got %s %+v, want %s %s rounded %t mixed %q", n, c.Case, got, response, c.Want, c.WantDecimal, c.WantRounded, c.WantMixed)
+		}
+	}
+}
+
+func TestComplex(t *testing.T) {
+	client := complexpb.NewMathComplexClient(dial(t))
+	z := func(re, im float64) *complexpb.Complex { return &complexpb.Complex{Real: re, Imaginary: im} }
+
+	var cases = []struct {
+		Case             string
+		Call             func(context.Context, *complexpb.ComplexRequest, ...grpc.CallOption) (*complexpb.ComplexResponse, error)
+		In               *complexpb.ComplexRequest
+		Real, Imaginary  float64
+		Magnitude, Phase float64
+		WantCode         codes.Code
+		WantFields       []string
+	}{
+		{Case: "Add", Call: client.Add, In: &complexpb.ComplexRequest{Number1: z(1, 2), Number2: z(3, -4)}, Real: 4, Imaginary: -2, Magnitude: math.Sqrt(20), Phase: math.Atan2(-2, 4)},
+		{Case: "Subtract", Call: client.Subtract, In: &complexpb.ComplexRequest{Number1: z(1, 2), Number2: z(1, 0)}, Imaginary: 2, Magnitude: 2, Phase: math.Pi / 2},
+		{Case: "Multiply", Call: client.Multiply, In: &complexpb.ComplexRequest{Number1: z(1, 2), Number2: z(3, 4)}, Real: -5, Imaginary: 10, Magnitude: math.Sqrt(125), Phase: math.Atan2(10, -5)},
+		{Case: "Divide", Call: client.Divide, In: &complexpb.ComplexRequest{Number1: z(-5, 10), Number2: z(3, 4)}, Real: 1, Imaginary: 2, Magnitude: math.Sqrt(5), Phase: math.Atan2(2, 1)},
+		{Case: "Divide by zero", Call: client.Divide, In: &complexpb.ComplexRequest{Number1: z(1, 1)}, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+		{Case: "i squared", Call: client.Pow, In: &complexpb.ComplexRequest{Number1: z(0, 1), Number2: z(2, 0)}, Real: -1, Magnitude: 1, Phase: math.Pi},
+		{Case: "Abs", Call: client.Abs, In: &complexpb.ComplexRequest{Number1: z(3, 4)}, Real: 5, Magnitude: 5},
+		{Case: "Phase", Call: client.Phase, In: &complexpb.ComplexRequest{Number1: z(0, -2)}, Real: -math.Pi / 2, Magnitude: math.Pi / 2, Phase: math.Pi},
+		{Case: "Conjugate", Call: client.Conjugate, In: &complexpb.ComplexRequest{Number1: z(3, 4)}, Real: 3, Imaginary: -4, Magnitude: 5, Phase: math.Atan2(-4, 3)},
+		{Case: "Euler", Call: client.Exp, In: &complexpb.ComplexRequest{Number1: z(0, math.Pi)}, Real: -1, Magnitude: 1, Phase: math.Pi},
+		{Case: "Log of minus one", Call: client.Log, In: &complexpb.ComplexRequest{Number1: z(-1, 0)}, Imaginary: math.Pi, Magnitude: math.Pi, Phase: math.Pi / 2},
+		{Case: "Log of zero", Call: client.Log, In: &complexpb.ComplexRequest{}, WantCode: codes.InvalidArgument, WantFields: []string{"number1"}},
+		{Case: "Sqrt of minus four", Call: client.Sqrt, In: &complexpb.ComplexRequest{Number1: z(-4, 0)}, Imaginary: 2, Magnitude: 2, Phase: math.Pi / 2},
+		{Case: "Unary ignores number2", Call: client.Sqrt, In: &complexpb.ComplexRequest{Number1: z(4, 0), Number2: z(math.NaN(), 0)}, Real: 2, Magnitude: 2},
+		{Case: "NaN operand", Call: client.Add, In: &complexpb.ComplexRequest{Number1: z(1, 0), Number2: z(0, math.NaN())}, WantCode: codes.InvalidArgument, WantFields: []string{"number2"}},
+		{Case: "Overflowing result", Call: client.Multiply, In: &complexpb.ComplexRequest{Number1: z(math.MaxFloat64, 0), Number2: z(2, 0)}, WantCode: codes.InvalidArgument, WantFields: []string{"number1", "number2"}},
+	}
+	near := func(got, want float64) bool { return math.Abs(got-want) <= 1e-12*math.Max(1, math.Abs(want)) }
+	for n, c := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		response, err := c.Call(ctx, c.In)
+		cancel()
+		if fields := violated(err); !reflect.DeepEqual(fields, c.WantFields) {
+			t.Errorf("Case: %d: %s: Got field violations %v, want %v", n, c.Case, fields, c.WantFields)
+		}
+		if !answered(t, n, c.Case, err, c.WantCode) {
+			continue
+		}
+		if !near(response.Result.Real, c.Real) || !near(response.Result.Imaginary, c.Imaginary) ||
+			!near(response.Polar.Magnitude, c.Magnitude) || !near(response.Polar.Phase, c.Phase) {
+			t.Errorf("Case: %d: %s: Got %+v, want %v%+vi, polar %v∠%v", n, c.Case, response, c.Real, c.Imaginary, c.Magnitude, c.Phase)
+		}
+	}
+}
+
+func TestSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "session")
+	if err != nil {
+		t.Fatalf("Unable to make a directory: %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	c := Config()
+	c.Session.History = filepath.Join(dir, "history.jsonl")
+	c.Session.IdleTimeout = 200 * time.Millisecond
+	s, err := Start(c)
+	if err != nil {
+		t.Fatalf("Unable to start the server: %s", err.Error())
+	}
+	defer s.Close()
+	conn, err := s.Dial()
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	defer conn.Close()
+	client := sessionpb.NewMathSessionClient(conn)
+
+	var cases = []struct {
+		Case     string
+		Command  string
+		Want     float64
+		WantCode codes.Code
+	}{
+		{Case: "Add", Command: "add 2", Want: 2},
+		{Case: "Multiply", Command: "multiply 3", Want: 6},
+		{Case: "Divide by zero", Command: "divide 0", Want: 6, WantCode: codes.InvalidArgument},
+		{Case: "Store", Command: "store M1", Want: 6},
+		{Case: "Add a register", Command: "add m1", Want: 12},
+		{Case: "Undo", Command: "undo", Want: 6},
+		{Case: "Transcript", Command: "transcript", Want: 6},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Session(ctx)
+	if err != nil {
+		t.Fatalf("Unable to open the session: %s", err.Error())
+	}
+	for n, c := range cases {
+		if err := stream.Send(&sessionpb.SessionCommand{Command: c.Command}); err != nil {
+			t.Fatalf("Case: %d: %s: Unable to send: %s", n, c.Case, err.Error())
+		}
+		state, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Case: %d: %s: Unable to receive: %s", n, c.Case, err.Error())
+		}
+		if got := codes.Code(state.Error.GetCode()); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s", n, c.Case, got, c.WantCode)
+		}
+		if state.Command != c.Command || state.Accumulator != c.Want {
+			t.Errorf("Case: %d: %s: Got %+v, want %v", n, c.Case, state, c.Want)
+		}
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Got %v at the end of the session, want EOF", err)
+	}
+
+	// A session that goes quiet is ended, without a transcript since it asked for none.
+	idle, err := client.Session(ctx)
+	if err != nil {
+		t.Fatalf("Unable to open the idle session: %s", err.Error())
+	}
+	if _, err := idle.Recv(); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Got %v from an idle session, want DeadlineExceeded", err)
+	}
+
+	history, err := ioutil.ReadFile(c.Session.History)
+	if err != nil {
+		t.Fatalf("Unable to read the history: %s", err.Error())
+	}
+	lines := strings.Split(strings.TrimSpace(string(history)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Got %d transcripts, want 1", len(lines))
+	}
+	var transcript session.Transcript
+	if err := json.Unmarshal([]byte(lines[0]), &transcript); err != nil {
+		t.Fatalf("Unable to decode the transcript: %s", err.Error())
+	}
+	if transcript.V != session.Version || transcript.End != "OK" || len(transcript.Commands) != len(cases) {
+		t.Fatalf("Got transcript %+v, want version %d ending OK with %d commands", transcript, session.Version, len(cases))
+	}
+	if got := transcript.Commands[2]; got.Code != "InvalidArgument" || got.Accumulator != "6" {
+		t.Errorf("Got %+v for the refused command, want InvalidArgument at 6", got)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	var seen int
+	counter := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		seen++
+		return handler(ctx, req)
+	}
+	s, _ := start(t, false, counter)
+
+	valid, _ := s.Token(time.Hour)
+	expired, _ := s.Token(-time.Minute)
+	stranger, _ := rsa.GenerateKey(rand.Reader, 2048)
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iss": Issuer, "exp": time.Now().Add(time.Hour).Unix()}).SignedString(stranger)
+	unknown, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"iss": "someone", "exp": time.Now().Add(time.Hour).Unix()}).SignedString(stranger)
+
+	var cases = []struct {
+		Case     string
+		Token    string
+		WantCode codes.Code
+	}{
+		{Case: "Valid", Token: valid, WantCode: codes.OK},
+		{Case: "No token", Token: "", WantCode: codes.Unauthenticated},
+		{Case: "Expired", Token: expired, WantCode: codes.Unauthenticated},
+		{Case: "Forged", Token: forged, WantCode: codes.Unauthenticated},
+		{Case: "Unknown issuer", Token: unknown, WantCode: codes.Unauthenticated},
+	}
+	for n, c := range cases {
+		conn, err := s.DialToken(c.Token)
+		if err != nil {
+			t.Fatalf("Case: %d: %s: Unable to dial: %s", n, c.Case, err.Error())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err = pb.NewMathClient(conn).AddNumber(ctx, &pb.MathRequest{Number1: 1, Number2: 2})
+		cancel()
+		conn.Close()
+		if got := status.Code(err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, err)
+		}
+	}
+
+	// Interceptors run after authentication, so only the valid call reached it.
+	if seen != 1 {
+		t.Errorf("Interceptor saw %d calls, want 1", seen)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	s, client := start(t, false)
+
+	settings := s.Live.Load()
+	settings.RateLimit, settings.RateBurst = 0.001, 2
+	if err := s.Live.Store(settings); err != nil {
+		t.Fatalf("Unable to change the rate limit: %s", err.Error())
+	}
+
+	want := []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted}
+	for n, code := range want {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		_, err := client.MultiplyNumber(ctx, &pb.MathRequest{Number1: 2, Number2: 3})
+		cancel()
+		if got := status.Code(err); got != code {
+			t.Errorf("Call %d: Got code %s, want %s", n, got, code)
+		}
+	}
+}
+
+func TestFaults(t *testing.T) {
+	s, client := start(t, false)
+
+	var cases = []struct {
+		Case     string
+		Rules    []fault.Rule
+		Call     call
+		Number1  float64
+		WantCode codes.Code
+		Cache    string
+	}{
+		{Case: "Database down", Rules: []fault.Rule{{Target: fault.DB, Code: codes.Unavailable}}, Call: add, Number1: 1, WantCode: codes.Unavailable, Cache: "miss"},
+		{Case: "One method down", Rules: []fault.Rule{{Target: "db.MultiplyNumber", Code: codes.Unavailable}}, Call: add, Number1: 2, Cache: "miss"},
+		{Case: "Database hangs", Rules: []fault.Rule{{Target: fault.DB, Hang: true}}, Call: divide, Number1: 3, WantCode: codes.DeadlineExceeded},
+		{Case: "Cache get fails", Rules: []fault.Rule{{Target: fault.CacheGet, Code: codes.Unavailable}}, Call: add, Number1: 4, Cache: "miss"},
+		{Case: "Cache set fails", Rules: []fault.Rule{{Target: fault.CacheSet, Code: codes.Unavailable}}, Call: multiply, Number1: 5, Cache: "miss"},
+		{Case: "Cache hangs", Rules: []fault.Rule{{Target: fault.CacheGet, Hang: true}}, Call: multiply, Number1: 6, Cache: "miss"},
+	}
+	for n, c := range cases {
+		if err := s.Faults.Set(c.Rules); err != nil {
+			t.Fatalf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		var header metadata.MD
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		_, err := c.Call(client, ctx, &pb.MathRequest{Number1: c.Number1, Number2: 2}, grpc.Header(&header))
+		cancel()
+		if got := status.Code(err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, err)
+		}
+		var cache string
+		if values := header[mathcache.CacheHeader]; len(values) > 0 {
+			cache = values[0]
+		}
+		if cache != c.Cache {
+			t.Errorf("Case: %d: %s: Got cache %q, want %q", n, c.Case, cache, c.Cache)
+		}
+	}
+}
+
+func TestFaultAdmin(t *testing.T) {
+	s, client := start(t, false)
+	conn, err := s.Dial()
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	defer conn.Close()
+	admin := adminpb.NewFaultAdminClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rules := []*adminpb.FaultRule{{Target: "db", Every: 2, Code: int32(codes.Unavailable)}}
+	if response, err := admin.SetFaults(ctx, &adminpb.SetFaultsRequest{Rules: rules}); err != nil || len(response.Rules) != 1 {
+		t.Fatalf("Unable to set faults: %v %v", response, err)
+	}
+	if _, err := admin.SetFaults(ctx, &adminpb.SetFaultsRequest{Rules: []*adminpb.FaultRule{{Target: "disk"}}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Got %v for an invalid rule, want InvalidArgument", err)
+	}
+
+	want := []codes.Code{codes.OK, codes.Unavailable, codes.OK, codes.Unavailable}
+	for n, code := range want {
+		_, err := client.AddNumber(ctx, &pb.MathRequest{Number1: float64(10 + n), Number2: 1})
+		if got := status.Code(err); got != code {
+			t.Errorf("Call %d: Got code %s, want %s", n+1, got, code)
+		}
+	}
+
+	if response, err := admin.ClearFaults(ctx, &adminpb.ClearFaultsRequest{}); err != nil || len(response.Rules) != 0 {
+		t.Errorf("Unable to clear faults: %v %v", response, err)
+	}
+	if response, err := admin.ListFaults(ctx, &adminpb.ListFaultsRequest{}); err != nil || len(response.Rules) != 0 {
+		t.Errorf("Got rules %v %v after clearing them", response, err)
+	}
+}
@@ -0,0 +1,49 @@
+package mathtest
+
+import (
+	"math"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"google.golang.org/grpc/codes"
+)
+
+// Methods are the Math methods, in the order the fuzz targets number them.
+var Methods = []string{"AddNumber", "MultiplyNumber", "DevideNumber"}
+
+// Arithmetic is the IEEE 754 double result of method, which is what the database and cache
+// tiers return for any operands at all: they leave validation to the handler.
+func Arithmetic(method string, number1, number2 float64) (float64, codes.Code) {
+	switch method {
+	case "AddNumber":
+		return number1 + number2, codes.OK
+	case "MultiplyNumber":
+		return number1 * number2, codes.OK
+	case "DevideNumber":
+		return number1 / number2, codes.OK
+	}
+	return 0, codes.Unimplemented
+}
+
+// Reference is what the service promises for method under policy: the Arithmetic result, or
+// InvalidArgument if a divisor is zero, either sign, or an operand or the result is a NaN
+// or an infinity the policy does not allow.
+func Reference(policy config.Validation, method string, number1, number2 float64) (float64, codes.Code) {
+	refused := func(v float64) bool {
+		return math.IsNaN(v) && !policy.AllowNaN || math.IsInf(v, 0) && !policy.AllowInfinity
+	}
+	if refused(number1) || refused(number2) || method == "DevideNumber" && number2 == 0 {
+		return 0, codes.InvalidArgument
+	}
+	result, code := Arithmetic(method, number1, number2)
+	if code == codes.OK && refused(result) {
+		return 0, codes.InvalidArgument
+	}
+	return result, code
+}
+
+// Same reports whether two results are the same answer: equal, or both NaN.  Proto3 leaves
+// zero out of the wire format, so a negative zero arrives as a positive one and the two are
+// the same answer too.
+func Same(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
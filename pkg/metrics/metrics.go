@@ -0,0 +1,410 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets are the latency buckets, in seconds, used when none are given.
+var DefaultBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultRegistry is the registry served by Handler.
+var DefaultRegistry = NewRegistry()
+
+// collector is anything that can write itself out in the prometheus text format.
+type collector interface {
+	name() string
+	write(buf *bytes.Buffer)
+}
+
+// Registry holds a set of named collectors.
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]collector
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{collectors: map[string]collector{}}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.collectors[c.name()]; ok {
+		panic(fmt.Sprintf("metrics: duplicate registration of %q", c.name()))
+	}
+	r.collectors[c.name()] = c
+}
+
+// WriteTo renders every registered collector, sorted by name.
+func (r *Registry) WriteTo(buf *bytes.Buffer) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collectors := make([]collector, 0, len(names))
+	for _, name := range names {
+		collectors = append(collectors, r.collectors[name])
+	}
+	r.mu.RUnlock()
+
+	for _, c := range collectors {
+		c.write(buf)
+	}
+}
+
+// ServeHTTP exposes the registry in the prometheus text exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	buf := bytes.NewBuffer(nil)
+	r.WriteTo(buf)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// Handler returns the http handler for the default registry.
+func Handler() http.Handler {
+	return DefaultRegistry
+}
+
+// Since is a convenience for observing durations in seconds.
+func Since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
+
+// family holds the shared parts of every labelled metric.
+type family struct {
+	metricName string
+	help       string
+	kind       string
+	labels     []string
+
+	mu     sync.RWMutex
+	series map[string]interface{}
+	values map[string][]string
+}
+
+func newFamily(name, help, kind string, labels []string) *family {
+	return &family{
+		metricName: name,
+		help:       help,
+		kind:       kind,
+		labels:     labels,
+		series:     map[string]interface{}{},
+		values:     map[string][]string{},
+	}
+}
+
+func (f *family) name() string {
+	return f.metricName
+}
+
+// get returns the series for the given label values, creating it with mk if needed.
+func (f *family) get(values []string, mk func() interface{}) interface{} {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.metricName, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	f.mu.RLock()
+	s, ok := f.series[key]
+	f.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok = f.series[key]; ok {
+		return s
+	}
+	s = mk()
+	f.series[key] = s
+	f.values[key] = append([]string(nil), values...)
+	return s
+}
+
+// each calls fn for every series in a stable order.
+func (f *family) each(fn func(labels string, series interface{})) {
+	f.mu.RLock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	f.mu.RUnlock()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f.mu.RLock()
+		s, values := f.series[key], f.values[key]
+		f.mu.RUnlock()
+		fn(formatLabels(f.labels, values), s)
+	}
+}
+
+func (f *family) header(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", f.metricName, escapeHelp(f.help))
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.metricName, f.kind)
+}
+
+// CounterVec is a set of monotonically increasing counters partitioned by labels.
+type CounterVec struct {
+	*family
+}
+
+// NewCounterVec creates and registers a counter family.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newFamily(name, help, "counter", labels)}
+	r.register(c)
+	return c
+}
+
+// WithLabelValues returns the counter for the given label values.
+func (c *CounterVec) WithLabelValues(values ...string) *Counter {
+	return c.get(values, func() interface{} { return &Counter{} }).(*Counter)
+}
+
+func (c *CounterVec) write(buf *bytes.Buffer) {
+	c.header(buf)
+	c.each(func(labels string, s interface{}) {
+		fmt.Fprintf(buf, "%s%s %s\n", c.metricName, labels, formatFloat(s.(*Counter).Value()))
+	})
+}
+
+// Counter is a single monotonically increasing value.
+type Counter struct {
+	bits uint64
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds the given non-negative value to the counter.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	addFloat(&c.bits, v)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&c.bits))
+}
+
+// GaugeVec is a set of gauges partitioned by labels.
+type GaugeVec struct {
+	*family
+}
+
+// NewGaugeVec creates and registers a gauge family.
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{newFamily(name, help, "gauge", labels)}
+	r.register(g)
+	return g
+}
+
+// WithLabelValues returns the gauge for the given label values.
+func (g *GaugeVec) WithLabelValues(values ...string) *Gauge {
+	return g.get(values, func() interface{} { return &Gauge{} }).(*Gauge)
+}
+
+func (g *GaugeVec) write(buf *bytes.Buffer) {
+	g.header(buf)
+	g.each(func(labels string, s interface{}) {
+		fmt.Fprintf(buf, "%s%s %s\n", g.metricName, labels, formatFloat(s.(*Gauge).Value()))
+	})
+}
+
+// Gauge is a single value that can go up and down.
+type Gauge struct {
+	bits uint64
+}
+
+// Set replaces the gauge value.
+func (g *Gauge) Set(v float64) {
+	atomic.StoreUint64(&g.bits, math.Float64bits(v))
+}
+
+// Inc adds one to the gauge.
+func (g *Gauge) Inc() {
+	addFloat(&g.bits, 1)
+}
+
+// Dec subtracts one from the gauge.
+func (g *Gauge) Dec() {
+	addFloat(&g.bits, -1)
+}
+
+// Value returns the current gauge value.
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&g.bits))
+}
+
+// GaugeFunc is a gauge whose value is computed at scrape time.
+type GaugeFunc struct {
+	metricName string
+	help       string
+	kind       string
+	fn         func() float64
+}
+
+// NewGaugeFunc registers a gauge backed by fn.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{metricName: name, help: help, kind: "gauge", fn: fn}
+	r.register(g)
+	return g
+}
+
+// NewCounterFunc registers a counter backed by fn, for values that are already cumulative.
+func (r *Registry) NewCounterFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{metricName: name, help: help, kind: "counter", fn: fn}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) name() string {
+	return g.metricName
+}
+
+func (g *GaugeFunc) write(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", g.metricName, escapeHelp(g.help))
+	fmt.Fprintf(buf, "# TYPE %s %s\n", g.metricName, g.kind)
+	fmt.Fprintf(buf, "%s %s\n", g.metricName, formatFloat(g.fn()))
+}
+
+// HistogramVec is a set of histograms partitioned by labels.
+type HistogramVec struct {
+	*family
+	buckets []float64
+}
+
+// NewHistogramVec creates and registers a histogram family. Nil buckets selects DefaultBuckets.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	h := &HistogramVec{family: newFamily(name, help, "histogram", labels), buckets: buckets}
+	r.register(h)
+	return h
+}
+
+// WithLabelValues returns the histogram for the given label values.
+func (h *HistogramVec) WithLabelValues(values ...string) *Histogram {
+	return h.get(values, func() interface{} {
+		return &Histogram{upperBounds: h.buckets, counts: make([]uint64, len(h.buckets))}
+	}).(*Histogram)
+}
+
+func (h *HistogramVec) write(buf *bytes.Buffer) {
+	h.header(buf)
+	h.each(func(labels string, s interface{}) {
+		hist := s.(*Histogram)
+		counts, count, sum := hist.snapshot()
+
+		var cumulative uint64
+		for i, upper := range hist.upperBounds {
+			cumulative += counts[i]
+			fmt.Fprintf(buf, "%s_bucket%s %d\n", h.metricName, withLabel(labels, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(buf, "%s_bucket%s %d\n", h.metricName, withLabel(labels, "le", "+Inf"), count)
+		fmt.Fprintf(buf, "%s_sum%s %s\n", h.metricName, labels, formatFloat(sum))
+		fmt.Fprintf(buf, "%s_count%s %d\n", h.metricName, labels, count)
+	})
+}
+
+// Histogram counts observations into fixed buckets.
+type Histogram struct {
+	mu          sync.Mutex
+	upperBounds []float64
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// Observe records a single value.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.upperBounds, v)
+
+	h.mu.Lock()
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += v
+	h.mu.Unlock()
+}
+
+func (h *Histogram) snapshot() ([]uint64, uint64, float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]uint64(nil), h.counts...), h.count, h.sum
+}
+
+func addFloat(bits *uint64, v float64) {
+	for {
+		old := atomic.LoadUint64(bits)
+		updated := math.Float64bits(math.Float64frombits(old) + v)
+		if atomic.CompareAndSwapUint64(bits, old, updated) {
+			return
+		}
+	}
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + quoteLabel(values[i])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func withLabel(labels, name, value string) string {
+	pair := name + "=" + quoteLabel(value)
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// quoteLabel quotes a label value the way the text format does, which escapes only
+// backslash, double quote and newline and leaves every other byte as it is.
+func quoteLabel(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeHelp(help string) string {
+	help = strings.Replace(help, `\`, `\\`, -1)
+	return strings.Replace(help, "\n", `\n`, -1)
+}
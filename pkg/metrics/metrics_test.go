@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := NewRegistry()
+	requests := r.NewCounterVec("test_requests_total", "Requests.", "method", "code")
+	inFlight := r.NewGaugeVec("test_in_flight", "In flight.", "method")
+	latency := r.NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.1, 1}, "method")
+	r.NewGaugeFunc("test_func", "Func.", func() float64 { return 42 })
+
+	requests.WithLabelValues("AddNumber", "OK").Inc()
+	requests.WithLabelValues("AddNumber", "OK").Add(2)
+	requests.WithLabelValues("Añadir\t\"x\"\\\n", "OK").Inc()
+	inFlight.WithLabelValues("AddNumber").Inc()
+	inFlight.WithLabelValues("AddNumber").Inc()
+	inFlight.WithLabelValues("AddNumber").Dec()
+	latency.WithLabelValues("AddNumber").Observe(0.05)
+	latency.WithLabelValues("AddNumber").Observe(0.5)
+	latency.WithLabelValues("AddNumber").Observe(5)
+
+	buf := bytes.NewBuffer(nil)
+	r.WriteTo(buf)
+	out := buf.String()
+
+	var cases = []struct {
+		Case string
+		Want string
+	}{
+		{Case: "Counter", Want: `test_requests_total{method="AddNumber",code="OK"} 3`},
+		{Case: "Escaped Label", Want: "test_requests_total{method=\"Añadir\t\\\"x\\\"\\\\\\n\",code=\"OK\"} 1"},
+		{Case: "Counter Type", Want: "# TYPE test_requests_total counter"},
+		{Case: "Gauge", Want: `test_in_flight{method="AddNumber"} 1`},
+		{Case: "Gauge Func", Want: "test_func 42"},
+		{Case: "First Bucket", Want: `test_latency_seconds_bucket{method="AddNumber",le="0.1"} 1`},
+		{Case: "Second Bucket", Want: `test_latency_seconds_bucket{method="AddNumber",le="1"} 2`},
+		{Case: "Inf Bucket", Want: `test_latency_seconds_bucket{method="AddNumber",le="+Inf"} 3`},
+		{Case: "Sum", Want: `test_latency_seconds_sum{method="AddNumber"} 5.55`},
+		{Case: "Count", Want: `test_latency_seconds_count{method="AddNumber"} 3`},
+	}
+
+	for n, c := range cases {
+		if !strings.Contains(out, c.Want+"\n") {
+			t.Errorf("Case: %d: %s: Expected %q in output:\n%s", n, c.Case, c.Want, out)
+		}
+	}
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "Total.").WithLabelValues().Inc()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Unexpected content type: %s", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "test_total 1\n") {
+		t.Errorf("Unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestRegistry_DuplicatePanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "Total.")
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic on duplicate registration")
+		}
+	}()
+	r.NewGaugeVec("test_total", "Total.")
+}
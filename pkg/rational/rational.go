@@ -0,0 +1,62 @@
+// Package rational parses and formats the exact fractions the MathRational service works in.
+// The arithmetic itself is math/big's: a big.Rat is always held in lowest terms with a
+// positive denominator, so every result is already reduced.
+package rational
+
+import (
+	"math/big"
+)
+
+// Error is a Parse error, Part is the part of the fraction at fault, "numerator" or
+// "denominator".
+type Error struct {
+	Part    string
+	Problem string
+}
+
+func (e *Error) Error() string {
+	return e.Part + " " + e.Problem
+}
+
+// ErrZeroDenominator is returned by Parse for a fraction with a zero denominator.
+var ErrZeroDenominator = &Error{Part: "denominator", Problem: "cannot be zero"}
+
+// Parse is the fraction numerator/denominator, each a decimal integer with an optional
+// sign.  An empty denominator is 1, so a whole number needs only its numerator.
+func Parse(numerator, denominator string) (*big.Rat, error) {
+	n, ok := new(big.Int).SetString(numerator, 10)
+	if !ok {
+		return nil, &Error{Part: "numerator", Problem: "is not an integer"}
+	}
+	d := big.NewInt(1)
+	if denominator != "" {
+		if _, ok := d.SetString(denominator, 10); !ok {
+			return nil, &Error{Part: "denominator", Problem: "is not an integer"}
+		}
+	}
+	if d.Sign() == 0 {
+		return nil, ErrZeroDenominator
+	}
+	return new(big.Rat).SetFrac(n, d), nil
+}
+
+// Decimal is r with digits places after the point, the last rounded half away from zero,
+// and whether that rounding lost anything.
+func Decimal(r *big.Rat, digits int) (string, bool) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	exact := new(big.Rat).Mul(r, new(big.Rat).SetInt(scale)).IsInt()
+	return r.FloatString(digits), !exact
+}
+
+// Mixed is r as a whole number and a proper fraction, such as "-1 1/2".  Either part is
+// left out when it is zero, so 3/1 is "3" and 1/2 is "1/2".
+func Mixed(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	whole, part := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if whole.Sign() == 0 {
+		return r.String()
+	}
+	return whole.String() + " " + part.Abs(part).String() + "/" + r.Denom().String()
+}
@@ -0,0 +1,71 @@
+package rational
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	var cases = []struct {
+		Case                   string
+		Numerator, Denominator string
+		Want                   string
+		WantErr                string
+	}{
+		{Case: "Reduced", Numerator: "2", Denominator: "6", Want: "1/3"},
+		{Case: "Whole", Numerator: "-7", Want: "-7/1"},
+		{Case: "Negative denominator", Numerator: "3", Denominator: "-9", Want: "-1/3"},
+		{Case: "Both negative", Numerator: "-3", Denominator: "-9", Want: "1/3"},
+		{Case: "Zero", Numerator: "0", Denominator: "5", Want: "0/1"},
+		{Case: "Large", Numerator: "123456789012345678901234567890", Denominator: "10", Want: "12345678901234567890123456789/1"},
+		{Case: "Zero denominator", Numerator: "1", Denominator: "0", WantErr: "denominator"},
+		{Case: "Decimal numerator", Numerator: "0.5", Denominator: "2", WantErr: "numerator"},
+		{Case: "Fraction numerator", Numerator: "1/2", WantErr: "numerator"},
+		{Case: "Empty numerator", Numerator: "", Denominator: "2", WantErr: "numerator"},
+		{Case: "Hexadecimal", Numerator: "0x10", WantErr: "numerator"},
+		{Case: "Decimal denominator", Numerator: "1", Denominator: "2.5", WantErr: "denominator"},
+	}
+	for n, c := range cases {
+		r, err := Parse(c.Numerator, c.Denominator)
+		var part string
+		if e, ok := err.(*Error); ok {
+			part = e.Part
+		}
+		if (err != nil) != (c.WantErr != "") || part != c.WantErr {
+			t.Errorf("Case: %d: %s: Got error %v, want one in the %q", n, c.Case, err, c.WantErr)
+			continue
+		}
+		if err == nil && r.String() != c.Want {
+			t.Errorf("Case: %d: %s: Got %s, want %s", n, c.Case, r, c.Want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var cases = []struct {
+		Case                   string
+		Numerator, Denominator string
+		Digits                 int
+		WantDecimal            string
+		WantRounded            bool
+		WantMixed              string
+	}{
+		{Case: "Half", Numerator: "1", Denominator: "2", Digits: 3, WantDecimal: "0.500", WantMixed: "1/2"},
+		{Case: "Third", Numerator: "1", Denominator: "3", Digits: 5, WantDecimal: "0.33333", WantRounded: true, WantMixed: "1/3"},
+		{Case: "Two thirds", Numerator: "-2", Denominator: "3", Digits: 2, WantDecimal: "-0.67", WantRounded: true, WantMixed: "-2/3"},
+		{Case: "Improper", Numerator: "7", Denominator: "2", Digits: 1, WantDecimal: "3.5", WantMixed: "3 1/2"},
+		{Case: "Negative improper", Numerator: "-7", Denominator: "4", Digits: 2, WantDecimal: "-1.75", WantMixed: "-1 3/4"},
+		{Case: "Whole", Numerator: "12", Denominator: "4", Digits: 2, WantDecimal: "3.00", WantMixed: "3"},
+		{Case: "No places", Numerator: "5", Denominator: "2", Digits: 0, WantDecimal: "3", WantRounded: true, WantMixed: "2 1/2"},
+		{Case: "Zero", Numerator: "0", Digits: 1, WantDecimal: "0.0", WantMixed: "0"},
+	}
+	for n, c := range cases {
+		r, _ := Parse(c.Numerator, c.Denominator)
+		decimal, rounded := Decimal(r, c.Digits)
+		if decimal != c.WantDecimal || rounded != c.WantRounded {
+			t.Errorf("Case: %d: %s: Got %s rounded %t, want %s rounded %t", n, c.Case, decimal, rounded, c.WantDecimal, c.WantRounded)
+		}
+		if mixed := Mixed(r); mixed != c.WantMixed {
+			t.Errorf("Case: %d: %s: Got mixed %q, want %q", n, c.Case, mixed, c.WantMixed)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/capture"
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"google.golang.org/grpc/status"
+)
+
+// Version is the format version written in every Transcript.
+const Version = 1
+
+// History is where the transcripts of the sessions that ask for one go: newline delimited
+// JSON, one Transcript per line, in a file rotated like a capture file.  It is safe for
+// concurrent use.
+type History struct {
+	out io.WriteCloser
+}
+
+// Transcript is one session as the history keeps it.
+type Transcript struct {
+	V       int       `json:"v"`
+	Started time.Time `json:"started"`
+	Ended   time.Time `json:"ended"`
+	// Dropped is how many commands from the start of a long session were not kept.
+	Dropped int `json:"dropped,omitempty"`
+	// End is the code the session ended with, OK when the client closed it.
+	End      string        `json:"end"`
+	Commands []Transcribed `json:"commands"`
+}
+
+// Transcribed is one command of a Transcript.
+type Transcribed struct {
+	Command string `json:"command"`
+	// Accumulator is formatted by strconv, JSON has no NaN or infinities.
+	Accumulator string `json:"accumulator"`
+	Code        string `json:"code"`
+	Error       string `json:"error,omitempty"`
+}
+
+// NewHistory opens the history file named by c.History, there is no history without one.
+func NewHistory(c config.Session) (*History, error) {
+	if c.History == "" {
+		return nil, nil
+	}
+	out, err := capture.NewRotatingFile(c.History, int64(c.MaxSizeMB)<<20, c.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &History{out: out}, nil
+}
+
+// Write appends the transcript of s, which ended with err.
+func (h *History) Write(s *Session, err error) error {
+	entries, dropped, _ := s.Transcript()
+	transcript := Transcript{
+		V:        Version,
+		Started:  s.Started().UTC(),
+		Ended:    time.Now().UTC(),
+		Dropped:  dropped,
+		End:      status.Code(err).String(),
+		Commands: make([]Transcribed, len(entries)),
+	}
+	for n, entry := range entries {
+		transcript.Commands[n] = Transcribed{Command: entry.Command, Accumulator: strconv.FormatFloat(entry.Accumulator, 'g', -1, 64), Code: status.Code(entry.Err).String()}
+		if entry.Err != nil {
+			transcript.Commands[n].Error = status.Convert(entry.Err).Message()
+		}
+	}
+	line, jsonErr := json.Marshal(transcript)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	_, writeErr := h.out.Write(append(line, '\n'))
+	return writeErr
+}
+
+// Close closes the history file.
+func (h *History) Close() error {
+	return h.out.Close()
+}
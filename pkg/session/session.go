@@ -0,0 +1,197 @@
+// Package session is the calculator behind the MathSession service: an accumulator, named
+// memory registers and an undo stack, driven by one line commands such as "add 5" or
+// "store M1".  Arithmetic is handed to the Math methods, so a session gets the same answers,
+// validation and errors as single calls do.
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Arithmetic answers a Math method, AddNumber, MultiplyNumber or DevideNumber.
+type Arithmetic func(ctx context.Context, method string, in *pb.MathRequest) (*pb.MathResponse, error)
+
+// methods are the Math methods behind the arithmetic commands.
+var methods = map[string]string{"add": "AddNumber", "multiply": "MultiplyNumber", "divide": "DevideNumber"}
+
+// register matches the names registers may have.
+var register = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+const (
+	// undoDepth is how many commands can be taken back.
+	undoDepth = 100
+	// maxRegisters bounds the memory one session can hold on to.
+	maxRegisters = 64
+	// maxTranscript is how many commands a transcript keeps, the latest ones.
+	maxTranscript = 10000
+)
+
+// Entry is one command and the state it left, Err says why it was refused.
+type Entry struct {
+	Command     string
+	Accumulator float64
+	Err         error
+}
+
+// state is what undo restores.
+type state struct {
+	accumulator float64
+	registers   map[string]float64
+}
+
+// Session is one calculator session.  It is not safe for concurrent use, a stream's
+// commands arrive one at a time.
+type Session struct {
+	arithmetic Arithmetic
+	policy     config.Validation
+	state
+	undo       []state
+	transcript []Entry
+	dropped    int
+	started    time.Time
+	keepable   bool
+	keep       bool
+}
+
+// New starts a session with the accumulator at 0.  policy is the validation policy for
+// the numbers given to set, arithmetic applies its own.  keepable says whether there is a
+// history for the transcript command to keep the session in.
+func New(arithmetic Arithmetic, policy config.Validation, keepable bool) *Session {
+	return &Session{
+		arithmetic: arithmetic,
+		policy:     policy,
+		keepable:   keepable,
+		state:      state{registers: map[string]float64{}},
+		started:    time.Now(),
+	}
+}
+
+// Apply runs one command.  A refused command leaves the state as it was.
+func (s *Session) Apply(ctx context.Context, command string) Entry {
+	err := s.apply(ctx, strings.Fields(command))
+	entry := Entry{Command: command, Accumulator: s.accumulator, Err: err}
+	if len(s.transcript) == maxTranscript {
+		s.transcript = s.transcript[1:]
+		s.dropped++
+	}
+	s.transcript = append(s.transcript, entry)
+	return entry
+}
+
+func (s *Session) apply(ctx context.Context, fields []string) error {
+	if len(fields) == 0 {
+		return validate.Violation("command", "is empty")
+	}
+	verb, args := strings.ToLower(fields[0]), fields[1:]
+	want := 1
+	switch verb {
+	case "clear", "undo", "transcript":
+		want = 0
+	}
+	if len(args) != want {
+		return validate.Violation("command", fmt.Sprintf("%s takes %d operands, not %d", verb, want, len(args)))
+	}
+
+	switch verb {
+	case "add", "multiply", "divide":
+		x, err := s.operand(args[0])
+		if err != nil {
+			return err
+		}
+		response, err := s.arithmetic(ctx, methods[verb], &pb.MathRequest{Number1: s.accumulator, Number2: x})
+		if err != nil {
+			return err
+		}
+		s.change(func() { s.accumulator = response.Result })
+	case "set":
+		x, err := s.operand(args[0])
+		if err != nil {
+			return err
+		}
+		if validate.Refused(s.policy, x) {
+			return validate.Violation("command", fmt.Sprintf("%v is not allowed", x))
+		}
+		s.change(func() { s.accumulator = x })
+	case "clear":
+		s.change(func() { s.accumulator = 0 })
+	case "store":
+		name := strings.ToUpper(args[0])
+		if _, err := strconv.ParseFloat(name, 64); err == nil || !register.MatchString(name) {
+			return validate.Violation("command", fmt.Sprintf("%q is not a register name", args[0]))
+		}
+		if _, ok := s.registers[name]; !ok && len(s.registers) >= maxRegisters {
+			return status.Errorf(codes.ResourceExhausted, "A session has at most %d registers", maxRegisters)
+		}
+		s.change(func() { s.registers[name] = s.accumulator })
+	case "recall":
+		x, err := s.recall(args[0])
+		if err != nil {
+			return err
+		}
+		s.change(func() { s.accumulator = x })
+	case "undo":
+		if len(s.undo) == 0 {
+			return status.Errorf(codes.FailedPrecondition, "Nothing to undo")
+		}
+		s.state, s.undo = s.undo[len(s.undo)-1], s.undo[:len(s.undo)-1]
+	case "transcript":
+		if !s.keepable {
+			return status.Errorf(codes.FailedPrecondition, "This server keeps no session history")
+		}
+		s.keep = true
+	default:
+		return validate.Violation("command", fmt.Sprintf("%q is not a command", fields[0]))
+	}
+	return nil
+}
+
+// change makes a change that undo can take back.
+func (s *Session) change(fn func()) {
+	previous := state{accumulator: s.accumulator, registers: make(map[string]float64, len(s.registers))}
+	for name, value := range s.registers {
+		previous.registers[name] = value
+	}
+	if len(s.undo) == undoDepth {
+		s.undo = s.undo[1:]
+	}
+	s.undo = append(s.undo, previous)
+	fn()
+}
+
+// operand is a number, or the value of a register.
+func (s *Session) operand(arg string) (float64, error) {
+	if x, err := strconv.ParseFloat(arg, 64); err == nil {
+		return x, nil
+	}
+	return s.recall(arg)
+}
+
+func (s *Session) recall(name string) (float64, error) {
+	x, ok := s.registers[strings.ToUpper(name)]
+	if !ok {
+		return 0, validate.Violation("command", fmt.Sprintf("%s is neither a number nor a stored register", name))
+	}
+	return x, nil
+}
+
+// Transcript is the commands of the session so far, how many earlier ones no longer fit,
+// and whether the session asked for it to be kept.
+func (s *Session) Transcript() (entries []Entry, dropped int, keep bool) {
+	return s.transcript, s.dropped, s.keep
+}
+
+// Started is when the session began.
+func (s *Session) Started() time.Time {
+	return s.started
+}
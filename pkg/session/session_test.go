@@ -0,0 +1,91 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/validate"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reference answers like the Math methods, with the default validation policy.
+func reference(ctx context.Context, method string, in *pb.MathRequest) (*pb.MathResponse, error) {
+	if err := validate.Request(config.Validation{}, method, in); err != nil {
+		return nil, err
+	}
+	response := &pb.MathResponse{}
+	switch method {
+	case "AddNumber":
+		response.Result = in.Number1 + in.Number2
+	case "MultiplyNumber":
+		response.Result = in.Number1 * in.Number2
+	default:
+		response.Result = in.Number1 / in.Number2
+	}
+	return response, validate.Result(config.Validation{}, response)
+}
+
+func TestApply(t *testing.T) {
+	var cases = []struct {
+		Case     string
+		Command  string
+		Want     float64
+		WantCode codes.Code
+	}{
+		{Case: "Add", Command: "add 5", Want: 5},
+		{Case: "Multiply", Command: "MULTIPLY 3", Want: 15},
+		{Case: "Store", Command: "store m1", Want: 15},
+		{Case: "Set", Command: "set -2", Want: -2},
+		{Case: "Add a register", Command: "add M1", Want: 13},
+		{Case: "Undo", Command: "undo", Want: -2},
+		{Case: "Recall", Command: "recall M1", Want: 15},
+		{Case: "Divide by zero", Command: "divide 0", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Unknown register", Command: "add M2", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Number as a register", Command: "store inf", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Unknown command", Command: "subtract 1", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Operands", Command: "add 1 2", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Empty", Command: " ", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Infinity refused", Command: "set inf", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "Clear", Command: "clear", Want: 0},
+		{Case: "Undo the clear", Command: "undo", Want: 15},
+		{Case: "Undo the recall", Command: "undo", Want: -2},
+		{Case: "Undo the set", Command: "undo", Want: 15},
+		{Case: "Undo the store", Command: "undo", Want: 15},
+		{Case: "Stored no more", Command: "recall M1", Want: 15, WantCode: codes.InvalidArgument},
+		{Case: "No history", Command: "transcript", Want: 15, WantCode: codes.FailedPrecondition},
+	}
+	s := New(reference, config.Validation{}, false)
+	for n, c := range cases {
+		entry := s.Apply(context.Background(), c.Command)
+		if got := status.Code(entry.Err); got != c.WantCode {
+			t.Errorf("Case: %d: %s: Got code %s, want %s (%v)", n, c.Case, got, c.WantCode, entry.Err)
+		}
+		if entry.Accumulator != c.Want {
+			t.Errorf("Case: %d: %s: Got %v, want %v", n, c.Case, entry.Accumulator, c.Want)
+		}
+	}
+
+	entries, dropped, keep := s.Transcript()
+	if len(entries) != len(cases) || dropped != 0 || keep {
+		t.Errorf("Got a transcript of %d entries, %d dropped, keep %t, want %d, 0, false", len(entries), dropped, keep, len(cases))
+	}
+}
+
+func TestUndoDepth(t *testing.T) {
+	s := New(reference, config.Validation{}, true)
+	for n := 0; n < undoDepth+10; n++ {
+		s.Apply(context.Background(), "add 1")
+	}
+	for n := 0; n < undoDepth; n++ {
+		if entry := s.Apply(context.Background(), "undo"); entry.Err != nil {
+			t.Fatalf("Undo %d: %s", n, entry.Err.Error())
+		}
+	}
+	entry := s.Apply(context.Background(), "undo")
+	if status.Code(entry.Err) != codes.FailedPrecondition || entry.Accumulator != 10 {
+		t.Errorf("Got %v and %v past the undo depth, want 10 and FailedPrecondition", entry.Accumulator, entry.Err)
+	}
+}
@@ -0,0 +1,49 @@
+// Package shadow compares a candidate build's answers with the primary's, either from the
+// client's compare command or by shadowing live traffic from the server.
+package shadow
+
+import (
+	"math"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Outcome is what one server made of a request.
+type Outcome struct {
+	Result float64
+	Code   codes.Code
+}
+
+// Verdicts.
+const (
+	Match          = "match"
+	ResultMismatch = "result_mismatch"
+	CodeMismatch   = "code_mismatch"
+)
+
+// Compare decides whether two outcomes agree.  Failures agree when their codes do, results
+// when they are within tolerance of each other relative to the larger, or within tolerance
+// outright near zero.  NaN agrees with NaN and each infinity with itself.
+func Compare(primary, candidate Outcome, tolerance float64) string {
+	if primary.Code != candidate.Code {
+		return CodeMismatch
+	}
+	if primary.Code != codes.OK || Equal(primary.Result, candidate.Result, tolerance) {
+		return Match
+	}
+	return ResultMismatch
+}
+
+// Equal reports whether a and b agree to within tolerance, see Compare.
+func Equal(a, b, tolerance float64) bool {
+	switch {
+	case a == b:
+		return true
+	case math.IsNaN(a) || math.IsNaN(b):
+		return math.IsNaN(a) && math.IsNaN(b)
+	case math.IsInf(a, 0) || math.IsInf(b, 0):
+		return false
+	}
+	scale := math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	return math.Abs(a-b) <= tolerance*scale
+}
@@ -0,0 +1,162 @@
+package shadow
+
+import (
+	"crypto/tls"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	"github.com/mangeshhendre/mathsvc/pkg/logging"
+	"github.com/mangeshhendre/mathsvc/pkg/metrics"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mathService prefixes every method the shadow knows how to compare.
+const mathService = "/services.luggage.v1.Math/"
+
+// maxInFlight bounds the shadow calls outstanding, past it requests are not shadowed
+// rather than queued, so a slow candidate never slows the primary down.
+const maxInFlight = 64
+
+var (
+	shadowRequests = metrics.DefaultRegistry.NewCounterVec(
+		"mathsvc_shadow_requests_total",
+		"Requests shadowed to the candidate, by method and verdict (match, result_mismatch, code_mismatch, skipped).",
+		"method", "verdict")
+	shadowDelta = metrics.DefaultRegistry.NewHistogramVec(
+		"mathsvc_shadow_latency_delta_seconds",
+		"How much slower the slower of primary and candidate was, by method and which one it was.",
+		nil,
+		"method", "slower")
+)
+
+// Shadow sends a sample of Math requests to a candidate server as well as handling them,
+// and records how the candidate's answers compare.
+type Shadow struct {
+	config   config.Shadow
+	conn     *grpc.ClientConn
+	logger   logxi.Logger
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New connects to the candidate named by c.Target.
+func New(c config.Shadow) (*Shadow, error) {
+	logger := logging.New("mathsvc.Shadow")
+
+	creds := grpc.WithInsecure()
+	if !c.Plaintext {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: c.Insecure}))
+	}
+	conn, err := grpc.Dial(c.Target, creds)
+	if err != nil {
+		return nil, logger.Error("Unable to dial the shadow candidate", "Target", c.Target, "Error", err)
+	}
+	return newShadow(c, conn, logger), nil
+}
+
+func newShadow(c config.Shadow, conn *grpc.ClientConn, logger logxi.Logger) *Shadow {
+	return &Shadow{
+		config:   c,
+		conn:     conn,
+		logger:   logger,
+		inFlight: make(chan struct{}, maxInFlight),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// UnaryServerInterceptor shadows the requests it samples once the primary has answered them.
+// The caller's authorization is passed on, so the candidate sees the same caller, but only
+// over TLS: a Plaintext candidate gets no credentials.
+func (s *Shadow) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		elapsed := time.Since(start)
+
+		if strings.HasPrefix(info.FullMethod, mathService) && s.sampled() {
+			s.shadow(ctx, info.FullMethod, req, resp, err, elapsed)
+		}
+		return resp, err
+	}
+}
+
+func (s *Shadow) sampled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float64() < s.config.Sample
+}
+
+// shadow sends req to the candidate in the background.
+func (s *Shadow) shadow(ctx context.Context, fullMethod string, req, resp interface{}, err error, elapsed time.Duration) {
+	method := strings.TrimPrefix(fullMethod, mathService)
+	select {
+	case s.inFlight <- struct{}{}:
+	default:
+		shadowRequests.WithLabelValues(method, "skipped").Inc()
+		return
+	}
+
+	primary := outcome(resp, err)
+	outgoing := context.Background()
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["authorization"]) > 0 && !s.config.Plaintext {
+		outgoing = metadata.NewOutgoingContext(outgoing, metadata.MD{"authorization": md["authorization"]})
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() { <-s.inFlight }()
+
+		callCtx, cancel := context.WithTimeout(outgoing, s.config.Timeout)
+		defer cancel()
+
+		reply := &pb.MathResponse{}
+		start := time.Now()
+		callErr := s.conn.Invoke(callCtx, fullMethod, req, reply)
+		candidateElapsed := time.Since(start)
+		candidate := outcome(reply, callErr)
+
+		verdict := Compare(primary, candidate, s.config.Tolerance)
+		shadowRequests.WithLabelValues(method, verdict).Inc()
+		if candidateElapsed > elapsed {
+			shadowDelta.WithLabelValues(method, "candidate").Observe((candidateElapsed - elapsed).Seconds())
+		} else {
+			shadowDelta.WithLabelValues(method, "primary").Observe((elapsed - candidateElapsed).Seconds())
+		}
+		if verdict != Match {
+			s.logger.Warn("Shadow candidate disagrees", "Method", method, "Verdict", verdict, "Request", req,
+				"Primary", primary.Result, "PrimaryCode", primary.Code, "Candidate", candidate.Result, "CandidateCode", candidate.Code)
+		}
+	}()
+}
+
+// outcome reads a Math response, or the code of its failure.
+func outcome(resp interface{}, err error) Outcome {
+	if err != nil {
+		return Outcome{Code: status.Code(err)}
+	}
+	o := Outcome{Code: codes.OK}
+	if r, ok := resp.(*pb.MathResponse); ok && r != nil {
+		o.Result = r.Result
+	}
+	return o
+}
+
+// Close waits for the shadow calls in flight and disconnects from the candidate.
+func (s *Shadow) Close() error {
+	s.wg.Wait()
+	return s.conn.Close()
+}
@@ -0,0 +1,158 @@
+package shadow
+
+import (
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	logxi "github.com/mgutz/logxi/v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCompare(t *testing.T) {
+	var cases = []struct {
+		Case      string
+		Primary   Outcome
+		Candidate Outcome
+		Want      string
+	}{
+		{Case: "Same", Primary: Outcome{Result: 7}, Candidate: Outcome{Result: 7}, Want: Match},
+		{Case: "Within Tolerance", Primary: Outcome{Result: 1e12}, Candidate: Outcome{Result: 1e12 + 1}, Want: Match},
+		{Case: "Near Zero", Primary: Outcome{Result: 0}, Candidate: Outcome{Result: 1e-12}, Want: Match},
+		{Case: "Different", Primary: Outcome{Result: 7}, Candidate: Outcome{Result: 7.001}, Want: ResultMismatch},
+		{Case: "NaN", Primary: Outcome{Result: math.NaN()}, Candidate: Outcome{Result: math.NaN()}, Want: Match},
+		{Case: "NaN And Number", Primary: Outcome{Result: math.NaN()}, Candidate: Outcome{Result: 1}, Want: ResultMismatch},
+		{Case: "Infinities", Primary: Outcome{Result: math.Inf(1)}, Candidate: Outcome{Result: math.Inf(-1)}, Want: ResultMismatch},
+		{Case: "Same Failure", Primary: Outcome{Code: codes.InvalidArgument}, Candidate: Outcome{Code: codes.InvalidArgument, Result: 3}, Want: Match},
+		{Case: "Different Failure", Primary: Outcome{Code: codes.InvalidArgument}, Candidate: Outcome{Result: 3}, Want: CodeMismatch},
+	}
+	for n, c := range cases {
+		if got := Compare(c.Primary, c.Candidate, 1e-9); got != c.Want {
+			t.Errorf("Case: %d: %s: Got %s, want %s", n, c.Case, got, c.Want)
+		}
+	}
+}
+
+// candidate adds, multiplies badly and cannot divide.
+type candidate struct{}
+
+func (candidate) AddNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: in.Number1 + in.Number2}, nil
+}
+
+func (candidate) MultiplyNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	return &pb.MathResponse{Result: in.Number1 + in.Number2}, nil
+}
+
+func (candidate) DevideNumber(ctx context.Context, in *pb.MathRequest) (*pb.MathResponse, error) {
+	return nil, status.Error(codes.Internal, "broken")
+}
+
+func TestShadow_Interceptor(t *testing.T) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %s", err.Error())
+	}
+	server := grpc.NewServer()
+	pb.RegisterMathServer(server, candidate{})
+	go server.Serve(listen)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listen.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Unable to dial: %s", err.Error())
+	}
+	c := config.Default().Shadow
+	s := newShadow(c, conn, logxi.New("test"))
+	intercept := s.UnaryServerInterceptor()
+
+	// The primary always gets it right.
+	primary := map[string]float64{"AddNumber": 5, "MultiplyNumber": 6, "DevideNumber": 2.0 / 3}
+
+	var cases = []struct {
+		Case    string
+		Method  string
+		Verdict string
+	}{
+		{Case: "Agree", Method: "AddNumber", Verdict: Match},
+		{Case: "Wrong Answer", Method: "MultiplyNumber", Verdict: ResultMismatch},
+		{Case: "Failure", Method: "DevideNumber", Verdict: CodeMismatch},
+	}
+	before := map[string]float64{}
+	for _, cs := range cases {
+		before[cs.Method] = shadowRequests.WithLabelValues(cs.Method, cs.Verdict).Value()
+	}
+
+	for n, cs := range cases {
+		resp, err := intercept(context.Background(), &pb.MathRequest{Number1: 2, Number2: 3},
+			&grpc.UnaryServerInfo{FullMethod: mathService + cs.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.MathResponse{Result: primary[cs.Method]}, nil
+			})
+		if err != nil || resp == nil {
+			t.Errorf("Case: %d: %s: The primary's answer was lost: %v, %v", n, cs.Case, resp, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Unexpected Error: %s", err.Error())
+	}
+
+	for n, cs := range cases {
+		if got := shadowRequests.WithLabelValues(cs.Method, cs.Verdict).Value() - before[cs.Method]; got != 1 {
+			t.Errorf("Case: %d: %s: Got %v %s verdicts, want 1", n, cs.Case, got, cs.Verdict)
+		}
+	}
+}
+
+func TestShadow_Authorization(t *testing.T) {
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %s", err.Error())
+	}
+	seen := make(chan []string, 1)
+	server := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		seen <- md["authorization"]
+		return handler(ctx, req)
+	}))
+	pb.RegisterMathServer(server, candidate{})
+	go server.Serve(listen)
+	defer server.Stop()
+
+	var cases = []struct {
+		Case      string
+		Plaintext bool
+		Want      []string
+	}{
+		{Case: "TLS", Want: []string{"Bearer token"}},
+		{Case: "Plaintext", Plaintext: true},
+	}
+	for n, c := range cases {
+		conn, err := grpc.Dial(listen.Addr().String(), grpc.WithInsecure())
+		if err != nil {
+			t.Fatalf("Unable to dial: %s", err.Error())
+		}
+		settings := config.Default().Shadow
+		settings.Plaintext = c.Plaintext
+		settings.Sample = 1
+		s := newShadow(settings, conn, logxi.New("test"))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+		s.UnaryServerInterceptor()(ctx, &pb.MathRequest{Number1: 2, Number2: 3},
+			&grpc.UnaryServerInfo{FullMethod: mathService + "AddNumber"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.MathResponse{Result: 5}, nil
+			})
+		if err := s.Close(); err != nil {
+			t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+		}
+		if got := <-seen; !reflect.DeepEqual(got, c.Want) {
+			t.Errorf("Case: %d: %s: The candidate got authorization %q, want %q", n, c.Case, got, c.Want)
+		}
+	}
+}
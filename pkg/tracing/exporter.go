@@ -0,0 +1,213 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// otlpSpan is a span in the OTLP/JSON encoding.
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func toOTLP(span *Span) otlpSpan {
+	span.mu.Lock()
+	defer span.mu.Unlock()
+
+	out := otlpSpan{
+		TraceID:           span.Context.TraceID.String(),
+		SpanID:            span.Context.SpanID.String(),
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status:            otlpStatus{Code: 1},
+	}
+	if span.ParentID.IsValid() {
+		out.ParentSpanID = span.ParentID.String()
+	}
+	if span.Err {
+		out.Status = otlpStatus{Code: 2, Message: span.Message}
+	}
+
+	keys := make([]string, 0, len(span.Attributes))
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		out.Attributes = append(out.Attributes, otlpAttribute{Key: key, Value: otlpValue{StringValue: span.Attributes[key]}})
+	}
+	return out
+}
+
+// FileExporter writes each finished span as a single line of OTLP/JSON.
+type FileExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewFileExporter creates (or appends to) the named file.
+func NewFileExporter(path string) (*FileExporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{w: f, closer: f}, nil
+}
+
+// NewWriterExporter writes spans to an arbitrary writer, handy for tests.
+func NewWriterExporter(w io.Writer) *FileExporter {
+	return &FileExporter{w: w}
+}
+
+// Export writes the span.
+func (e *FileExporter) Export(span *Span) {
+	line, err := json.Marshal(toOTLP(span))
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	e.w.Write(append(line, '\n'))
+	e.mu.Unlock()
+}
+
+// Close closes the underlying file, if there is one.
+func (e *FileExporter) Close() error {
+	if e.closer == nil {
+		return nil
+	}
+	return e.closer.Close()
+}
+
+// OTLPExporter batches spans and posts them to an OTLP/HTTP collector as JSON.
+type OTLPExporter struct {
+	endpoint string
+	service  string
+	client   *http.Client
+
+	mu      sync.Mutex
+	pending []otlpSpan
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// OTLPBatchSize is the number of spans that triggers an immediate flush.
+const OTLPBatchSize = 512
+
+// NewOTLPExporter creates an exporter posting to endpoint (for example http://otel-collector:4318/v1/traces) every interval.
+func NewOTLPExporter(endpoint, service string, interval time.Duration) *OTLPExporter {
+	e := &OTLPExporter{
+		endpoint: endpoint,
+		service:  service,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		done:     make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.loop(interval)
+	return e
+}
+
+func (e *OTLPExporter) loop(interval time.Duration) {
+	defer e.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.Flush()
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// Export queues the span for the next flush.
+func (e *OTLPExporter) Export(span *Span) {
+	e.mu.Lock()
+	e.pending = append(e.pending, toOTLP(span))
+	full := len(e.pending) >= OTLPBatchSize
+	e.mu.Unlock()
+	if full {
+		go e.Flush()
+	}
+}
+
+// Flush posts everything queued so far.
+func (e *OTLPExporter) Flush() error {
+	e.mu.Lock()
+	spans := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpAttribute{{Key: "service.name", Value: otlpValue{StringValue: e.service}}},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "github.com/mangeshhendre/mathsvc/pkg/tracing"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("tracing: collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Close stops the flush loop and sends anything still queued.
+func (e *OTLPExporter) Close() error {
+	close(e.done)
+	e.wg.Wait()
+	return e.Flush()
+}
@@ -0,0 +1,287 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TraceParentHeader is the W3C trace context header, carried as grpc metadata.
+const TraceParentHeader = "traceparent"
+
+// TraceID identifies a whole trace.
+type TraceID [16]byte
+
+// SpanID identifies a single span within a trace.
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+// IsValid reports whether the trace id is non zero.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// IsValid reports whether the span id is non zero.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext is the portion of a span that crosses process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid reports whether both ids are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// TraceParent formats the span context as a W3C traceparent value.
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses a W3C traceparent value.
+func ParseTraceParent(value string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return sc, fmt.Errorf("tracing: malformed traceparent %q", value)
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return sc, fmt.Errorf("tracing: malformed traceparent %q", value)
+	}
+	if err := decodeHex(sc.TraceID[:], parts[1]); err != nil {
+		return sc, fmt.Errorf("tracing: bad trace id in %q: %v", value, err)
+	}
+	if err := decodeHex(sc.SpanID[:], parts[2]); err != nil {
+		return sc, fmt.Errorf("tracing: bad span id in %q: %v", value, err)
+	}
+	flags := make([]byte, 1)
+	if err := decodeHex(flags, parts[3]); err != nil {
+		return sc, fmt.Errorf("tracing: bad flags in %q: %v", value, err)
+	}
+	sc.Sampled = flags[0]&1 == 1
+	if !sc.IsValid() {
+		return sc, fmt.Errorf("tracing: zero ids in traceparent %q", value)
+	}
+	return sc, nil
+}
+
+func decodeHex(dst []byte, src string) error {
+	if len(src) != hex.EncodedLen(len(dst)) || strings.ToLower(src) != src {
+		return fmt.Errorf("want %d lower case hex characters", hex.EncodedLen(len(dst)))
+	}
+	_, err := hex.Decode(dst, []byte(src))
+	return err
+}
+
+// Span kinds, numbered as in OTLP.
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Span is a single timed operation.
+type Span struct {
+	Name       string
+	Kind       int
+	Context    SpanContext
+	ParentID   SpanID
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Err        bool
+	Message    string
+
+	mu     sync.Mutex
+	tracer *Tracer
+	ended  bool
+}
+
+// SetAttribute records a key/value pair on the span.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Attributes[key] = fmt.Sprint(value)
+	s.mu.Unlock()
+}
+
+// SetError marks the span as failed if err is non nil, and records its grpc code.
+func (s *Span) SetError(err error) {
+	if s == nil {
+		return
+	}
+	code := status.Code(err)
+	s.mu.Lock()
+	s.Attributes["rpc.grpc.status_code"] = code.String()
+	if err != nil {
+		s.Err = true
+		s.Message = err.Error()
+	}
+	s.mu.Unlock()
+}
+
+// Fail marks the span as failed with the given message.
+func (s *Span) Fail(message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.Err = true
+	s.Message = message
+	s.mu.Unlock()
+}
+
+// Finish ends the span and hands it to the exporter, it is safe to call more than once.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mu.Unlock()
+
+	if s.Context.Sampled {
+		s.tracer.export(s)
+	}
+}
+
+// Exporter receives finished spans.
+type Exporter interface {
+	Export(span *Span)
+	Close() error
+}
+
+// Tracer creates spans and hands them to its exporter.
+type Tracer struct {
+	mu       sync.RWMutex
+	exporter Exporter
+}
+
+// New creates a tracer with no exporter, spans are propagated but not recorded.
+func New() *Tracer {
+	return &Tracer{}
+}
+
+// DefaultTracer is the tracer used by the package level helpers.
+var DefaultTracer = New()
+
+// SetExporter replaces the exporter, returning the previous one.
+func (t *Tracer) SetExporter(exporter Exporter) Exporter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	previous := t.exporter
+	t.exporter = exporter
+	return previous
+}
+
+// Close closes the current exporter, flushing anything it has buffered.
+func (t *Tracer) Close() error {
+	previous := t.SetExporter(nil)
+	if previous == nil {
+		return nil
+	}
+	return previous.Close()
+}
+
+func (t *Tracer) export(span *Span) {
+	t.mu.RLock()
+	exporter := t.exporter
+	t.mu.RUnlock()
+	if exporter != nil {
+		exporter.Export(span)
+	}
+}
+
+type spanKey struct{}
+
+// SpanFromContext returns the current span, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+type remoteKey struct{}
+
+// ContextWithRemote records a parent span context received from another process.
+func ContextWithRemote(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// FromIncomingContext picks up the traceparent from incoming grpc metadata, if any.
+func FromIncomingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md[TraceParentHeader]
+	if len(values) == 0 {
+		return ctx
+	}
+	sc, err := ParseTraceParent(values[0])
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemote(ctx, sc)
+}
+
+// ToOutgoingContext adds the current span as a traceparent on outgoing grpc metadata.
+func ToOutgoingContext(ctx context.Context) context.Context {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, TraceParentHeader, span.Context.TraceParent())
+}
+
+// Start begins a span as a child of whatever span or remote parent is in the context.
+func (t *Tracer) Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	span := &Span{
+		Name:       name,
+		Kind:       kind,
+		Start:      time.Now(),
+		Attributes: map[string]string{},
+		tracer:     t,
+	}
+
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.Context.TraceID = parent.Context.TraceID
+		span.Context.Sampled = parent.Context.Sampled
+		span.ParentID = parent.Context.SpanID
+	} else if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		span.Context.TraceID = remote.TraceID
+		span.Context.Sampled = remote.Sampled
+		span.ParentID = remote.SpanID
+	} else {
+		rand.Read(span.Context.TraceID[:])
+		span.Context.Sampled = true
+	}
+	rand.Read(span.Context.SpanID[:])
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Start begins a span on the default tracer.
+func Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	return DefaultTracer.Start(ctx, name, kind)
+}
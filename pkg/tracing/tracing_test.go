@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	var cases = []struct {
+		Case    string
+		Value   string
+		WantErr bool
+		Sampled bool
+	}{
+		{Case: "Sampled", Value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", Sampled: true},
+		{Case: "Not Sampled", Value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{Case: "Future Version", Value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", Sampled: true},
+		{Case: "Invalid Version", Value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", WantErr: true},
+		{Case: "Zero Trace", Value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", WantErr: true},
+		{Case: "Upper Case", Value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", WantErr: true},
+		{Case: "Short", Value: "00-4bf92f35-00f067aa0ba902b7-01", WantErr: true},
+		{Case: "Garbage", Value: "nope", WantErr: true},
+	}
+
+	for n, c := range cases {
+		sc, err := ParseTraceParent(c.Value)
+		if err != nil {
+			if !c.WantErr {
+				t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			}
+			continue
+		}
+		if c.WantErr {
+			t.Errorf("Case: %d: %s: Expected error, none reported.", n, c.Case)
+			continue
+		}
+		if sc.Sampled != c.Sampled {
+			t.Errorf("Case: %d: %s: Sampled = %v, want %v", n, c.Case, sc.Sampled, c.Sampled)
+		}
+		if !strings.HasPrefix(c.Value, "00-") {
+			continue
+		}
+		if sc.TraceParent() != c.Value {
+			t.Errorf("Case: %d: %s: Round trip gave %s", n, c.Case, sc.TraceParent())
+		}
+	}
+}
+
+func TestTracer_ParentChild(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	tracer := New()
+	tracer.SetExporter(NewWriterExporter(buf))
+
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceParentHeader, parent))
+	ctx = FromIncomingContext(ctx)
+
+	ctx, server := tracer.Start(ctx, "Math/AddNumber", KindServer)
+	_, child := tracer.Start(ctx, "cache.get", KindClient)
+	child.SetAttribute("cache.result", "miss")
+	child.Fail("cache miss")
+	child.Finish()
+	server.SetError(errors.New("boom"))
+	server.Finish()
+	server.Finish()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 spans, got %d: %s", len(lines), buf.String())
+	}
+	spans := make([]otlpSpan, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &spans[i]); err != nil {
+			t.Fatalf("Unable to decode span %d: %s", i, err.Error())
+		}
+	}
+
+	if spans[0].Name != "cache.get" || spans[1].Name != "Math/AddNumber" {
+		t.Fatalf("Unexpected span order: %s, %s", spans[0].Name, spans[1].Name)
+	}
+	for _, span := range spans {
+		if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("%s: trace id %s was not propagated", span.Name, span.TraceID)
+		}
+		if span.Status.Code != 2 {
+			t.Errorf("%s: expected error status, got %d", span.Name, span.Status.Code)
+		}
+	}
+	if spans[1].ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("Server span parent = %s, want remote span", spans[1].ParentSpanID)
+	}
+	if spans[0].ParentSpanID != spans[1].SpanID {
+		t.Errorf("Child span parent = %s, want %s", spans[0].ParentSpanID, spans[1].SpanID)
+	}
+	if len(spans[0].Attributes) != 1 || spans[0].Attributes[0].Value.StringValue != "miss" {
+		t.Errorf("Unexpected child attributes: %v", spans[0].Attributes)
+	}
+}
+
+func TestTracer_Unsampled(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	tracer := New()
+	tracer.SetExporter(NewWriterExporter(buf))
+
+	sc, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	_, span := tracer.Start(ContextWithRemote(context.Background(), sc), "Math/AddNumber", KindServer)
+	span.Finish()
+
+	if buf.Len() != 0 {
+		t.Errorf("Unsampled span was exported: %s", buf.String())
+	}
+}
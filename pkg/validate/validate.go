@@ -0,0 +1,87 @@
+// Package validate holds the one set of rules the math methods check their operands and
+// results against.  Zero is a valid operand for every method except as the divisor, NaN
+// and the infinities are refused going in or coming out unless the policy allows them.
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Request checks the operands of a call to method.  A violation is an InvalidArgument
+// status carrying a BadRequest with a field violation for each operand at fault.
+func Request(policy config.Validation, method string, in *pb.MathRequest) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, operand := range []struct {
+		field string
+		value float64
+	}{{"number1", in.Number1}, {"number2", in.Number2}} {
+		if Refused(policy, operand.value) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       operand.field,
+				Description: fmt.Sprintf("%v is not allowed", operand.value),
+			})
+		}
+	}
+	if method == "DevideNumber" && in.Number2 == 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "number2",
+			Description: "cannot divide by zero",
+		})
+	}
+	return invalid(violations)
+}
+
+// Result checks what a call returned.  The operands together are what produced an
+// unacceptable result, so the violation is reported against both of them.
+func Result(policy config.Validation, out *pb.MathResponse) error {
+	if !Refused(policy, out.Result) {
+		return nil
+	}
+	return Violations(fmt.Sprintf("the result %v is not allowed", out.Result), "number1", "number2")
+}
+
+// Violation is the InvalidArgument status for a single field that is wrong, for checks that
+// belong to one method rather than the policy.
+func Violation(field, description string) error {
+	return invalid([]*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}})
+}
+
+// Violations is the InvalidArgument status for one problem that several fields share, such
+// as a result that no single operand is to blame for.
+func Violations(description string, fields ...string) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+	for n, field := range fields {
+		violations[n] = &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+	}
+	return invalid(violations)
+}
+
+// Refused reports whether policy refuses value, for methods whose operands are not a
+// MathRequest.
+func Refused(policy config.Validation, value float64) bool {
+	return math.IsNaN(value) && !policy.AllowNaN || math.IsInf(value, 0) && !policy.AllowInfinity
+}
+
+// invalid turns violations into an InvalidArgument status, nil if there are none.
+func invalid(violations []*errdetails.BadRequest_FieldViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	problems := make([]string, len(violations))
+	for n, v := range violations {
+		problems[n] = v.Field + ": " + v.Description
+	}
+	s := status.New(codes.InvalidArgument, "Invalid Request: "+strings.Join(problems, "; "))
+	if detailed, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		s = detailed
+	}
+	return s.Err()
+}
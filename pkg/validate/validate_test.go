@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mangeshhendre/mathsvc/pkg/config"
+	pb "github.com/mangeshhendre/models/services_math_v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fields returns the fields an error's BadRequest details blame, nil if there are none.
+func fields(err error) []string {
+	var fields []string
+	for _, detail := range status.Convert(err).Details() {
+		if request, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range request.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestRequest(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	var cases = []struct {
+		Case       string
+		Policy     config.Validation
+		Method     string
+		Number1    float64
+		Number2    float64
+		WantFields []string
+	}{
+		{Case: "Add zero", Method: "AddNumber", Number1: 0, Number2: 5},
+		{Case: "Multiply by zero", Method: "MultiplyNumber", Number1: 5, Number2: 0},
+		{Case: "Divide zero", Method: "DevideNumber", Number1: 0, Number2: 5},
+		{Case: "Divide by zero", Method: "DevideNumber", Number1: 5, Number2: 0, WantFields: []string{"number2"}},
+		{Case: "Divide by negative zero", Method: "DevideNumber", Number1: 5, Number2: math.Copysign(0, -1), WantFields: []string{"number2"}},
+		{Case: "Divide by zero allowing everything", Policy: config.Validation{AllowNaN: true, AllowInfinity: true}, Method: "DevideNumber", Number1: 5, WantFields: []string{"number2"}},
+		{Case: "NaN", Method: "AddNumber", Number1: nan, Number2: 1, WantFields: []string{"number1"}},
+		{Case: "NaN allowed", Policy: config.Validation{AllowNaN: true}, Method: "AddNumber", Number1: nan, Number2: 1},
+		{Case: "Infinity", Method: "MultiplyNumber", Number1: 1, Number2: -inf, WantFields: []string{"number2"}},
+		{Case: "Infinity allowed", Policy: config.Validation{AllowInfinity: true}, Method: "MultiplyNumber", Number1: 1, Number2: -inf},
+		{Case: "Both", Method: "DevideNumber", Number1: nan, Number2: inf, WantFields: []string{"number1", "number2"}},
+	}
+	for n, c := range cases {
+		err := Request(c.Policy, c.Method, &pb.MathRequest{Number1: c.Number1, Number2: c.Number2})
+		if c.WantFields == nil {
+			if err != nil {
+				t.Errorf("Case: %d: %s: Unexpected Error: %s", n, c.Case, err.Error())
+			}
+			continue
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Case: %d: %s: Got %v, want InvalidArgument", n, c.Case, err)
+		}
+		if got := fields(err); !reflect.DeepEqual(got, c.WantFields) {
+			t.Errorf("Case: %d: %s: Got fields %v, want %v", n, c.Case, got, c.WantFields)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	var cases = []struct {
+		Case    string
+		Policy  config.Validation
+		Result  float64
+		WantErr bool
+	}{
+		{Case: "Finite", Result: 7},
+		{Case: "Zero", Result: 0},
+		{Case: "Overflow", Result: math.Inf(1), WantErr: true},
+		{Case: "Overflow allowed", Policy: config.Validation{AllowInfinity: true}, Result: math.Inf(1)},
+		{Case: "NaN", Policy: config.Validation{AllowInfinity: true}, Result: math.NaN(), WantErr: true},
+		{Case: "NaN allowed", Policy: config.Validation{AllowNaN: true}, Result: math.NaN()},
+	}
+	for n, c := range cases {
+		err := Result(c.Policy, &pb.MathResponse{Result: c.Result})
+		if (err != nil) != c.WantErr {
+			t.Errorf("Case: %d: %s: Got %v, want error %t", n, c.Case, err, c.WantErr)
+			continue
+		}
+		if c.WantErr && !reflect.DeepEqual(fields(err), []string{"number1", "number2"}) {
+			t.Errorf("Case: %d: %s: Got fields %v, want both operands", n, c.Case, fields(err))
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package proto holds the protocol buffer definitions of the services mathsvc serves beside
+// Math, which lives in github.com/mangeshhendre/models, and the code generated from them.
+//
+// Regenerate with go generate, which needs protoc 3.5 and protoc-gen-go v1.0.0, the version
+// in vendor, on the PATH, and GOOGLEAPIS set to a checkout of github.com/googleapis/googleapis
+// for google/rpc/status.proto.
+package proto
+
+//go:generate protoc --go_out=plugins=grpc:. services_fault_v1/fault_v1.proto
+//go:generate protoc -I . -I $GOOGLEAPIS --go_out=plugins=grpc,Mgoogle/rpc/status.proto=google.golang.org/genproto/googleapis/rpc/status:. services_batch_v1/batch_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_aggregate_v1/aggregate_v1.proto
+//go:generate protoc -I . -I $GOOGLEAPIS --go_out=plugins=grpc,Mgoogle/rpc/status.proto=google.golang.org/genproto/googleapis/rpc/status:. services_session_v1/session_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_expression_v1/expression_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_precise_v1/precise_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_money_v1/money_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_integer_v1/integer_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_rational_v1/rational_v1.proto
+//go:generate protoc --go_out=plugins=grpc:. services_complex_v1/complex_v1.proto
@@ -0,0 +1,508 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_aggregate_v1/aggregate_v1.proto
+
+/*
+Package services_aggregate_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_aggregate_v1/aggregate_v1.proto
+
+It has these top-level messages:
+
+	AggregateNumber
+	AggregateResponse
+*/
+package services_aggregate_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type AggregateNumber struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *AggregateNumber) Reset()                    { *m = AggregateNumber{} }
+func (m *AggregateNumber) String() string            { return proto.CompactTextString(m) }
+func (*AggregateNumber) ProtoMessage()               {}
+func (*AggregateNumber) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *AggregateNumber) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type AggregateResponse struct {
+	Result float64 `protobuf:"fixed64,1,opt,name=result" json:"result,omitempty"`
+	// count is how many numbers were folded.
+	Count int64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	// has_nan says a NaN was folded in, which the policy allowed.  The result is then NaN.
+	HasNan bool `protobuf:"varint,3,opt,name=has_nan,json=hasNan" json:"has_nan,omitempty"`
+	// has_infinity says an infinity was folded in, which the policy allowed.
+	HasInfinity bool `protobuf:"varint,4,opt,name=has_infinity,json=hasInfinity" json:"has_infinity,omitempty"`
+}
+
+func (m *AggregateResponse) Reset()                    { *m = AggregateResponse{} }
+func (m *AggregateResponse) String() string            { return proto.CompactTextString(m) }
+func (*AggregateResponse) ProtoMessage()               {}
+func (*AggregateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *AggregateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *AggregateResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AggregateResponse) GetHasNan() bool {
+	if m != nil {
+		return m.HasNan
+	}
+	return false
+}
+
+func (m *AggregateResponse) GetHasInfinity() bool {
+	if m != nil {
+		return m.HasInfinity
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*AggregateNumber)(nil), "services.luggage.v1.AggregateNumber")
+	proto.RegisterType((*AggregateResponse)(nil), "services.luggage.v1.AggregateResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathAggregate service
+
+type MathAggregateClient interface {
+	// Sum adds the numbers with compensated (Neumaier) summation, 0 for none.
+	Sum(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_SumClient, error)
+	// Product multiplies the numbers, 1 for none.
+	Product(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_ProductClient, error)
+	// Min is the smallest number, it needs at least one.
+	Min(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MinClient, error)
+	// Max is the largest number, it needs at least one.
+	Max(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MaxClient, error)
+	// Mean is the arithmetic mean of the numbers, it needs at least one.
+	Mean(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MeanClient, error)
+}
+
+type mathAggregateClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathAggregateClient(cc *grpc.ClientConn) MathAggregateClient {
+	return &mathAggregateClient{cc}
+}
+
+func (c *mathAggregateClient) Sum(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_SumClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathAggregate_serviceDesc.Streams[0], c.cc, "/services.luggage.v1.MathAggregate/Sum", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathAggregateSumClient{stream}
+	return x, nil
+}
+
+type MathAggregate_SumClient interface {
+	Send(*AggregateNumber) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type mathAggregateSumClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathAggregateSumClient) Send(m *AggregateNumber) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathAggregateSumClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mathAggregateClient) Product(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_ProductClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathAggregate_serviceDesc.Streams[1], c.cc, "/services.luggage.v1.MathAggregate/Product", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathAggregateProductClient{stream}
+	return x, nil
+}
+
+type MathAggregate_ProductClient interface {
+	Send(*AggregateNumber) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type mathAggregateProductClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathAggregateProductClient) Send(m *AggregateNumber) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathAggregateProductClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mathAggregateClient) Min(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MinClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathAggregate_serviceDesc.Streams[2], c.cc, "/services.luggage.v1.MathAggregate/Min", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathAggregateMinClient{stream}
+	return x, nil
+}
+
+type MathAggregate_MinClient interface {
+	Send(*AggregateNumber) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type mathAggregateMinClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathAggregateMinClient) Send(m *AggregateNumber) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathAggregateMinClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mathAggregateClient) Max(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MaxClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathAggregate_serviceDesc.Streams[3], c.cc, "/services.luggage.v1.MathAggregate/Max", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathAggregateMaxClient{stream}
+	return x, nil
+}
+
+type MathAggregate_MaxClient interface {
+	Send(*AggregateNumber) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type mathAggregateMaxClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathAggregateMaxClient) Send(m *AggregateNumber) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathAggregateMaxClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mathAggregateClient) Mean(ctx context.Context, opts ...grpc.CallOption) (MathAggregate_MeanClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathAggregate_serviceDesc.Streams[4], c.cc, "/services.luggage.v1.MathAggregate/Mean", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathAggregateMeanClient{stream}
+	return x, nil
+}
+
+type MathAggregate_MeanClient interface {
+	Send(*AggregateNumber) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type mathAggregateMeanClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathAggregateMeanClient) Send(m *AggregateNumber) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathAggregateMeanClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for MathAggregate service
+
+type MathAggregateServer interface {
+	// Sum adds the numbers with compensated (Neumaier) summation, 0 for none.
+	Sum(MathAggregate_SumServer) error
+	// Product multiplies the numbers, 1 for none.
+	Product(MathAggregate_ProductServer) error
+	// Min is the smallest number, it needs at least one.
+	Min(MathAggregate_MinServer) error
+	// Max is the largest number, it needs at least one.
+	Max(MathAggregate_MaxServer) error
+	// Mean is the arithmetic mean of the numbers, it needs at least one.
+	Mean(MathAggregate_MeanServer) error
+}
+
+func RegisterMathAggregateServer(s *grpc.Server, srv MathAggregateServer) {
+	s.RegisterService(&_MathAggregate_serviceDesc, srv)
+}
+
+func _MathAggregate_Sum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathAggregateServer).Sum(&mathAggregateSumServer{stream})
+}
+
+type MathAggregate_SumServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*AggregateNumber, error)
+	grpc.ServerStream
+}
+
+type mathAggregateSumServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathAggregateSumServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathAggregateSumServer) Recv() (*AggregateNumber, error) {
+	m := new(AggregateNumber)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MathAggregate_Product_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathAggregateServer).Product(&mathAggregateProductServer{stream})
+}
+
+type MathAggregate_ProductServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*AggregateNumber, error)
+	grpc.ServerStream
+}
+
+type mathAggregateProductServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathAggregateProductServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathAggregateProductServer) Recv() (*AggregateNumber, error) {
+	m := new(AggregateNumber)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MathAggregate_Min_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathAggregateServer).Min(&mathAggregateMinServer{stream})
+}
+
+type MathAggregate_MinServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*AggregateNumber, error)
+	grpc.ServerStream
+}
+
+type mathAggregateMinServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathAggregateMinServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathAggregateMinServer) Recv() (*AggregateNumber, error) {
+	m := new(AggregateNumber)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MathAggregate_Max_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathAggregateServer).Max(&mathAggregateMaxServer{stream})
+}
+
+type MathAggregate_MaxServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*AggregateNumber, error)
+	grpc.ServerStream
+}
+
+type mathAggregateMaxServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathAggregateMaxServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathAggregateMaxServer) Recv() (*AggregateNumber, error) {
+	m := new(AggregateNumber)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _MathAggregate_Mean_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathAggregateServer).Mean(&mathAggregateMeanServer{stream})
+}
+
+type MathAggregate_MeanServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*AggregateNumber, error)
+	grpc.ServerStream
+}
+
+type mathAggregateMeanServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathAggregateMeanServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathAggregateMeanServer) Recv() (*AggregateNumber, error) {
+	m := new(AggregateNumber)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _MathAggregate_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathAggregate",
+	HandlerType: (*MathAggregateServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Sum",
+			Handler:       _MathAggregate_Sum_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Product",
+			Handler:       _MathAggregate_Product_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Min",
+			Handler:       _MathAggregate_Min_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Max",
+			Handler:       _MathAggregate_Max_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Mean",
+			Handler:       _MathAggregate_Mean_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "services_aggregate_v1/aggregate_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_aggregate_v1/aggregate_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 273 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0x4d, 0x4b, 0xc3, 0x40,
+	0x10, 0x86, 0x5d, 0x53, 0x53, 0x19, 0x15, 0x71, 0xfd, 0x68, 0xf0, 0x14, 0x83, 0x68, 0x4e, 0x91,
+	0xea, 0x2f, 0xd0, 0x9b, 0x87, 0x14, 0x89, 0x87, 0x8a, 0x1e, 0xc2, 0x34, 0x8e, 0x9b, 0x40, 0xba,
+	0x29, 0xfb, 0x11, 0xf4, 0xe0, 0x8f, 0xf4, 0x1f, 0x49, 0xdb, 0xa4, 0x88, 0x94, 0xde, 0x72, 0x7c,
+	0x67, 0x1e, 0x1e, 0xe6, 0x85, 0x81, 0x50, 0x93, 0xaa, 0x8b, 0x8c, 0x74, 0x8a, 0x42, 0x28, 0x12,
+	0x68, 0x28, 0xad, 0x87, 0x37, 0x7f, 0x43, 0x34, 0x53, 0x95, 0xa9, 0xf8, 0x71, 0x4b, 0x46, 0xa5,
+	0x15, 0x02, 0x05, 0x45, 0xf5, 0x30, 0xb8, 0x86, 0xc3, 0xfb, 0x16, 0x1d, 0xd9, 0xe9, 0x84, 0x14,
+	0x3f, 0x81, 0x9d, 0x1a, 0x4b, 0x4b, 0x1e, 0xf3, 0x59, 0xc8, 0x92, 0x65, 0x08, 0xbe, 0xe1, 0x68,
+	0x05, 0x26, 0xa4, 0x67, 0x95, 0xd4, 0xc4, 0xcf, 0xc0, 0x55, 0xa4, 0x6d, 0x69, 0x1a, 0xb6, 0x49,
+	0x73, 0x45, 0x56, 0x59, 0x69, 0xbc, 0x6d, 0x9f, 0x85, 0x4e, 0xb2, 0x0c, 0x7c, 0x00, 0xfd, 0x1c,
+	0x75, 0x2a, 0x51, 0x7a, 0x8e, 0xcf, 0xc2, 0xdd, 0xc4, 0xcd, 0x51, 0x8f, 0x50, 0xf2, 0x0b, 0xd8,
+	0x9f, 0x2f, 0x0a, 0xf9, 0x51, 0xc8, 0xc2, 0x7c, 0x79, 0xbd, 0xc5, 0x76, 0x2f, 0x47, 0xfd, 0xd8,
+	0x8c, 0x6e, 0x7f, 0x1c, 0x38, 0x88, 0xd1, 0xe4, 0xab, 0x1b, 0xf8, 0x18, 0x9c, 0x67, 0x3b, 0xe5,
+	0x97, 0xd1, 0x9a, 0x5a, 0xd1, 0xbf, 0x4e, 0xe7, 0x57, 0x9b, 0xa9, 0xb6, 0x50, 0xb0, 0x15, 0x32,
+	0xfe, 0x06, 0xfd, 0x27, 0x55, 0xbd, 0xdb, 0xcc, 0x74, 0x20, 0x1f, 0x83, 0x13, 0x17, 0xb2, 0x23,
+	0x31, 0x7e, 0x76, 0x20, 0x7e, 0x81, 0x5e, 0x4c, 0xd8, 0xc1, 0xc9, 0x0f, 0x83, 0xd7, 0xd3, 0xb5,
+	0xcf, 0x3b, 0x71, 0x17, 0x0f, 0x7b, 0xf7, 0x3b, 0x00, 0xf1, 0xfe, 0xe6, 0x5b, 0xdc, 0x02, 0x00,
+	0x00,
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_batch_v1/batch_v1.proto
+
+/*
+Package services_batch_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_batch_v1/batch_v1.proto
+
+It has these top-level messages:
+
+	BatchItem
+	BatchRequest
+	BatchResult
+	BatchResponse
+*/
+package services_batch_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_rpc "google.golang.org/genproto/googleapis/rpc/status"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Operation is the Math method an item is evaluated with.
+type Operation int32
+
+const (
+	Operation_OPERATION_UNSPECIFIED Operation = 0
+	Operation_OPERATION_ADD         Operation = 1
+	Operation_OPERATION_MULTIPLY    Operation = 2
+	Operation_OPERATION_DIVIDE      Operation = 3
+)
+
+var Operation_name = map[int32]string{
+	0: "OPERATION_UNSPECIFIED",
+	1: "OPERATION_ADD",
+	2: "OPERATION_MULTIPLY",
+	3: "OPERATION_DIVIDE",
+}
+var Operation_value = map[string]int32{
+	"OPERATION_UNSPECIFIED": 0,
+	"OPERATION_ADD":         1,
+	"OPERATION_MULTIPLY":    2,
+	"OPERATION_DIVIDE":      3,
+}
+
+func (x Operation) String() string {
+	return proto.EnumName(Operation_name, int32(x))
+}
+func (Operation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// BatchItem is one operation and its operands.
+type BatchItem struct {
+	Operation Operation `protobuf:"varint,1,opt,name=operation,enum=services.luggage.v1.Operation" json:"operation,omitempty"`
+	Number1   float64   `protobuf:"fixed64,2,opt,name=number1" json:"number1,omitempty"`
+	Number2   float64   `protobuf:"fixed64,3,opt,name=number2" json:"number2,omitempty"`
+}
+
+func (m *BatchItem) Reset()                    { *m = BatchItem{} }
+func (m *BatchItem) String() string            { return proto.CompactTextString(m) }
+func (*BatchItem) ProtoMessage()               {}
+func (*BatchItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BatchItem) GetOperation() Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return Operation_OPERATION_UNSPECIFIED
+}
+
+func (m *BatchItem) GetNumber1() float64 {
+	if m != nil {
+		return m.Number1
+	}
+	return 0
+}
+
+func (m *BatchItem) GetNumber2() float64 {
+	if m != nil {
+		return m.Number2
+	}
+	return 0
+}
+
+type BatchRequest struct {
+	Items []*BatchItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *BatchRequest) Reset()                    { *m = BatchRequest{} }
+func (m *BatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*BatchRequest) ProtoMessage()               {}
+func (*BatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *BatchRequest) GetItems() []*BatchItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// BatchResult answers the item at the same index.
+type BatchResult struct {
+	// result is only meaningful when there is no error.
+	Result float64 `protobuf:"fixed64,1,opt,name=result" json:"result,omitempty"`
+	// error is why the item failed, with the same code and details the Math method would
+	// have returned for it.
+	Error *google_rpc.Status `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// cache is hit, miss or bypass, what the x-cache header says for a single call.
+	Cache string `protobuf:"bytes,3,opt,name=cache" json:"cache,omitempty"`
+}
+
+func (m *BatchResult) Reset()                    { *m = BatchResult{} }
+func (m *BatchResult) String() string            { return proto.CompactTextString(m) }
+func (*BatchResult) ProtoMessage()               {}
+func (*BatchResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *BatchResult) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *BatchResult) GetError() *google_rpc.Status {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *BatchResult) GetCache() string {
+	if m != nil {
+		return m.Cache
+	}
+	return ""
+}
+
+type BatchResponse struct {
+	Results []*BatchResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *BatchResponse) Reset()                    { *m = BatchResponse{} }
+func (m *BatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*BatchResponse) ProtoMessage()               {}
+func (*BatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *BatchResponse) GetResults() []*BatchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BatchItem)(nil), "services.luggage.v1.BatchItem")
+	proto.RegisterType((*BatchRequest)(nil), "services.luggage.v1.BatchRequest")
+	proto.RegisterType((*BatchResult)(nil), "services.luggage.v1.BatchResult")
+	proto.RegisterType((*BatchResponse)(nil), "services.luggage.v1.BatchResponse")
+	proto.RegisterEnum("services.luggage.v1.Operation", Operation_name, Operation_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathBatch service
+
+type MathBatchClient interface {
+	// Batch evaluates every item and answers them in the order they were given.  One item
+	// failing does not fail the others, only a batch that cannot be run at all fails.
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+}
+
+type mathBatchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathBatchClient(cc *grpc.ClientConn) MathBatchClient {
+	return &mathBatchClient{cc}
+}
+
+func (c *mathBatchClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathBatch/Batch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathBatch service
+
+type MathBatchServer interface {
+	// Batch evaluates every item and answers them in the order they were given.  One item
+	// failing does not fail the others, only a batch that cannot be run at all fails.
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+}
+
+func RegisterMathBatchServer(s *grpc.Server, srv MathBatchServer) {
+	s.RegisterService(&_MathBatch_serviceDesc, srv)
+}
+
+func _MathBatch_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathBatchServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathBatch/Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathBatchServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathBatch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathBatch",
+	HandlerType: (*MathBatchServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Batch",
+			Handler:    _MathBatch_Batch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_batch_v1/batch_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_batch_v1/batch_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 382 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xdf, 0xaf, 0xd2, 0x30,
+	0x14, 0xc7, 0xef, 0x2e, 0xd9, 0x25, 0x3b, 0x88, 0x19, 0x05, 0x71, 0xf2, 0x60, 0xe6, 0x9e, 0x16,
+	0x1f, 0x4a, 0x98, 0x3e, 0x19, 0x5f, 0xc0, 0xcd, 0x64, 0x91, 0x1f, 0x4b, 0x01, 0x13, 0x4d, 0x0c,
+	0x19, 0x4b, 0x33, 0x48, 0x80, 0xce, 0xb6, 0xe3, 0xcd, 0xff, 0xdd, 0xd8, 0x32, 0x66, 0xa2, 0xf0,
+	0x76, 0x4e, 0xcf, 0xf7, 0x9c, 0xf3, 0xe9, 0xb7, 0x05, 0x57, 0x50, 0x7e, 0xde, 0x67, 0x54, 0x6c,
+	0xb6, 0xa9, 0xcc, 0x76, 0x9b, 0xf3, 0x68, 0x58, 0x05, 0xb8, 0xe0, 0x4c, 0x32, 0xd4, 0xad, 0x14,
+	0xf8, 0x50, 0xe6, 0x79, 0x9a, 0x53, 0x7c, 0x1e, 0x0d, 0x5e, 0xe6, 0x8c, 0xe5, 0x07, 0x3a, 0xe4,
+	0x45, 0x36, 0x14, 0x32, 0x95, 0xa5, 0xd0, 0x6a, 0xef, 0x17, 0x58, 0x93, 0x3f, 0xfd, 0xb1, 0xa4,
+	0x47, 0xf4, 0x11, 0x2c, 0x56, 0x50, 0x9e, 0xca, 0x3d, 0x3b, 0x39, 0x86, 0x6b, 0xf8, 0xcf, 0x83,
+	0xd7, 0xf8, 0x3f, 0xe3, 0xf0, 0xa2, 0x52, 0x91, 0xba, 0x01, 0x39, 0xd0, 0x3c, 0x95, 0xc7, 0x2d,
+	0xe5, 0x23, 0xe7, 0xd1, 0x35, 0x7c, 0x83, 0x54, 0x69, 0x5d, 0x09, 0x9c, 0xc6, 0xdf, 0x95, 0xc0,
+	0x0b, 0xe1, 0x99, 0x5a, 0x4f, 0xe8, 0xcf, 0x92, 0x0a, 0x89, 0xde, 0x83, 0xb9, 0x97, 0xf4, 0x28,
+	0x1c, 0xc3, 0x6d, 0xf8, 0xad, 0x1b, 0xdb, 0xaf, 0xc0, 0x44, 0x8b, 0x3d, 0x0a, 0xad, 0xcb, 0x14,
+	0x51, 0x1e, 0x24, 0xea, 0xc3, 0x13, 0x57, 0x91, 0xba, 0x83, 0x41, 0x2e, 0x19, 0xf2, 0xc1, 0xa4,
+	0x9c, 0x33, 0xae, 0xf0, 0x5a, 0x01, 0xc2, 0xda, 0x14, 0xcc, 0x8b, 0x0c, 0x2f, 0x95, 0x29, 0x44,
+	0x0b, 0x50, 0x0f, 0xcc, 0x2c, 0xcd, 0x76, 0x54, 0xe1, 0x5a, 0x44, 0x27, 0xde, 0x17, 0x68, 0x57,
+	0x6b, 0x0a, 0x76, 0x12, 0x14, 0x7d, 0x80, 0xa6, 0x1e, 0x5d, 0xf1, 0xba, 0xb7, 0x79, 0x35, 0x1b,
+	0xa9, 0x1a, 0xde, 0xe6, 0x60, 0x5d, 0x5d, 0x44, 0xaf, 0xe0, 0xc5, 0x22, 0x89, 0xc8, 0x78, 0x15,
+	0x2f, 0xe6, 0x9b, 0xf5, 0x7c, 0x99, 0x44, 0x9f, 0xe2, 0xcf, 0x71, 0x14, 0xda, 0x0f, 0xa8, 0x03,
+	0xed, 0xba, 0x34, 0x0e, 0x43, 0xdb, 0x40, 0x7d, 0x40, 0xf5, 0xd1, 0x6c, 0x3d, 0x5d, 0xc5, 0xc9,
+	0xf4, 0x9b, 0xfd, 0x88, 0x7a, 0x60, 0xd7, 0xe7, 0x61, 0xfc, 0x35, 0x0e, 0x23, 0xbb, 0x11, 0xfc,
+	0x00, 0x6b, 0x96, 0xca, 0x9d, 0x82, 0x40, 0x09, 0x98, 0x3a, 0x78, 0x73, 0x8f, 0x54, 0xbd, 0xc5,
+	0xc0, 0xbb, 0x7b, 0x19, 0xe5, 0x80, 0xf7, 0x30, 0xe9, 0x7e, 0xef, 0xfc, 0xf3, 0x25, 0xb7, 0x4f,
+	0xea, 0x73, 0xbd, 0xfb, 0x3d, 0x00, 0x56, 0x3c, 0xc6, 0xc6, 0xae, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,586 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_complex_v1/complex_v1.proto
+
+/*
+Package services_complex_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_complex_v1/complex_v1.proto
+
+It has these top-level messages:
+
+	Complex
+	Polar
+	ComplexRequest
+	ComplexResponse
+*/
+package services_complex_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Complex is real + imaginary·i.  A missing Complex is zero.
+type Complex struct {
+	Real      float64 `protobuf:"fixed64,1,opt,name=real" json:"real,omitempty"`
+	Imaginary float64 `protobuf:"fixed64,2,opt,name=imaginary" json:"imaginary,omitempty"`
+}
+
+func (m *Complex) Reset()                    { *m = Complex{} }
+func (m *Complex) String() string            { return proto.CompactTextString(m) }
+func (*Complex) ProtoMessage()               {}
+func (*Complex) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Complex) GetReal() float64 {
+	if m != nil {
+		return m.Real
+	}
+	return 0
+}
+
+func (m *Complex) GetImaginary() float64 {
+	if m != nil {
+		return m.Imaginary
+	}
+	return 0
+}
+
+// Polar is magnitude·e^(phase·i), the phase in radians in [-π, π].
+type Polar struct {
+	Magnitude float64 `protobuf:"fixed64,1,opt,name=magnitude" json:"magnitude,omitempty"`
+	Phase     float64 `protobuf:"fixed64,2,opt,name=phase" json:"phase,omitempty"`
+}
+
+func (m *Polar) Reset()                    { *m = Polar{} }
+func (m *Polar) String() string            { return proto.CompactTextString(m) }
+func (*Polar) ProtoMessage()               {}
+func (*Polar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Polar) GetMagnitude() float64 {
+	if m != nil {
+		return m.Magnitude
+	}
+	return 0
+}
+
+func (m *Polar) GetPhase() float64 {
+	if m != nil {
+		return m.Phase
+	}
+	return 0
+}
+
+type ComplexRequest struct {
+	Number1 *Complex `protobuf:"bytes,1,opt,name=number1" json:"number1,omitempty"`
+	Number2 *Complex `protobuf:"bytes,2,opt,name=number2" json:"number2,omitempty"`
+}
+
+func (m *ComplexRequest) Reset()                    { *m = ComplexRequest{} }
+func (m *ComplexRequest) String() string            { return proto.CompactTextString(m) }
+func (*ComplexRequest) ProtoMessage()               {}
+func (*ComplexRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ComplexRequest) GetNumber1() *Complex {
+	if m != nil {
+		return m.Number1
+	}
+	return nil
+}
+
+func (m *ComplexRequest) GetNumber2() *Complex {
+	if m != nil {
+		return m.Number2
+	}
+	return nil
+}
+
+type ComplexResponse struct {
+	Result *Complex `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	Polar  *Polar   `protobuf:"bytes,2,opt,name=polar" json:"polar,omitempty"`
+}
+
+func (m *ComplexResponse) Reset()                    { *m = ComplexResponse{} }
+func (m *ComplexResponse) String() string            { return proto.CompactTextString(m) }
+func (*ComplexResponse) ProtoMessage()               {}
+func (*ComplexResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ComplexResponse) GetResult() *Complex {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *ComplexResponse) GetPolar() *Polar {
+	if m != nil {
+		return m.Polar
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Complex)(nil), "services.luggage.v1.Complex")
+	proto.RegisterType((*Polar)(nil), "services.luggage.v1.Polar")
+	proto.RegisterType((*ComplexRequest)(nil), "services.luggage.v1.ComplexRequest")
+	proto.RegisterType((*ComplexResponse)(nil), "services.luggage.v1.ComplexResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathComplex service
+
+type MathComplexClient interface {
+	Add(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	Subtract(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	Multiply(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Divide fails with InvalidArgument when number2 is zero.
+	Divide(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Pow is number1 raised to number2, on the principal branch.
+	Pow(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Abs is the magnitude of number1, a real result.
+	Abs(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Phase is the argument of number1 in radians, in [-π, π], a real result.
+	Phase(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	Conjugate(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	Exp(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Log is the principal natural logarithm of number1.
+	Log(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	// Sqrt is the principal square root of number1.
+	Sqrt(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+}
+
+type mathComplexClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathComplexClient(cc *grpc.ClientConn) MathComplexClient {
+	return &mathComplexClient{cc}
+}
+
+func (c *mathComplexClient) Add(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Subtract(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Subtract", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Multiply(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Multiply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Divide(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Divide", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Pow(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Pow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Abs(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Abs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Phase(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Phase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Conjugate(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Conjugate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Exp(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Exp", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Log(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Log", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathComplexClient) Sqrt(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*ComplexResponse, error) {
+	out := new(ComplexResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathComplex/Sqrt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathComplex service
+
+type MathComplexServer interface {
+	Add(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	Subtract(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	Multiply(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Divide fails with InvalidArgument when number2 is zero.
+	Divide(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Pow is number1 raised to number2, on the principal branch.
+	Pow(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Abs is the magnitude of number1, a real result.
+	Abs(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Phase is the argument of number1 in radians, in [-π, π], a real result.
+	Phase(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	Conjugate(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	Exp(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Log is the principal natural logarithm of number1.
+	Log(context.Context, *ComplexRequest) (*ComplexResponse, error)
+	// Sqrt is the principal square root of number1.
+	Sqrt(context.Context, *ComplexRequest) (*ComplexResponse, error)
+}
+
+func RegisterMathComplexServer(s *grpc.Server, srv MathComplexServer) {
+	s.RegisterService(&_MathComplex_serviceDesc, srv)
+}
+
+func _MathComplex_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Add(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Subtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Subtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Subtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Subtract(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Multiply(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Divide(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Pow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Pow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Pow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Pow(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Abs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Abs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Abs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Abs(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Phase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Phase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Phase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Phase(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Conjugate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Conjugate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Conjugate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Conjugate(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Exp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Exp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Exp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Exp(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Log(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Log",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Log(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathComplex_Sqrt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathComplexServer).Sqrt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathComplex/Sqrt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathComplexServer).Sqrt(ctx, req.(*ComplexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathComplex_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathComplex",
+	HandlerType: (*MathComplexServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _MathComplex_Add_Handler,
+		},
+		{
+			MethodName: "Subtract",
+			Handler:    _MathComplex_Subtract_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _MathComplex_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _MathComplex_Divide_Handler,
+		},
+		{
+			MethodName: "Pow",
+			Handler:    _MathComplex_Pow_Handler,
+		},
+		{
+			MethodName: "Abs",
+			Handler:    _MathComplex_Abs_Handler,
+		},
+		{
+			MethodName: "Phase",
+			Handler:    _MathComplex_Phase_Handler,
+		},
+		{
+			MethodName: "Conjugate",
+			Handler:    _MathComplex_Conjugate_Handler,
+		},
+		{
+			MethodName: "Exp",
+			Handler:    _MathComplex_Exp_Handler,
+		},
+		{
+			MethodName: "Log",
+			Handler:    _MathComplex_Log_Handler,
+		},
+		{
+			MethodName: "Sqrt",
+			Handler:    _MathComplex_Sqrt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_complex_v1/complex_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_complex_v1/complex_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 363 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x4f, 0x4f, 0xea, 0x40,
+	0x14, 0xc5, 0x5f, 0x81, 0xc2, 0xe3, 0x92, 0xbc, 0x97, 0x0c, 0x9a, 0x10, 0xc2, 0xc2, 0x54, 0x16,
+	0xae, 0xaa, 0x54, 0xe3, 0x86, 0x15, 0xa2, 0x3b, 0x49, 0x48, 0xd1, 0x68, 0xdc, 0x90, 0x29, 0x9d,
+	0x94, 0x9a, 0xb6, 0x33, 0xcc, 0x9f, 0x0a, 0x3b, 0xbf, 0x99, 0x5f, 0xcd, 0xd0, 0x3f, 0xe9, 0x86,
+	0x10, 0x16, 0xb3, 0x9b, 0xde, 0x9e, 0xf3, 0xbb, 0x33, 0x27, 0xb9, 0x17, 0x86, 0x82, 0xf0, 0x34,
+	0x5c, 0x11, 0xb1, 0x5c, 0xd1, 0x98, 0x45, 0x64, 0xbb, 0x4c, 0x47, 0xd7, 0xd5, 0xd1, 0x66, 0x9c,
+	0x4a, 0x8a, 0xba, 0xa5, 0xca, 0x8e, 0x54, 0x10, 0xe0, 0x80, 0xd8, 0xe9, 0xc8, 0x1a, 0x43, 0x6b,
+	0x9a, 0x0b, 0x11, 0x82, 0x06, 0x27, 0x38, 0xea, 0x19, 0x17, 0xc6, 0x95, 0xe1, 0x66, 0x67, 0x34,
+	0x80, 0x76, 0x18, 0xe3, 0x20, 0x4c, 0x30, 0xdf, 0xf5, 0x6a, 0xd9, 0x8f, 0xaa, 0x60, 0x8d, 0xc1,
+	0x9c, 0xd3, 0x08, 0xf3, 0xbd, 0x2c, 0xc6, 0x41, 0x12, 0x4a, 0xe5, 0x93, 0xc2, 0x5f, 0x15, 0xd0,
+	0x19, 0x98, 0x6c, 0x8d, 0x05, 0x29, 0x00, 0xf9, 0x87, 0xf5, 0x6d, 0xc0, 0xbf, 0xa2, 0xb5, 0x4b,
+	0x36, 0x8a, 0x08, 0x89, 0xee, 0xa1, 0x95, 0xa8, 0xd8, 0x23, 0x7c, 0x94, 0x41, 0x3a, 0xce, 0xc0,
+	0x3e, 0x70, 0x67, 0xbb, 0x74, 0x95, 0xe2, 0xca, 0xe7, 0xf4, 0x6a, 0xa7, 0xfb, 0x1c, 0x6b, 0x07,
+	0xff, 0xcb, 0x1a, 0x11, 0x8c, 0x26, 0x82, 0xa0, 0x3b, 0x68, 0x72, 0x22, 0x54, 0x24, 0x4f, 0xba,
+	0x41, 0xa1, 0x45, 0x37, 0x60, 0xb2, 0x7d, 0x10, 0x45, 0xfb, 0xfe, 0x41, 0x53, 0x16, 0x95, 0x9b,
+	0x0b, 0x9d, 0x9f, 0x16, 0x74, 0x66, 0x58, 0xae, 0xcb, 0xf0, 0x5d, 0xa8, 0x4f, 0x7c, 0x1f, 0x5d,
+	0x1e, 0x6d, 0x97, 0xc7, 0xd4, 0x1f, 0x1e, 0x17, 0xe5, 0x2f, 0xb1, 0xfe, 0xa0, 0x37, 0xf8, 0xbb,
+	0x50, 0x9e, 0xe4, 0x78, 0x25, 0xb5, 0x83, 0x67, 0x2a, 0x92, 0x21, 0x8b, 0x76, 0x7a, 0xc1, 0xaf,
+	0xd0, 0x7c, 0x0c, 0xd3, 0xd0, 0x27, 0x7a, 0xb1, 0x2e, 0xd4, 0xe7, 0xf4, 0x4b, 0x3b, 0x73, 0xe2,
+	0x09, 0xbd, 0xcc, 0x17, 0x30, 0xe7, 0xfb, 0xd9, 0xd0, 0x4b, 0x7d, 0x87, 0xf6, 0x94, 0x26, 0x9f,
+	0x2a, 0xc0, 0x52, 0x7f, 0xae, 0x4f, 0x5b, 0xa6, 0x9d, 0xf9, 0x4c, 0x03, 0xbd, 0xcc, 0x05, 0x34,
+	0x16, 0x1b, 0xae, 0x77, 0x08, 0x1e, 0xce, 0x3f, 0xba, 0x07, 0xd6, 0xae, 0xd7, 0xcc, 0x96, 0xed,
+	0xed, 0xef, 0x00, 0x28, 0x24, 0x74, 0x95, 0x94, 0x05, 0x00, 0x00,
+}
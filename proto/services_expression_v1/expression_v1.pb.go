@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_expression_v1/expression_v1.proto
+
+/*
+Package services_expression_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_expression_v1/expression_v1.proto
+
+It has these top-level messages:
+
+	EvaluateRequest
+	EvaluateResponse
+*/
+package services_expression_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type EvaluateRequest struct {
+	Expression string             `protobuf:"bytes,1,opt,name=expression" json:"expression,omitempty"`
+	Bindings   map[string]float64 `protobuf:"bytes,2,rep,name=bindings" json:"bindings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+}
+
+func (m *EvaluateRequest) Reset()                    { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string            { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()               {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *EvaluateRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetBindings() map[string]float64 {
+	if m != nil {
+		return m.Bindings
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	Result float64 `protobuf:"fixed64,1,opt,name=result" json:"result,omitempty"`
+	// normalized is the expression as the server reads it, which results are cached by.
+	Normalized string `protobuf:"bytes,2,opt,name=normalized" json:"normalized,omitempty"`
+}
+
+func (m *EvaluateResponse) Reset()                    { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string            { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()               {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *EvaluateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *EvaluateResponse) GetNormalized() string {
+	if m != nil {
+		return m.Normalized
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*EvaluateRequest)(nil), "services.luggage.v1.EvaluateRequest")
+	proto.RegisterType((*EvaluateResponse)(nil), "services.luggage.v1.EvaluateResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathExpression service
+
+type MathExpressionClient interface {
+	// Evaluate computes expression, such as "(3 + 4) * 2 / x", with its variables bound by
+	// bindings.  It knows + - * / and ^ with the usual precedence, unary minus, parentheses,
+	// the constants pi and e, and the functions abs, sqrt, cbrt, exp, ln, log2, log10, floor,
+	// ceil, round, trunc, sin, cos, tan, asin, acos, atan, sinh, cosh, tanh, pow, atan2,
+	// hypot, mod, min and max.  A malformed expression, or one whose evaluation is refused,
+	// fails with InvalidArgument and a BadRequest field violation saying at which offset.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+}
+
+type mathExpressionClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathExpressionClient(cc *grpc.ClientConn) MathExpressionClient {
+	return &mathExpressionClient{cc}
+}
+
+func (c *mathExpressionClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathExpression/Evaluate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathExpression service
+
+type MathExpressionServer interface {
+	// Evaluate computes expression, such as "(3 + 4) * 2 / x", with its variables bound by
+	// bindings.  It knows + - * / and ^ with the usual precedence, unary minus, parentheses,
+	// the constants pi and e, and the functions abs, sqrt, cbrt, exp, ln, log2, log10, floor,
+	// ceil, round, trunc, sin, cos, tan, asin, acos, atan, sinh, cosh, tanh, pow, atan2,
+	// hypot, mod, min and max.  A malformed expression, or one whose evaluation is refused,
+	// fails with InvalidArgument and a BadRequest field violation saying at which offset.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+}
+
+func RegisterMathExpressionServer(s *grpc.Server, srv MathExpressionServer) {
+	s.RegisterService(&_MathExpression_serviceDesc, srv)
+}
+
+func _MathExpression_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathExpressionServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathExpression/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathExpressionServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathExpression_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathExpression",
+	HandlerType: (*MathExpressionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _MathExpression_Evaluate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_expression_v1/expression_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_expression_v1/expression_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 267 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x2a, 0x4e, 0x2d, 0x2a,
+	0xcb, 0x4c, 0x4e, 0x2d, 0x8e, 0x4f, 0xad, 0x28, 0x28, 0x4a, 0x2d, 0x2e, 0xce, 0xcc, 0xcf, 0x8b,
+	0x2f, 0x33, 0xd4, 0x47, 0xe1, 0xe9, 0x15, 0x14, 0xe5, 0x97, 0xe4, 0x0b, 0x09, 0xc3, 0xd4, 0xea,
+	0xe5, 0x94, 0xa6, 0xa7, 0x27, 0xa6, 0xa7, 0xea, 0x95, 0x19, 0x2a, 0xed, 0x63, 0xe4, 0xe2, 0x77,
+	0x2d, 0x4b, 0xcc, 0x29, 0x4d, 0x2c, 0x49, 0x0d, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x92,
+	0xe3, 0xe2, 0x42, 0xe8, 0x97, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x42, 0x12, 0x11, 0xf2, 0xe3,
+	0xe2, 0x48, 0xca, 0xcc, 0x4b, 0xc9, 0xcc, 0x4b, 0x2f, 0x96, 0x60, 0x52, 0x60, 0xd6, 0xe0, 0x36,
+	0x32, 0xd2, 0xc3, 0x62, 0xb6, 0x1e, 0x9a, 0xb9, 0x7a, 0x4e, 0x50, 0x4d, 0xae, 0x79, 0x25, 0x45,
+	0x95, 0x41, 0x70, 0x33, 0xa4, 0xac, 0xb9, 0x78, 0x51, 0xa4, 0x84, 0x04, 0xb8, 0x98, 0xb3, 0x53,
+	0x2b, 0xa1, 0x36, 0x83, 0x98, 0x42, 0x22, 0x5c, 0xac, 0x20, 0xc3, 0x52, 0x25, 0x98, 0x14, 0x18,
+	0x35, 0x18, 0x83, 0x20, 0x1c, 0x2b, 0x26, 0x0b, 0x46, 0x25, 0x2f, 0x2e, 0x01, 0x84, 0x3d, 0xc5,
+	0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x42, 0x62, 0x5c, 0x6c, 0x45, 0xa9, 0xc5, 0xa5, 0x39, 0x25, 0x60,
+	0x23, 0x18, 0x83, 0xa0, 0x3c, 0x90, 0xc7, 0xf2, 0xf2, 0x8b, 0x72, 0x13, 0x73, 0x32, 0xab, 0x52,
+	0x53, 0xc0, 0x46, 0x71, 0x06, 0x21, 0x89, 0x18, 0x65, 0x73, 0xf1, 0xf9, 0x26, 0x96, 0x64, 0xb8,
+	0x22, 0xbc, 0x1a, 0xc9, 0xc5, 0x01, 0x33, 0x5d, 0x48, 0x85, 0x18, 0x4f, 0x4a, 0xa9, 0x12, 0x50,
+	0x05, 0x71, 0xa2, 0x12, 0x83, 0x93, 0x44, 0x94, 0x18, 0xf6, 0xc8, 0x4b, 0x62, 0x03, 0xc7, 0x97,
+	0x31, 0x60, 0x00, 0x03, 0xa6, 0xd2, 0x3b, 0xdd, 0x01, 0x00, 0x00,
+}
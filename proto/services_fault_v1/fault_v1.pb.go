@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_fault_v1/fault_v1.proto
+
+/*
+Package services_fault_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_fault_v1/fault_v1.proto
+
+It has these top-level messages:
+
+	FaultRule
+	SetFaultsRequest
+	ListFaultsRequest
+	ClearFaultsRequest
+	FaultsResponse
+*/
+package services_fault_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/golang/protobuf/ptypes/duration"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// FaultRule says which calls fail and how.
+type FaultRule struct {
+	// target is db, cache.get or cache.set, or a single database method such as db.AddNumber.
+	Target string `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
+	// every fires the rule on every nth call to the target.
+	Every int32 `protobuf:"varint,2,opt,name=every" json:"every,omitempty"`
+	// probability fires the rule on that fraction of calls.  With neither every nor
+	// probability the rule fires on every call.
+	Probability float64 `protobuf:"fixed64,3,opt,name=probability" json:"probability,omitempty"`
+	// latency delays the call when the rule fires.
+	Latency *google_protobuf.Duration `protobuf:"bytes,4,opt,name=latency" json:"latency,omitempty"`
+	// code is the gRPC status code to fail with, 0 for a call that is only delayed.
+	Code int32 `protobuf:"varint,5,opt,name=code" json:"code,omitempty"`
+	// hang holds the call until its deadline, as a database that stopped answering would.
+	Hang bool `protobuf:"varint,6,opt,name=hang" json:"hang,omitempty"`
+}
+
+func (m *FaultRule) Reset()                    { *m = FaultRule{} }
+func (m *FaultRule) String() string            { return proto.CompactTextString(m) }
+func (*FaultRule) ProtoMessage()               {}
+func (*FaultRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *FaultRule) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *FaultRule) GetEvery() int32 {
+	if m != nil {
+		return m.Every
+	}
+	return 0
+}
+
+func (m *FaultRule) GetProbability() float64 {
+	if m != nil {
+		return m.Probability
+	}
+	return 0
+}
+
+func (m *FaultRule) GetLatency() *google_protobuf.Duration {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+func (m *FaultRule) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *FaultRule) GetHang() bool {
+	if m != nil {
+		return m.Hang
+	}
+	return false
+}
+
+type SetFaultsRequest struct {
+	Rules []*FaultRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *SetFaultsRequest) Reset()                    { *m = SetFaultsRequest{} }
+func (m *SetFaultsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetFaultsRequest) ProtoMessage()               {}
+func (*SetFaultsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SetFaultsRequest) GetRules() []*FaultRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type ListFaultsRequest struct {
+}
+
+func (m *ListFaultsRequest) Reset()                    { *m = ListFaultsRequest{} }
+func (m *ListFaultsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListFaultsRequest) ProtoMessage()               {}
+func (*ListFaultsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type ClearFaultsRequest struct {
+}
+
+func (m *ClearFaultsRequest) Reset()                    { *m = ClearFaultsRequest{} }
+func (m *ClearFaultsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClearFaultsRequest) ProtoMessage()               {}
+func (*ClearFaultsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type FaultsResponse struct {
+	Rules []*FaultRule `protobuf:"bytes,1,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *FaultsResponse) Reset()                    { *m = FaultsResponse{} }
+func (m *FaultsResponse) String() string            { return proto.CompactTextString(m) }
+func (*FaultsResponse) ProtoMessage()               {}
+func (*FaultsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *FaultsResponse) GetRules() []*FaultRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*FaultRule)(nil), "services.luggage.v1.FaultRule")
+	proto.RegisterType((*SetFaultsRequest)(nil), "services.luggage.v1.SetFaultsRequest")
+	proto.RegisterType((*ListFaultsRequest)(nil), "services.luggage.v1.ListFaultsRequest")
+	proto.RegisterType((*ClearFaultsRequest)(nil), "services.luggage.v1.ClearFaultsRequest")
+	proto.RegisterType((*FaultsResponse)(nil), "services.luggage.v1.FaultsResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for FaultAdmin service
+
+type FaultAdminClient interface {
+	// SetFaults replaces every rule.
+	SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error)
+	// ListFaults returns the rules in force.
+	ListFaults(ctx context.Context, in *ListFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error)
+	// ClearFaults removes every rule.
+	ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error)
+}
+
+type faultAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFaultAdminClient(cc *grpc.ClientConn) FaultAdminClient {
+	return &faultAdminClient{cc}
+}
+
+func (c *faultAdminClient) SetFaults(ctx context.Context, in *SetFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	out := new(FaultsResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.FaultAdmin/SetFaults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultAdminClient) ListFaults(ctx context.Context, in *ListFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	out := new(FaultsResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.FaultAdmin/ListFaults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultAdminClient) ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	out := new(FaultsResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.FaultAdmin/ClearFaults", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for FaultAdmin service
+
+type FaultAdminServer interface {
+	// SetFaults replaces every rule.
+	SetFaults(context.Context, *SetFaultsRequest) (*FaultsResponse, error)
+	// ListFaults returns the rules in force.
+	ListFaults(context.Context, *ListFaultsRequest) (*FaultsResponse, error)
+	// ClearFaults removes every rule.
+	ClearFaults(context.Context, *ClearFaultsRequest) (*FaultsResponse, error)
+}
+
+func RegisterFaultAdminServer(s *grpc.Server, srv FaultAdminServer) {
+	s.RegisterService(&_FaultAdmin_serviceDesc, srv)
+}
+
+func _FaultAdmin_SetFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultAdminServer).SetFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.FaultAdmin/SetFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultAdminServer).SetFaults(ctx, req.(*SetFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultAdmin_ListFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultAdminServer).ListFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.FaultAdmin/ListFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultAdminServer).ListFaults(ctx, req.(*ListFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultAdmin_ClearFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultAdminServer).ClearFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.FaultAdmin/ClearFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultAdminServer).ClearFaults(ctx, req.(*ClearFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _FaultAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.FaultAdmin",
+	HandlerType: (*FaultAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetFaults",
+			Handler:    _FaultAdmin_SetFaults_Handler,
+		},
+		{
+			MethodName: "ListFaults",
+			Handler:    _FaultAdmin_ListFaults_Handler,
+		},
+		{
+			MethodName: "ClearFaults",
+			Handler:    _FaultAdmin_ClearFaults_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_fault_v1/fault_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_fault_v1/fault_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0x5d, 0xab, 0xda, 0x40,
+	0x10, 0xed, 0xaa, 0xb1, 0x75, 0x02, 0xa5, 0xae, 0x52, 0x52, 0x1f, 0x64, 0x49, 0x69, 0x9b, 0xa7,
+	0x15, 0xb5, 0x7f, 0xa0, 0x1f, 0x48, 0x1f, 0xfa, 0xb4, 0x7d, 0x6a, 0x4b, 0x91, 0x8d, 0x8e, 0x69,
+	0x60, 0x9b, 0xb5, 0xbb, 0x9b, 0x80, 0xbf, 0xe7, 0xfe, 0x89, 0xfb, 0xf3, 0x2e, 0x26, 0xc6, 0xab,
+	0xd7, 0x5c, 0x90, 0xfb, 0x36, 0x73, 0x38, 0x73, 0xe6, 0x9c, 0x61, 0x80, 0x59, 0x34, 0x45, 0xba,
+	0x42, 0xbb, 0xdc, 0xc8, 0x5c, 0xb9, 0x65, 0x31, 0x9d, 0xd4, 0x05, 0xdf, 0x1a, 0xed, 0x34, 0x1d,
+	0xd4, 0x0c, 0xae, 0xf2, 0x24, 0x91, 0x09, 0xf2, 0x62, 0x3a, 0x1a, 0x27, 0x5a, 0x27, 0x0a, 0x27,
+	0x25, 0x25, 0xce, 0x37, 0x93, 0x75, 0x6e, 0xa4, 0x4b, 0x75, 0x56, 0x0d, 0x85, 0xb7, 0x04, 0x7a,
+	0x8b, 0xbd, 0x8e, 0xc8, 0x15, 0xd2, 0xd7, 0xd0, 0x75, 0xd2, 0x24, 0xe8, 0x02, 0xc2, 0x48, 0xd4,
+	0x13, 0x87, 0x8e, 0x0e, 0xc1, 0xc3, 0x02, 0xcd, 0x2e, 0x68, 0x31, 0x12, 0x79, 0xa2, 0x6a, 0x28,
+	0x03, 0x7f, 0x6b, 0x74, 0x2c, 0xe3, 0x54, 0xa5, 0x6e, 0x17, 0xb4, 0x19, 0x89, 0x88, 0x38, 0x85,
+	0xe8, 0x1c, 0x9e, 0x2b, 0xe9, 0x30, 0x5b, 0xed, 0x82, 0x0e, 0x23, 0x91, 0x3f, 0x7b, 0xc3, 0x2b,
+	0x3f, 0xbc, 0xf6, 0xc3, 0xbf, 0x1e, 0xfc, 0x88, 0x9a, 0x49, 0x29, 0x74, 0x56, 0x7a, 0x8d, 0x81,
+	0x57, 0xee, 0x2a, 0xeb, 0x3d, 0xf6, 0x57, 0x66, 0x49, 0xd0, 0x65, 0x24, 0x7a, 0x21, 0xca, 0x3a,
+	0xfc, 0x06, 0xaf, 0x7e, 0xa0, 0x2b, 0xcd, 0x5b, 0x81, 0xff, 0x73, 0xb4, 0x8e, 0x7e, 0x04, 0xcf,
+	0xe4, 0x0a, 0x6d, 0x40, 0x58, 0x3b, 0xf2, 0x67, 0x63, 0xde, 0x70, 0x13, 0x7e, 0xcc, 0x2b, 0x2a,
+	0x72, 0x38, 0x80, 0xfe, 0xf7, 0xd4, 0x9e, 0x4b, 0x85, 0x43, 0xa0, 0x5f, 0x14, 0x4a, 0x73, 0x8e,
+	0x2e, 0xe0, 0x65, 0x0d, 0xd8, 0xad, 0xce, 0x2c, 0x3e, 0x6d, 0xe5, 0xec, 0xa6, 0x05, 0x50, 0x82,
+	0x9f, 0xd6, 0xff, 0xd2, 0x8c, 0xfe, 0x84, 0xde, 0x31, 0x0b, 0x7d, 0xd7, 0x28, 0xf1, 0x30, 0xeb,
+	0xe8, 0xed, 0xe3, 0x9b, 0x8e, 0xee, 0xc2, 0x67, 0xf4, 0x37, 0xc0, 0x7d, 0x38, 0xfa, 0xbe, 0x71,
+	0xe8, 0x22, 0xfd, 0xb5, 0xe2, 0x7f, 0xc0, 0x3f, 0x39, 0x12, 0xfd, 0xd0, 0x38, 0x75, 0x79, 0xc6,
+	0x2b, 0xe5, 0x3f, 0x0f, 0x7e, 0xf5, 0x2f, 0xde, 0x3e, 0xee, 0x96, 0xbf, 0x33, 0xbf, 0x1b, 0x00,
+	0x02, 0x80, 0x2d, 0x0a, 0x12, 0x03, 0x00, 0x00,
+}
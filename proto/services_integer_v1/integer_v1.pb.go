@@ -0,0 +1,373 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_integer_v1/integer_v1.proto
+
+/*
+Package services_integer_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_integer_v1/integer_v1.proto
+
+It has these top-level messages:
+
+	IntegerRequest
+	IntegerResponse
+*/
+package services_integer_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Overflow int32
+
+const (
+	// OVERFLOW_CHECKED fails with OutOfRange.
+	Overflow_OVERFLOW_CHECKED Overflow = 0
+	// OVERFLOW_SATURATING gives the int64 nearest the true result, its maximum or minimum.
+	Overflow_OVERFLOW_SATURATING Overflow = 1
+	// OVERFLOW_WRAPPING gives the true result modulo 2^64, as two's complement hardware does.
+	Overflow_OVERFLOW_WRAPPING Overflow = 2
+)
+
+var Overflow_name = map[int32]string{
+	0: "OVERFLOW_CHECKED",
+	1: "OVERFLOW_SATURATING",
+	2: "OVERFLOW_WRAPPING",
+}
+var Overflow_value = map[string]int32{
+	"OVERFLOW_CHECKED":    0,
+	"OVERFLOW_SATURATING": 1,
+	"OVERFLOW_WRAPPING":   2,
+}
+
+func (x Overflow) String() string {
+	return proto.EnumName(Overflow_name, int32(x))
+}
+func (Overflow) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type Division int32
+
+const (
+	// DIVISION_TRUNCATE rounds the quotient toward zero, the remainder has the dividend's sign.
+	Division_DIVISION_TRUNCATE Division = 0
+	// DIVISION_FLOOR rounds the quotient toward negative infinity, the remainder has the
+	// divisor's sign.
+	Division_DIVISION_FLOOR Division = 1
+)
+
+var Division_name = map[int32]string{
+	0: "DIVISION_TRUNCATE",
+	1: "DIVISION_FLOOR",
+}
+var Division_value = map[string]int32{
+	"DIVISION_TRUNCATE": 0,
+	"DIVISION_FLOOR":    1,
+}
+
+func (x Division) String() string {
+	return proto.EnumName(Division_name, int32(x))
+}
+func (Division) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type IntegerRequest struct {
+	Number1  int64    `protobuf:"varint,1,opt,name=number1" json:"number1,omitempty"`
+	Number2  int64    `protobuf:"varint,2,opt,name=number2" json:"number2,omitempty"`
+	Overflow Overflow `protobuf:"varint,3,opt,name=overflow,enum=services.luggage.v1.Overflow" json:"overflow,omitempty"`
+	Division Division `protobuf:"varint,4,opt,name=division,enum=services.luggage.v1.Division" json:"division,omitempty"`
+}
+
+func (m *IntegerRequest) Reset()                    { *m = IntegerRequest{} }
+func (m *IntegerRequest) String() string            { return proto.CompactTextString(m) }
+func (*IntegerRequest) ProtoMessage()               {}
+func (*IntegerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *IntegerRequest) GetNumber1() int64 {
+	if m != nil {
+		return m.Number1
+	}
+	return 0
+}
+
+func (m *IntegerRequest) GetNumber2() int64 {
+	if m != nil {
+		return m.Number2
+	}
+	return 0
+}
+
+func (m *IntegerRequest) GetOverflow() Overflow {
+	if m != nil {
+		return m.Overflow
+	}
+	return Overflow_OVERFLOW_CHECKED
+}
+
+func (m *IntegerRequest) GetDivision() Division {
+	if m != nil {
+		return m.Division
+	}
+	return Division_DIVISION_TRUNCATE
+}
+
+type IntegerResponse struct {
+	Result    int64 `protobuf:"varint,1,opt,name=result" json:"result,omitempty"`
+	Remainder int64 `protobuf:"varint,2,opt,name=remainder" json:"remainder,omitempty"`
+	// overflowed says the true result did not fit, and was saturated or wrapped.
+	Overflowed bool `protobuf:"varint,3,opt,name=overflowed" json:"overflowed,omitempty"`
+}
+
+func (m *IntegerResponse) Reset()                    { *m = IntegerResponse{} }
+func (m *IntegerResponse) String() string            { return proto.CompactTextString(m) }
+func (*IntegerResponse) ProtoMessage()               {}
+func (*IntegerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *IntegerResponse) GetResult() int64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+func (m *IntegerResponse) GetRemainder() int64 {
+	if m != nil {
+		return m.Remainder
+	}
+	return 0
+}
+
+func (m *IntegerResponse) GetOverflowed() bool {
+	if m != nil {
+		return m.Overflowed
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*IntegerRequest)(nil), "services.luggage.v1.IntegerRequest")
+	proto.RegisterType((*IntegerResponse)(nil), "services.luggage.v1.IntegerResponse")
+	proto.RegisterEnum("services.luggage.v1.Overflow", Overflow_name, Overflow_value)
+	proto.RegisterEnum("services.luggage.v1.Division", Division_name, Division_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathInteger service
+
+type MathIntegerClient interface {
+	Add(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	Subtract(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	Multiply(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+	// Divide sets the remainder too.  Dividing by zero fails with InvalidArgument whatever
+	// the overflow mode.
+	Divide(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error)
+}
+
+type mathIntegerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathIntegerClient(cc *grpc.ClientConn) MathIntegerClient {
+	return &mathIntegerClient{cc}
+}
+
+func (c *mathIntegerClient) Add(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathInteger/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathIntegerClient) Subtract(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathInteger/Subtract", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathIntegerClient) Multiply(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathInteger/Multiply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathIntegerClient) Divide(ctx context.Context, in *IntegerRequest, opts ...grpc.CallOption) (*IntegerResponse, error) {
+	out := new(IntegerResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathInteger/Divide", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathInteger service
+
+type MathIntegerServer interface {
+	Add(context.Context, *IntegerRequest) (*IntegerResponse, error)
+	Subtract(context.Context, *IntegerRequest) (*IntegerResponse, error)
+	Multiply(context.Context, *IntegerRequest) (*IntegerResponse, error)
+	// Divide sets the remainder too.  Dividing by zero fails with InvalidArgument whatever
+	// the overflow mode.
+	Divide(context.Context, *IntegerRequest) (*IntegerResponse, error)
+}
+
+func RegisterMathIntegerServer(s *grpc.Server, srv MathIntegerServer) {
+	s.RegisterService(&_MathInteger_serviceDesc, srv)
+}
+
+func _MathInteger_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathIntegerServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathInteger/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathIntegerServer).Add(ctx, req.(*IntegerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathInteger_Subtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathIntegerServer).Subtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathInteger/Subtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathIntegerServer).Subtract(ctx, req.(*IntegerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathInteger_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathIntegerServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathInteger/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathIntegerServer).Multiply(ctx, req.(*IntegerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathInteger_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntegerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathIntegerServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathInteger/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathIntegerServer).Divide(ctx, req.(*IntegerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathInteger_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathInteger",
+	HandlerType: (*MathIntegerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _MathInteger_Add_Handler,
+		},
+		{
+			MethodName: "Subtract",
+			Handler:    _MathInteger_Subtract_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _MathInteger_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _MathInteger_Divide_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_integer_v1/integer_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_integer_v1/integer_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 388 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xa2, 0x50,
+	0x10, 0xc7, 0x45, 0x37, 0x2e, 0x3b, 0x9b, 0xb8, 0xec, 0x73, 0xdd, 0x25, 0x9b, 0xdd, 0xc6, 0x58,
+	0x0f, 0xc6, 0x03, 0x8d, 0x34, 0x3d, 0xf4, 0x48, 0x15, 0x5b, 0x52, 0x15, 0xf3, 0x44, 0x4d, 0x7a,
+	0x21, 0x28, 0xaf, 0x94, 0x04, 0xc1, 0x3e, 0x1e, 0x34, 0xfd, 0x6a, 0xfd, 0x48, 0xfd, 0x14, 0x0d,
+	0x0a, 0x68, 0x13, 0xe3, 0xc9, 0x1b, 0xf3, 0x9f, 0xdf, 0x0c, 0xff, 0x99, 0x97, 0x81, 0x66, 0x48,
+	0x68, 0xec, 0x2e, 0x49, 0x68, 0xba, 0x3e, 0x23, 0x0e, 0xa1, 0x66, 0xdc, 0xb9, 0xd8, 0x7d, 0x4a,
+	0x6b, 0x1a, 0xb0, 0x00, 0x55, 0x33, 0x4a, 0xf2, 0x22, 0xc7, 0xb1, 0x1c, 0x22, 0xc5, 0x9d, 0xc6,
+	0x1b, 0x07, 0x15, 0x6d, 0x4b, 0x62, 0xf2, 0x1c, 0x91, 0x90, 0x21, 0x11, 0xbe, 0xfa, 0xd1, 0x6a,
+	0x41, 0x68, 0x47, 0xe4, 0xea, 0x5c, 0xab, 0x84, 0xb3, 0x70, 0x97, 0x91, 0xc5, 0xe2, 0x7e, 0x46,
+	0x46, 0xd7, 0xc0, 0x07, 0x31, 0xa1, 0x8f, 0x5e, 0xf0, 0x22, 0x96, 0xea, 0x5c, 0xab, 0x22, 0xff,
+	0x97, 0x0e, 0xfc, 0x4e, 0xd2, 0x53, 0x08, 0xe7, 0x78, 0x52, 0x6a, 0xbb, 0xb1, 0x1b, 0xba, 0x81,
+	0x2f, 0x7e, 0x39, 0x52, 0xda, 0x4b, 0x21, 0x9c, 0xe3, 0x0d, 0x07, 0x7e, 0xe4, 0xde, 0xc3, 0x75,
+	0xe0, 0x87, 0x04, 0xfd, 0x86, 0x32, 0x25, 0x61, 0xe4, 0xb1, 0xd4, 0x7b, 0x1a, 0xa1, 0x7f, 0xf0,
+	0x8d, 0x92, 0x95, 0xe5, 0xfa, 0x36, 0xa1, 0xa9, 0xf9, 0x9d, 0x80, 0xce, 0x00, 0x32, 0x3f, 0xc4,
+	0xde, 0x0c, 0xc0, 0xe3, 0x3d, 0xa5, 0x3d, 0x06, 0x3e, 0x73, 0x8e, 0x7e, 0x81, 0xa0, 0xcf, 0x54,
+	0xdc, 0x1f, 0xe8, 0x73, 0xb3, 0x7b, 0xa7, 0x76, 0xef, 0xd5, 0x9e, 0x50, 0x40, 0x7f, 0xa0, 0x9a,
+	0xab, 0x13, 0xc5, 0x98, 0x62, 0xc5, 0xd0, 0x46, 0xb7, 0x02, 0x87, 0x6a, 0xf0, 0x33, 0x4f, 0xcc,
+	0xb1, 0x32, 0x1e, 0x27, 0x72, 0xb1, 0x7d, 0x05, 0x7c, 0x36, 0x50, 0x82, 0xf4, 0xb4, 0x99, 0x36,
+	0xd1, 0xf4, 0x91, 0x69, 0xe0, 0xe9, 0xa8, 0xab, 0x18, 0xaa, 0x50, 0x40, 0x08, 0x2a, 0xb9, 0xdc,
+	0x1f, 0xe8, 0x3a, 0x16, 0x38, 0xf9, 0xbd, 0x08, 0xdf, 0x87, 0x16, 0x7b, 0x4a, 0xc7, 0x46, 0x18,
+	0x4a, 0x8a, 0x6d, 0xa3, 0xf3, 0x83, 0x1b, 0xfb, 0xfc, 0xae, 0x7f, 0x9b, 0xc7, 0xa1, 0xed, 0x02,
+	0x1b, 0x05, 0x34, 0x07, 0x7e, 0x12, 0x2d, 0x18, 0xb5, 0x96, 0xec, 0xe4, 0x8d, 0x87, 0x91, 0xc7,
+	0xdc, 0xb5, 0xf7, 0x7a, 0xda, 0xc6, 0x53, 0x28, 0x27, 0xcb, 0xb4, 0xc9, 0x49, 0xdb, 0xde, 0xd4,
+	0x1e, 0xaa, 0x07, 0x0e, 0x6b, 0x51, 0xde, 0x9c, 0xd3, 0xe5, 0xc7, 0x00, 0x3c, 0xe4, 0x3c, 0x3a,
+	0x76, 0x03, 0x00, 0x00,
+}
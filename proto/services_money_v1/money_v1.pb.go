@@ -0,0 +1,382 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_money_v1/money_v1.proto
+
+/*
+Package services_money_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_money_v1/money_v1.proto
+
+It has these top-level messages:
+
+	Money
+	MoneyRequest
+	MoneyResponse
+*/
+package services_money_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Rounding int32
+
+const (
+	// ROUNDING_HALF_EVEN goes to the nearer, ties to an even last digit, as banks round.
+	Rounding_ROUNDING_HALF_EVEN Rounding = 0
+	// ROUNDING_HALF_UP goes to the nearer, ties away from zero.
+	Rounding_ROUNDING_HALF_UP Rounding = 1
+	// ROUNDING_DOWN goes toward zero.
+	Rounding_ROUNDING_DOWN Rounding = 2
+	// ROUNDING_CEILING goes toward positive infinity.
+	Rounding_ROUNDING_CEILING Rounding = 3
+	// ROUNDING_FLOOR goes toward negative infinity.
+	Rounding_ROUNDING_FLOOR Rounding = 4
+)
+
+var Rounding_name = map[int32]string{
+	0: "ROUNDING_HALF_EVEN",
+	1: "ROUNDING_HALF_UP",
+	2: "ROUNDING_DOWN",
+	3: "ROUNDING_CEILING",
+	4: "ROUNDING_FLOOR",
+}
+var Rounding_value = map[string]int32{
+	"ROUNDING_HALF_EVEN": 0,
+	"ROUNDING_HALF_UP":   1,
+	"ROUNDING_DOWN":      2,
+	"ROUNDING_CEILING":   3,
+	"ROUNDING_FLOOR":     4,
+}
+
+func (x Rounding) String() string {
+	return proto.EnumName(Rounding_name, int32(x))
+}
+func (Rounding) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type Money struct {
+	// amount is a decimal string such as "19.99".
+	Amount string `protobuf:"bytes,1,opt,name=amount" json:"amount,omitempty"`
+	// currency is an ISO 4217 code such as "USD".
+	Currency string `protobuf:"bytes,2,opt,name=currency" json:"currency,omitempty"`
+}
+
+func (m *Money) Reset()                    { *m = Money{} }
+func (m *Money) String() string            { return proto.CompactTextString(m) }
+func (*Money) ProtoMessage()               {}
+func (*Money) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Money) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Money) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+type MoneyRequest struct {
+	Number1 *Money `protobuf:"bytes,1,opt,name=number1" json:"number1,omitempty"`
+	Number2 *Money `protobuf:"bytes,2,opt,name=number2" json:"number2,omitempty"`
+	// scale is how many places the result has after the point, 2 for cents, 0 for whole units.
+	Scale    uint32   `protobuf:"varint,3,opt,name=scale" json:"scale,omitempty"`
+	Rounding Rounding `protobuf:"varint,4,opt,name=rounding,enum=services.luggage.v1.Rounding" json:"rounding,omitempty"`
+}
+
+func (m *MoneyRequest) Reset()                    { *m = MoneyRequest{} }
+func (m *MoneyRequest) String() string            { return proto.CompactTextString(m) }
+func (*MoneyRequest) ProtoMessage()               {}
+func (*MoneyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *MoneyRequest) GetNumber1() *Money {
+	if m != nil {
+		return m.Number1
+	}
+	return nil
+}
+
+func (m *MoneyRequest) GetNumber2() *Money {
+	if m != nil {
+		return m.Number2
+	}
+	return nil
+}
+
+func (m *MoneyRequest) GetScale() uint32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *MoneyRequest) GetRounding() Rounding {
+	if m != nil {
+		return m.Rounding
+	}
+	return Rounding_ROUNDING_HALF_EVEN
+}
+
+type MoneyResponse struct {
+	// result is in the currency of number1, its amount with exactly scale places.
+	Result *Money `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	// rounded says the exact result needed more places than scale.
+	Rounded bool `protobuf:"varint,2,opt,name=rounded" json:"rounded,omitempty"`
+}
+
+func (m *MoneyResponse) Reset()                    { *m = MoneyResponse{} }
+func (m *MoneyResponse) String() string            { return proto.CompactTextString(m) }
+func (*MoneyResponse) ProtoMessage()               {}
+func (*MoneyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *MoneyResponse) GetResult() *Money {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *MoneyResponse) GetRounded() bool {
+	if m != nil {
+		return m.Rounded
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Money)(nil), "services.luggage.v1.Money")
+	proto.RegisterType((*MoneyRequest)(nil), "services.luggage.v1.MoneyRequest")
+	proto.RegisterType((*MoneyResponse)(nil), "services.luggage.v1.MoneyResponse")
+	proto.RegisterEnum("services.luggage.v1.Rounding", Rounding_name, Rounding_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathMoney service
+
+type MathMoneyClient interface {
+	// Add is number1 + number2, which must be in the same currency.
+	Add(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error)
+	// Subtract is number1 - number2, which must be in the same currency.
+	Subtract(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error)
+	// Multiply is number1 times number2, a quantity or rate with no currency.
+	Multiply(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error)
+	// Divide is number1 divided by number2, a quantity with no currency.
+	Divide(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error)
+}
+
+type mathMoneyClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathMoneyClient(cc *grpc.ClientConn) MathMoneyClient {
+	return &mathMoneyClient{cc}
+}
+
+func (c *mathMoneyClient) Add(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error) {
+	out := new(MoneyResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathMoney/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathMoneyClient) Subtract(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error) {
+	out := new(MoneyResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathMoney/Subtract", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathMoneyClient) Multiply(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error) {
+	out := new(MoneyResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathMoney/Multiply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathMoneyClient) Divide(ctx context.Context, in *MoneyRequest, opts ...grpc.CallOption) (*MoneyResponse, error) {
+	out := new(MoneyResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathMoney/Divide", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathMoney service
+
+type MathMoneyServer interface {
+	// Add is number1 + number2, which must be in the same currency.
+	Add(context.Context, *MoneyRequest) (*MoneyResponse, error)
+	// Subtract is number1 - number2, which must be in the same currency.
+	Subtract(context.Context, *MoneyRequest) (*MoneyResponse, error)
+	// Multiply is number1 times number2, a quantity or rate with no currency.
+	Multiply(context.Context, *MoneyRequest) (*MoneyResponse, error)
+	// Divide is number1 divided by number2, a quantity with no currency.
+	Divide(context.Context, *MoneyRequest) (*MoneyResponse, error)
+}
+
+func RegisterMathMoneyServer(s *grpc.Server, srv MathMoneyServer) {
+	s.RegisterService(&_MathMoney_serviceDesc, srv)
+}
+
+func _MathMoney_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathMoneyServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathMoney/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathMoneyServer).Add(ctx, req.(*MoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathMoney_Subtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathMoneyServer).Subtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathMoney/Subtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathMoneyServer).Subtract(ctx, req.(*MoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathMoney_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathMoneyServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathMoney/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathMoneyServer).Multiply(ctx, req.(*MoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathMoney_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathMoneyServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathMoney/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathMoneyServer).Divide(ctx, req.(*MoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathMoney_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathMoney",
+	HandlerType: (*MathMoneyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _MathMoney_Add_Handler,
+		},
+		{
+			MethodName: "Subtract",
+			Handler:    _MathMoney_Subtract_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _MathMoney_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _MathMoney_Divide_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_money_v1/money_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_money_v1/money_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 389 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x5f, 0x8b, 0xd3, 0x40,
+	0x14, 0xc5, 0x37, 0xe9, 0x6e, 0x36, 0x7b, 0xb5, 0x4b, 0xf6, 0xee, 0xb2, 0x84, 0x82, 0x10, 0xf3,
+	0x54, 0x7c, 0x88, 0x34, 0xfa, 0x22, 0x3e, 0x55, 0xfb, 0xc7, 0x42, 0x9b, 0xe8, 0x94, 0x2a, 0x08,
+	0x12, 0xd2, 0x64, 0x88, 0x81, 0x34, 0xa9, 0x93, 0x99, 0x40, 0x3f, 0xa3, 0x5f, 0xc0, 0x8f, 0x23,
+	0xe6, 0x9f, 0x94, 0x2d, 0xa5, 0x0f, 0x7d, 0xcb, 0xb9, 0xf9, 0x9d, 0x33, 0x9c, 0x19, 0x2e, 0x18,
+	0x39, 0x65, 0x45, 0x1c, 0xd0, 0xdc, 0xdb, 0x64, 0x29, 0xdd, 0x79, 0xc5, 0xe0, 0x75, 0xf3, 0x61,
+	0x6d, 0x59, 0xc6, 0x33, 0xbc, 0x6f, 0x08, 0x2b, 0x11, 0x51, 0xe4, 0x47, 0xd4, 0x2a, 0x06, 0xe6,
+	0x7b, 0xb8, 0x5a, 0xfc, 0xc3, 0xf0, 0x11, 0x14, 0x7f, 0x93, 0x89, 0x94, 0xeb, 0x92, 0x21, 0xf5,
+	0x6f, 0x48, 0xad, 0xb0, 0x07, 0x6a, 0x20, 0x18, 0xa3, 0x69, 0xb0, 0xd3, 0xe5, 0xf2, 0x4f, 0xab,
+	0xcd, 0xdf, 0x12, 0x3c, 0x2f, 0xdd, 0x84, 0xfe, 0x12, 0x34, 0xe7, 0xf8, 0x16, 0xae, 0x53, 0xb1,
+	0x59, 0x53, 0x36, 0x28, 0x53, 0x9e, 0xd9, 0x3d, 0xeb, 0xc0, 0xa1, 0x56, 0xe5, 0x69, 0xd0, 0xff,
+	0x2e, 0x5b, 0x97, 0x4f, 0x75, 0xd9, 0xf8, 0x00, 0x57, 0x79, 0xe0, 0x27, 0x54, 0xef, 0x18, 0x52,
+	0xbf, 0x4b, 0x2a, 0x81, 0xef, 0x40, 0x65, 0x99, 0x48, 0xc3, 0x38, 0x8d, 0xf4, 0x4b, 0x43, 0xea,
+	0xdf, 0xda, 0x2f, 0x0e, 0x86, 0x91, 0x1a, 0x22, 0x2d, 0x6e, 0xfe, 0x80, 0x6e, 0x5d, 0x26, 0xdf,
+	0x66, 0x69, 0x4e, 0xd1, 0x06, 0x85, 0xd1, 0x5c, 0x24, 0xfc, 0x84, 0x32, 0x35, 0x89, 0x3a, 0x5c,
+	0x97, 0x81, 0x34, 0x2c, 0xbb, 0xa8, 0xa4, 0x91, 0xaf, 0x04, 0xa8, 0xcd, 0xa1, 0xf8, 0x08, 0x48,
+	0xdc, 0x95, 0x33, 0x9a, 0x39, 0x53, 0xef, 0xd3, 0x70, 0x3e, 0xf1, 0xc6, 0x5f, 0xc7, 0x8e, 0x76,
+	0x81, 0x0f, 0xa0, 0xed, 0xcf, 0x57, 0x9f, 0x35, 0x09, 0xef, 0xa0, 0xdb, 0x4e, 0x47, 0xee, 0x37,
+	0x47, 0x93, 0xf7, 0xc0, 0x8f, 0xe3, 0xd9, 0x7c, 0xe6, 0x4c, 0xb5, 0x0e, 0x22, 0xdc, 0xb6, 0xd3,
+	0xc9, 0xdc, 0x75, 0x89, 0x76, 0x69, 0xff, 0x91, 0xe1, 0x66, 0xe1, 0xf3, 0x9f, 0xd5, 0x2b, 0x3b,
+	0xd0, 0x19, 0x86, 0x21, 0xbe, 0x3c, 0xd2, 0xa4, 0x7a, 0xca, 0x9e, 0x79, 0x0c, 0xa9, 0x2e, 0xc8,
+	0xbc, 0xc0, 0x25, 0xa8, 0x4b, 0xb1, 0xe6, 0xcc, 0x0f, 0xf8, 0x59, 0x43, 0x17, 0x22, 0xe1, 0xf1,
+	0x36, 0xd9, 0x9d, 0x2f, 0xf4, 0x0b, 0x28, 0xa3, 0xb8, 0x88, 0x43, 0x7a, 0xb6, 0xc8, 0x0f, 0xf7,
+	0xdf, 0xef, 0x9e, 0x2c, 0xdd, 0x5a, 0x29, 0x97, 0xed, 0xcd, 0xdf, 0x01, 0x00, 0x3b, 0xff, 0x18,
+	0x50, 0x90, 0x03, 0x00, 0x00,
+}
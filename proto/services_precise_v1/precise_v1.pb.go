@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_precise_v1/precise_v1.proto
+
+/*
+Package services_precise_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_precise_v1/precise_v1.proto
+
+It has these top-level messages:
+
+	PreciseRequest
+	PreciseResponse
+*/
+package services_precise_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type PreciseRequest struct {
+	// number1 and number2 are decimal strings such as "12", "-0.50" or "6.02e23".
+	Number1 string `protobuf:"bytes,1,opt,name=number1" json:"number1,omitempty"`
+	Number2 string `protobuf:"bytes,2,opt,name=number2" json:"number2,omitempty"`
+	// precision is the most significant digits the result may have, 0 for the server's default.
+	Precision uint32 `protobuf:"varint,3,opt,name=precision" json:"precision,omitempty"`
+}
+
+func (m *PreciseRequest) Reset()                    { *m = PreciseRequest{} }
+func (m *PreciseRequest) String() string            { return proto.CompactTextString(m) }
+func (*PreciseRequest) ProtoMessage()               {}
+func (*PreciseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *PreciseRequest) GetNumber1() string {
+	if m != nil {
+		return m.Number1
+	}
+	return ""
+}
+
+func (m *PreciseRequest) GetNumber2() string {
+	if m != nil {
+		return m.Number2
+	}
+	return ""
+}
+
+func (m *PreciseRequest) GetPrecision() uint32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+type PreciseResponse struct {
+	// result is a decimal string, exact unless rounded is set.
+	Result  string `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	Rounded bool   `protobuf:"varint,2,opt,name=rounded" json:"rounded,omitempty"`
+	// precision is the precision the result was computed to.
+	Precision uint32 `protobuf:"varint,3,opt,name=precision" json:"precision,omitempty"`
+}
+
+func (m *PreciseResponse) Reset()                    { *m = PreciseResponse{} }
+func (m *PreciseResponse) String() string            { return proto.CompactTextString(m) }
+func (*PreciseResponse) ProtoMessage()               {}
+func (*PreciseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *PreciseResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *PreciseResponse) GetRounded() bool {
+	if m != nil {
+		return m.Rounded
+	}
+	return false
+}
+
+func (m *PreciseResponse) GetPrecision() uint32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PreciseRequest)(nil), "services.luggage.v1.PreciseRequest")
+	proto.RegisterType((*PreciseResponse)(nil), "services.luggage.v1.PreciseResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathPrecise service
+
+type MathPreciseClient interface {
+	Add(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error)
+	Multiply(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error)
+	Divide(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error)
+}
+
+type mathPreciseClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathPreciseClient(cc *grpc.ClientConn) MathPreciseClient {
+	return &mathPreciseClient{cc}
+}
+
+func (c *mathPreciseClient) Add(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error) {
+	out := new(PreciseResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathPrecise/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathPreciseClient) Multiply(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error) {
+	out := new(PreciseResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathPrecise/Multiply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathPreciseClient) Divide(ctx context.Context, in *PreciseRequest, opts ...grpc.CallOption) (*PreciseResponse, error) {
+	out := new(PreciseResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathPrecise/Divide", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathPrecise service
+
+type MathPreciseServer interface {
+	Add(context.Context, *PreciseRequest) (*PreciseResponse, error)
+	Multiply(context.Context, *PreciseRequest) (*PreciseResponse, error)
+	Divide(context.Context, *PreciseRequest) (*PreciseResponse, error)
+}
+
+func RegisterMathPreciseServer(s *grpc.Server, srv MathPreciseServer) {
+	s.RegisterService(&_MathPrecise_serviceDesc, srv)
+}
+
+func _MathPrecise_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreciseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathPreciseServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathPrecise/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathPreciseServer).Add(ctx, req.(*PreciseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathPrecise_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreciseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathPreciseServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathPrecise/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathPreciseServer).Multiply(ctx, req.(*PreciseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathPrecise_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreciseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathPreciseServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathPrecise/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathPreciseServer).Divide(ctx, req.(*PreciseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathPrecise_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathPrecise",
+	HandlerType: (*MathPreciseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _MathPrecise_Add_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _MathPrecise_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _MathPrecise_Divide_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_precise_v1/precise_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_precise_v1/precise_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 243 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x29, 0x4e, 0x2d, 0x2a,
+	0xcb, 0x4c, 0x4e, 0x2d, 0x8e, 0x2f, 0x28, 0x4a, 0x4d, 0xce, 0x2c, 0x4e, 0x8d, 0x2f, 0x33, 0xd4,
+	0x47, 0x30, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2, 0x85, 0x84, 0x61, 0xaa, 0xf4, 0x72, 0x4a, 0xd3,
+	0xd3, 0x13, 0xd3, 0x53, 0xf5, 0xca, 0x0c, 0x95, 0x92, 0xb8, 0xf8, 0x02, 0x20, 0x0a, 0x83, 0x52,
+	0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84, 0x24, 0xb8, 0xd8, 0xf3, 0x4a, 0x73, 0x93, 0x52, 0x8b, 0x0c,
+	0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0x60, 0x5c, 0x84, 0x8c, 0x91, 0x04, 0x13, 0xb2, 0x8c,
+	0x91, 0x90, 0x0c, 0x17, 0x27, 0xc4, 0xba, 0xcc, 0xfc, 0x3c, 0x09, 0x66, 0x05, 0x46, 0x0d, 0xde,
+	0x20, 0x84, 0x80, 0x52, 0x22, 0x17, 0x3f, 0xdc, 0x8e, 0xe2, 0x82, 0xfc, 0xbc, 0xe2, 0x54, 0x21,
+	0x31, 0x2e, 0xb6, 0xa2, 0xd4, 0xe2, 0xd2, 0x9c, 0x12, 0xa8, 0x1d, 0x50, 0x1e, 0xc8, 0x8a, 0xa2,
+	0xfc, 0xd2, 0xbc, 0x94, 0xd4, 0x14, 0xb0, 0x15, 0x1c, 0x41, 0x30, 0x2e, 0x7e, 0x2b, 0x8c, 0x26,
+	0x32, 0x71, 0x71, 0xfb, 0x26, 0x96, 0x64, 0x40, 0xed, 0x11, 0x0a, 0xe2, 0x62, 0x76, 0x4c, 0x49,
+	0x11, 0x52, 0xd6, 0xc3, 0xe2, 0x67, 0x3d, 0x54, 0x0f, 0x4b, 0xa9, 0xe0, 0x57, 0x04, 0x71, 0xb1,
+	0x12, 0x83, 0x50, 0x38, 0x17, 0x87, 0x6f, 0x69, 0x4e, 0x49, 0x66, 0x41, 0x4e, 0x25, 0x75, 0x0d,
+	0x0e, 0xe5, 0x62, 0x73, 0xc9, 0x2c, 0xcb, 0x4c, 0x49, 0xa5, 0xaa, 0xb1, 0x4e, 0xa2, 0x51, 0xc2,
+	0x58, 0xd2, 0x45, 0x12, 0x1b, 0x38, 0x35, 0x18, 0x03, 0x06, 0x00, 0x77, 0x22, 0x88, 0x02, 0x35,
+	0x02, 0x00, 0x00,
+}
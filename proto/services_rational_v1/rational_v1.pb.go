@@ -0,0 +1,346 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_rational_v1/rational_v1.proto
+
+/*
+Package services_rational_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_rational_v1/rational_v1.proto
+
+It has these top-level messages:
+
+	Rational
+	RationalRequest
+	RationalResponse
+*/
+package services_rational_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Rational is numerator/denominator, each a decimal integer of any size with an optional
+// sign.  An empty denominator is 1.
+type Rational struct {
+	Numerator   string `protobuf:"bytes,1,opt,name=numerator" json:"numerator,omitempty"`
+	Denominator string `protobuf:"bytes,2,opt,name=denominator" json:"denominator,omitempty"`
+}
+
+func (m *Rational) Reset()                    { *m = Rational{} }
+func (m *Rational) String() string            { return proto.CompactTextString(m) }
+func (*Rational) ProtoMessage()               {}
+func (*Rational) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Rational) GetNumerator() string {
+	if m != nil {
+		return m.Numerator
+	}
+	return ""
+}
+
+func (m *Rational) GetDenominator() string {
+	if m != nil {
+		return m.Denominator
+	}
+	return ""
+}
+
+type RationalRequest struct {
+	Number1 *Rational `protobuf:"bytes,1,opt,name=number1" json:"number1,omitempty"`
+	Number2 *Rational `protobuf:"bytes,2,opt,name=number2" json:"number2,omitempty"`
+	// digits is the places after the point of the decimal form, the server's default if 0.
+	Digits uint32 `protobuf:"varint,3,opt,name=digits" json:"digits,omitempty"`
+}
+
+func (m *RationalRequest) Reset()                    { *m = RationalRequest{} }
+func (m *RationalRequest) String() string            { return proto.CompactTextString(m) }
+func (*RationalRequest) ProtoMessage()               {}
+func (*RationalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *RationalRequest) GetNumber1() *Rational {
+	if m != nil {
+		return m.Number1
+	}
+	return nil
+}
+
+func (m *RationalRequest) GetNumber2() *Rational {
+	if m != nil {
+		return m.Number2
+	}
+	return nil
+}
+
+func (m *RationalRequest) GetDigits() uint32 {
+	if m != nil {
+		return m.Digits
+	}
+	return 0
+}
+
+type RationalResponse struct {
+	// result is in lowest terms, its denominator positive.
+	Result *Rational `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	// decimal is result with the digits asked for, the last rounded half away from zero.
+	Decimal string `protobuf:"bytes,2,opt,name=decimal" json:"decimal,omitempty"`
+	// rounded says decimal is not exactly result.
+	Rounded bool `protobuf:"varint,3,opt,name=rounded" json:"rounded,omitempty"`
+	// mixed is result as a whole number and a proper fraction, such as "-1 1/2".
+	Mixed string `protobuf:"bytes,4,opt,name=mixed" json:"mixed,omitempty"`
+}
+
+func (m *RationalResponse) Reset()                    { *m = RationalResponse{} }
+func (m *RationalResponse) String() string            { return proto.CompactTextString(m) }
+func (*RationalResponse) ProtoMessage()               {}
+func (*RationalResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RationalResponse) GetResult() *Rational {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *RationalResponse) GetDecimal() string {
+	if m != nil {
+		return m.Decimal
+	}
+	return ""
+}
+
+func (m *RationalResponse) GetRounded() bool {
+	if m != nil {
+		return m.Rounded
+	}
+	return false
+}
+
+func (m *RationalResponse) GetMixed() string {
+	if m != nil {
+		return m.Mixed
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Rational)(nil), "services.luggage.v1.Rational")
+	proto.RegisterType((*RationalRequest)(nil), "services.luggage.v1.RationalRequest")
+	proto.RegisterType((*RationalResponse)(nil), "services.luggage.v1.RationalResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathRational service
+
+type MathRationalClient interface {
+	Add(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error)
+	Subtract(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error)
+	Multiply(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error)
+	// Divide fails with InvalidArgument when number2 is zero.
+	Divide(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error)
+}
+
+type mathRationalClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathRationalClient(cc *grpc.ClientConn) MathRationalClient {
+	return &mathRationalClient{cc}
+}
+
+func (c *mathRationalClient) Add(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error) {
+	out := new(RationalResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathRational/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathRationalClient) Subtract(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error) {
+	out := new(RationalResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathRational/Subtract", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathRationalClient) Multiply(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error) {
+	out := new(RationalResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathRational/Multiply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mathRationalClient) Divide(ctx context.Context, in *RationalRequest, opts ...grpc.CallOption) (*RationalResponse, error) {
+	out := new(RationalResponse)
+	err := grpc.Invoke(ctx, "/services.luggage.v1.MathRational/Divide", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MathRational service
+
+type MathRationalServer interface {
+	Add(context.Context, *RationalRequest) (*RationalResponse, error)
+	Subtract(context.Context, *RationalRequest) (*RationalResponse, error)
+	Multiply(context.Context, *RationalRequest) (*RationalResponse, error)
+	// Divide fails with InvalidArgument when number2 is zero.
+	Divide(context.Context, *RationalRequest) (*RationalResponse, error)
+}
+
+func RegisterMathRationalServer(s *grpc.Server, srv MathRationalServer) {
+	s.RegisterService(&_MathRational_serviceDesc, srv)
+}
+
+func _MathRational_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RationalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathRationalServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathRational/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathRationalServer).Add(ctx, req.(*RationalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathRational_Subtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RationalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathRationalServer).Subtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathRational/Subtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathRationalServer).Subtract(ctx, req.(*RationalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathRational_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RationalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathRationalServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathRational/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathRationalServer).Multiply(ctx, req.(*RationalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MathRational_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RationalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MathRationalServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/services.luggage.v1.MathRational/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MathRationalServer).Divide(ctx, req.(*RationalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MathRational_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathRational",
+	HandlerType: (*MathRationalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _MathRational_Add_Handler,
+		},
+		{
+			MethodName: "Subtract",
+			Handler:    _MathRational_Subtract_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _MathRational_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _MathRational_Divide_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services_rational_v1/rational_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_rational_v1/rational_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0xcd, 0x4a, 0xf3, 0x40,
+	0x14, 0xfd, 0xd2, 0x7e, 0xa6, 0xed, 0xad, 0xa2, 0x8c, 0xa5, 0x04, 0x51, 0x28, 0x41, 0xa5, 0xab,
+	0x48, 0x23, 0xe2, 0x5a, 0x71, 0x25, 0x74, 0x13, 0x05, 0xd1, 0x4d, 0x99, 0x76, 0x2e, 0x71, 0x20,
+	0x99, 0xa9, 0xf3, 0x13, 0xf4, 0x39, 0x5c, 0xfa, 0x98, 0xbe, 0x80, 0x38, 0x4d, 0xda, 0x2e, 0x8a,
+	0x3f, 0x50, 0x77, 0x73, 0xee, 0x3d, 0xe7, 0xcc, 0x99, 0x3b, 0x5c, 0x38, 0xd6, 0xa8, 0x0a, 0x3e,
+	0x41, 0x3d, 0x52, 0xd4, 0x70, 0x29, 0x68, 0x36, 0x2a, 0x06, 0x27, 0x4b, 0xe7, 0x68, 0xaa, 0xa4,
+	0x91, 0x64, 0xb7, 0xe2, 0x45, 0x99, 0x4d, 0x53, 0x9a, 0x62, 0x54, 0x0c, 0xc2, 0x6b, 0x68, 0x26,
+	0x25, 0x93, 0xec, 0x43, 0x4b, 0xd8, 0x1c, 0x15, 0x35, 0x52, 0x05, 0x5e, 0xcf, 0xeb, 0xb7, 0x92,
+	0x45, 0x81, 0xf4, 0xa0, 0xcd, 0x50, 0xc8, 0x9c, 0x0b, 0xd7, 0xaf, 0xb9, 0xfe, 0x72, 0x29, 0x7c,
+	0xf3, 0x60, 0xbb, 0x32, 0x4b, 0xf0, 0xc9, 0xa2, 0x36, 0xe4, 0x1c, 0x1a, 0xc2, 0xe6, 0x63, 0x54,
+	0x03, 0xe7, 0xd8, 0x8e, 0x0f, 0xa2, 0x15, 0x31, 0xa2, 0xb9, 0xac, 0x62, 0x2f, 0x84, 0x71, 0x50,
+	0xfb, 0x85, 0x30, 0x26, 0x5d, 0xf0, 0x19, 0x4f, 0xb9, 0xd1, 0x41, 0xbd, 0xe7, 0xf5, 0xb7, 0x92,
+	0x12, 0x85, 0xaf, 0x1e, 0xec, 0x2c, 0xd2, 0xe9, 0xa9, 0x14, 0x1a, 0xc9, 0x19, 0xf8, 0x0a, 0xb5,
+	0xcd, 0xcc, 0xcf, 0xd2, 0x95, 0x64, 0x12, 0x40, 0x83, 0xe1, 0x84, 0xe7, 0x34, 0x2b, 0xe7, 0x50,
+	0xc1, 0xcf, 0x8e, 0x92, 0x56, 0x30, 0x64, 0xee, 0xfa, 0x66, 0x52, 0x41, 0xd2, 0x81, 0x8d, 0x9c,
+	0x3f, 0x23, 0x0b, 0xfe, 0x3b, 0xc5, 0x0c, 0xc4, 0xef, 0x35, 0xd8, 0x1c, 0x52, 0xf3, 0x38, 0xff,
+	0x84, 0x5b, 0xa8, 0x5f, 0x30, 0x46, 0x0e, 0xbf, 0x0e, 0x32, 0x9b, 0xee, 0xde, 0xd1, 0x37, 0xac,
+	0xd9, 0x2b, 0xc3, 0x7f, 0xe4, 0x1e, 0x9a, 0x37, 0x76, 0x6c, 0x14, 0x9d, 0x98, 0x3f, 0xb0, 0x1e,
+	0xda, 0xcc, 0xf0, 0x69, 0xf6, 0xb2, 0x6e, 0xeb, 0x3b, 0xf0, 0xaf, 0x78, 0xc1, 0x19, 0xae, 0xd9,
+	0xf8, 0xb2, 0xfb, 0xd0, 0x59, 0xb5, 0x34, 0x63, 0xdf, 0x6d, 0xca, 0xe9, 0xc7, 0x00, 0xc2, 0xd2,
+	0xa5, 0x5c, 0x53, 0x03, 0x00, 0x00,
+}
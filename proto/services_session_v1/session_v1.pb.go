@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: services_session_v1/session_v1.proto
+
+/*
+Package services_session_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+
+	services_session_v1/session_v1.proto
+
+It has these top-level messages:
+
+	SessionCommand
+	SessionState
+*/
+package services_session_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_rpc "google.golang.org/genproto/googleapis/rpc/status"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type SessionCommand struct {
+	Command string `protobuf:"bytes,1,opt,name=command" json:"command,omitempty"`
+}
+
+func (m *SessionCommand) Reset()                    { *m = SessionCommand{} }
+func (m *SessionCommand) String() string            { return proto.CompactTextString(m) }
+func (*SessionCommand) ProtoMessage()               {}
+func (*SessionCommand) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *SessionCommand) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+type SessionState struct {
+	// command is the command this answers, as it was sent.
+	Command     string  `protobuf:"bytes,1,opt,name=command" json:"command,omitempty"`
+	Accumulator float64 `protobuf:"fixed64,2,opt,name=accumulator" json:"accumulator,omitempty"`
+	// error is why the command was refused, with the same code and details a Math method
+	// would have returned.  The state is then unchanged, and the session goes on.
+	Error *google_rpc.Status `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *SessionState) Reset()                    { *m = SessionState{} }
+func (m *SessionState) String() string            { return proto.CompactTextString(m) }
+func (*SessionState) ProtoMessage()               {}
+func (*SessionState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SessionState) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *SessionState) GetAccumulator() float64 {
+	if m != nil {
+		return m.Accumulator
+	}
+	return 0
+}
+
+func (m *SessionState) GetError() *google_rpc.Status {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SessionCommand)(nil), "services.luggage.v1.SessionCommand")
+	proto.RegisterType((*SessionState)(nil), "services.luggage.v1.SessionState")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MathSession service
+
+type MathSessionClient interface {
+	// Session applies each command to the accumulator, which starts at 0, and answers every
+	// command with the state after it, in order.  The commands are:
+	//
+	//   add X, multiply X, divide X   apply the Math method to the accumulator and X
+	//   set X                         make the accumulator X
+	//   clear                         make the accumulator 0
+	//   store R                       copy the accumulator into register R
+	//   recall R                      copy register R into the accumulator
+	//   undo                          take back the last command that changed anything
+	//   transcript                    write the session to the server's history when it ends
+	//
+	// X is a number or a register, R a name such as M1.  The server ends a session that
+	// sends no command for its idle timeout with DeadlineExceeded.
+	Session(ctx context.Context, opts ...grpc.CallOption) (MathSession_SessionClient, error)
+}
+
+type mathSessionClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMathSessionClient(cc *grpc.ClientConn) MathSessionClient {
+	return &mathSessionClient{cc}
+}
+
+func (c *mathSessionClient) Session(ctx context.Context, opts ...grpc.CallOption) (MathSession_SessionClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_MathSession_serviceDesc.Streams[0], c.cc, "/services.luggage.v1.MathSession/Session", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mathSessionSessionClient{stream}
+	return x, nil
+}
+
+type MathSession_SessionClient interface {
+	Send(*SessionCommand) error
+	Recv() (*SessionState, error)
+	grpc.ClientStream
+}
+
+type mathSessionSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *mathSessionSessionClient) Send(m *SessionCommand) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mathSessionSessionClient) Recv() (*SessionState, error) {
+	m := new(SessionState)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for MathSession service
+
+type MathSessionServer interface {
+	// Session applies each command to the accumulator, which starts at 0, and answers every
+	// command with the state after it, in order.  The commands are:
+	//
+	//   add X, multiply X, divide X   apply the Math method to the accumulator and X
+	//   set X                         make the accumulator X
+	//   clear                         make the accumulator 0
+	//   store R                       copy the accumulator into register R
+	//   recall R                      copy register R into the accumulator
+	//   undo                          take back the last command that changed anything
+	//   transcript                    write the session to the server's history when it ends
+	//
+	// X is a number or a register, R a name such as M1.  The server ends a session that
+	// sends no command for its idle timeout with DeadlineExceeded.
+	Session(MathSession_SessionServer) error
+}
+
+func RegisterMathSessionServer(s *grpc.Server, srv MathSessionServer) {
+	s.RegisterService(&_MathSession_serviceDesc, srv)
+}
+
+func _MathSession_Session_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MathSessionServer).Session(&mathSessionSessionServer{stream})
+}
+
+type MathSession_SessionServer interface {
+	Send(*SessionState) error
+	Recv() (*SessionCommand, error)
+	grpc.ServerStream
+}
+
+type mathSessionSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *mathSessionSessionServer) Send(m *SessionState) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mathSessionSessionServer) Recv() (*SessionCommand, error) {
+	m := new(SessionCommand)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _MathSession_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "services.luggage.v1.MathSession",
+	HandlerType: (*MathSessionServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Session",
+			Handler:       _MathSession_Session_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "services_session_v1/session_v1.proto",
+}
+
+func init() { proto.RegisterFile("services_session_v1/session_v1.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x90, 0x41, 0x4b, 0xc3, 0x40,
+	0x10, 0x85, 0x5d, 0x45, 0x8b, 0x1b, 0xf1, 0xb0, 0x45, 0x0c, 0x3d, 0xc5, 0xe8, 0x61, 0xf1, 0x30,
+	0xb1, 0xf5, 0x1f, 0xe8, 0xd9, 0x4b, 0x72, 0x10, 0xbc, 0x94, 0x75, 0x5d, 0xd7, 0x42, 0xd2, 0x09,
+	0xb3, 0xb3, 0xf9, 0xfd, 0x42, 0xb6, 0x41, 0x85, 0xda, 0xdb, 0x9b, 0xe1, 0x63, 0xe6, 0xbd, 0x27,
+	0xef, 0x82, 0xa3, 0x61, 0x63, 0x5d, 0x58, 0x07, 0x17, 0xc2, 0x06, 0xb7, 0xeb, 0x61, 0x59, 0xfd,
+	0x48, 0xe8, 0x09, 0x19, 0xd5, 0x7c, 0xa2, 0xa0, 0x8d, 0xde, 0x1b, 0xef, 0x60, 0x58, 0x2e, 0xae,
+	0x3d, 0xa2, 0x6f, 0x5d, 0x45, 0xbd, 0xad, 0x02, 0x1b, 0x8e, 0x21, 0xd1, 0xe5, 0xbd, 0xbc, 0x6c,
+	0xd2, 0x85, 0x67, 0xec, 0x3a, 0xb3, 0xfd, 0x50, 0xb9, 0x9c, 0xd9, 0x24, 0x73, 0x51, 0x08, 0x7d,
+	0x5e, 0x4f, 0x63, 0xc9, 0xf2, 0x62, 0xc7, 0x36, 0x6c, 0xd8, 0xfd, 0x4f, 0xaa, 0x42, 0x66, 0xc6,
+	0xda, 0xd8, 0xc5, 0xd6, 0x30, 0x52, 0x7e, 0x5c, 0x08, 0x2d, 0xea, 0xdf, 0x2b, 0xa5, 0xe5, 0xa9,
+	0x23, 0x42, 0xca, 0x4f, 0x0a, 0xa1, 0xb3, 0x95, 0x82, 0x64, 0x10, 0xa8, 0xb7, 0xd0, 0x8c, 0x06,
+	0xeb, 0x04, 0xac, 0x3e, 0x65, 0xf6, 0x62, 0xf8, 0x6b, 0xf7, 0x59, 0xbd, 0xca, 0xd9, 0x24, 0x6f,
+	0x61, 0x4f, 0x54, 0xf8, 0x1b, 0x67, 0x71, 0x73, 0x08, 0x1a, 0x73, 0x94, 0x47, 0x5a, 0x3c, 0x88,
+	0xa7, 0xab, 0xb7, 0xf9, 0x9e, 0x7e, 0xdf, 0xcf, 0xc6, 0x9e, 0x1e, 0xbf, 0x07, 0x00, 0x1c, 0xfc,
+	0x0d, 0xc5, 0x7d, 0x01, 0x00, 0x00,
+}